@@ -0,0 +1,105 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package affinity routes requests to backends by an affinity key, such
+// as a user ID, so that all requests for the same key reach the same
+// backend as long as it is available.
+//
+// A Picker is immutable and safe for concurrent use, which matches the
+// model of load balancers such as the one of gRPC, where a new picker
+// is built whenever the set of ready backends changes. A gRPC picker is
+// a thin adapter around it:
+//
+//	func (p *grpcPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+//		md, _ := metadata.FromOutgoingContext(info.Ctx)
+//		var key string
+//		if v := md.Get("x-user-id"); len(v) > 0 {
+//			key = v[0]
+//		}
+//		sc, err := p.affinity.Pick([]byte(key))
+//		if err != nil {
+//			return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+//		}
+//		return balancer.PickResult{SubConn: sc}, nil
+//	}
+//
+// where p.affinity is a *Picker[balancer.SubConn] built from the ready
+// SubConns keyed by their addresses.
+package affinity
+
+import (
+	"errors"
+
+	"github.com/caoimhechaos/golang-nzaat/rendezvous"
+	"github.com/caoimhechaos/golang-nzaat/ring"
+)
+
+// ErrNoBackend is returned by Pick if there are no backends.
+var ErrNoBackend = errors.New("affinity: no backend available")
+
+// Router assigns keys to node names. It is implemented by
+// *rendezvous.Table and *ring.Ring.
+type Router interface {
+	Pick(key []byte) (string, bool)
+}
+
+// Options select how keys are assigned to backends.
+type Options struct {
+	// Ring selects a consistent hash ring instead of rendezvous
+	// hashing. Rendezvous hashing spreads keys more evenly, the ring
+	// picks in logarithmic rather than linear time in the number of
+	// backends.
+	Ring bool
+
+	// Replicas is the number of points per backend on the ring.
+	// Defaults to ring.DefaultReplicas.
+	Replicas int
+}
+
+// Picker picks the backend of type B for an affinity key.
+type Picker[B any] struct {
+	router   Router
+	backends map[string]B
+}
+
+// NewPicker returns a Picker choosing between the backends, which are
+// identified by a stable name such as their address.
+func NewPicker[B any](backends map[string]B, opts Options) *Picker[B] {
+	var p *Picker[B] = &Picker[B]{backends: make(map[string]B, len(backends))}
+	var names []string = make([]string, 0, len(backends))
+
+	for name, b := range backends {
+		p.backends[name] = b
+		names = append(names, name)
+	}
+
+	if opts.Ring {
+		p.router = ring.New(opts.Replicas, names...)
+	} else {
+		p.router = rendezvous.New(names...)
+	}
+	return p
+}
+
+// NewRouterPicker returns a Picker using router to choose between the
+// backends. The names known to router must be keys of backends.
+func NewRouterPicker[B any](router Router, backends map[string]B) *Picker[B] {
+	return &Picker[B]{router: router, backends: backends}
+}
+
+// Pick returns the backend for key.
+func (p *Picker[B]) Pick(key []byte) (B, error) {
+	var zero B
+
+	name, ok := p.router.Pick(key)
+	if !ok {
+		return zero, ErrNoBackend
+	}
+	b, ok := p.backends[name]
+	if !ok {
+		return zero, ErrNoBackend
+	}
+	return b, nil
+}
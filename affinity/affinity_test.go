@@ -0,0 +1,49 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package affinity
+
+import (
+	"strconv"
+	"testing"
+)
+
+type backend struct {
+	addr string
+}
+
+// Test that both strategies route consistently to all backends.
+func TestPicker(t *testing.T) {
+	var backends map[string]*backend = map[string]*backend{
+		"10.0.0.1:443": {"10.0.0.1:443"},
+		"10.0.0.2:443": {"10.0.0.2:443"},
+		"10.0.0.3:443": {"10.0.0.3:443"},
+	}
+
+	for _, opts := range []Options{{}, {Ring: true}} {
+		var p *Picker[*backend] = NewPicker(backends, opts)
+		var q *Picker[*backend] = NewPicker(backends, opts)
+		var seen map[string]bool = make(map[string]bool)
+
+		for i := 0; i < 100; i++ {
+			var key []byte = []byte(strconv.Itoa(i))
+			b, err := p.Pick(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c, _ := q.Pick(key); c != b {
+				t.Errorf("%+v: key %d went to %s and %s", opts, i, b.addr, c.addr)
+			}
+			seen[b.addr] = true
+		}
+		if len(seen) != 3 {
+			t.Errorf("%+v: only %d backends used", opts, len(seen))
+		}
+	}
+
+	if _, err := NewPicker(map[string]int{}, Options{}).Pick([]byte("x")); err != ErrNoBackend {
+		t.Errorf("Expected ErrNoBackend, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package antientropy finds the key ranges in which two replicas of a
+// key-value store differ, by comparing Merkle trees over their
+// contents, as the first step of replica reconciliation.
+//
+// Keys are placed in a space of 2³² positions by their NZAAT checksum,
+// and a Tree of depth d splits that space into 2ᵈ leaf ranges. Every
+// leaf holds an order-independent hash of the key-value pairs in its
+// range, an nzaat.Multiset, so the tree can be built by scanning the
+// store in any order and kept up to date as pairs are added and
+// removed. Every inner node is the NZAAT checksum of its two children.
+//
+// Diff descends the trees of both replicas from the root, asking the
+// peer only for the children of nodes which differ, so replicas which
+// mostly agree exchange a few hashes per divergent range. The peer is
+// reached through the Peer interface, which a Tree implements itself;
+// the transport is up to the caller: serve Tree.Nodes on the remote
+// side and implement Peer as a client calling it.
+package antientropy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// MaxDepth is the largest depth of a Tree.
+const MaxDepth = 24
+
+// ErrProtocol is returned, wrapped, by Diff if the peer answers with
+// the wrong number of hashes.
+var ErrProtocol = errors.New("antientropy: bad answer from peer")
+
+// Peer answers queries for the nodes of its tree.
+type Peer interface {
+	// Nodes returns the hashes of the nodes at the given indexes of a
+	// level of the tree. Level 0 is the root, and level d holds the 2ᵈ
+	// nodes at depth d.
+	Nodes(level int, indexes []int) ([]uint32, error)
+}
+
+// Range is an inclusive range of key positions.
+type Range struct {
+	Start uint32
+	End   uint32
+}
+
+// Contains reports whether the key is in the range.
+func (r Range) Contains(key []byte) bool {
+	var pos uint32 = Position(key)
+	return r.Start <= pos && pos <= r.End
+}
+
+// Position returns the position of key in the key space.
+func Position(key []byte) uint32 {
+	return nzaat.Checksum(key)
+}
+
+// Tree is a Merkle tree over the key-value pairs of a store. It is not
+// safe for concurrent use.
+type Tree struct {
+	depth  int
+	leaves []nzaat.Multiset
+
+	// levels caches the hashes of all levels. It is recomputed when
+	// dirty is set.
+	levels [][]uint32
+	dirty  bool
+}
+
+// New returns an empty tree with 2^depth leaves. It panics if depth is
+// negative or greater than MaxDepth.
+func New(depth int) *Tree {
+	if depth < 0 || depth > MaxDepth {
+		panic(fmt.Sprintf("antientropy: depth %d out of range", depth))
+	}
+	return &Tree{depth: depth, leaves: make([]nzaat.Multiset, 1<<depth), dirty: true}
+}
+
+// Depth returns the depth of the tree.
+func (t *Tree) Depth() int {
+	return t.depth
+}
+
+// leaf returns the index of the leaf holding key.
+func (t *Tree) leaf(key []byte) int {
+	return int(uint64(Position(key)) >> (32 - t.depth))
+}
+
+// pair returns the element representing a key-value pair.
+func pair(key, value []byte) []byte {
+	var b []byte = binary.AppendUvarint(nil, uint64(len(key)))
+	return append(append(b, key...), value...)
+}
+
+// Add adds a key-value pair to the tree.
+func (t *Tree) Add(key, value []byte) {
+	t.leaves[t.leaf(key)].Add(pair(key, value))
+	t.dirty = true
+}
+
+// Remove removes a key-value pair which was added before, such as the
+// old value of a key which was overwritten.
+func (t *Tree) Remove(key, value []byte) {
+	t.leaves[t.leaf(key)].Remove(pair(key, value))
+	t.dirty = true
+}
+
+// update recomputes the hashes of all levels if needed.
+func (t *Tree) update() {
+	if !t.dirty {
+		return
+	}
+
+	t.levels = make([][]uint32, t.depth+1)
+	t.levels[t.depth] = make([]uint32, len(t.leaves))
+	for i := range t.leaves {
+		t.levels[t.depth][i] = t.leaves[i].Sum32()
+	}
+	for l := t.depth - 1; l >= 0; l-- {
+		var below []uint32 = t.levels[l+1]
+		t.levels[l] = make([]uint32, len(below)/2)
+		for i := range t.levels[l] {
+			var b [8]byte
+			binary.BigEndian.PutUint32(b[:4], below[2*i])
+			binary.BigEndian.PutUint32(b[4:], below[2*i+1])
+			t.levels[l][i] = nzaat.Checksum(b[:])
+		}
+	}
+	t.dirty = false
+}
+
+// Root returns the hash of the root of the tree, which is the same for
+// two stores exactly if their contents are, barring collisions.
+func (t *Tree) Root() uint32 {
+	t.update()
+	return t.levels[0][0]
+}
+
+// Nodes returns the hashes of the nodes at the given indexes of a level,
+// as Peer describes it.
+func (t *Tree) Nodes(level int, indexes []int) ([]uint32, error) {
+	if level < 0 || level > t.depth {
+		return nil, fmt.Errorf("antientropy: level %d out of range", level)
+	}
+
+	t.update()
+	var hashes []uint32 = make([]uint32, len(indexes))
+	for i, idx := range indexes {
+		if idx < 0 || idx >= len(t.levels[level]) {
+			return nil, fmt.Errorf("antientropy: node %d out of range at level %d", idx, level)
+		}
+		hashes[i] = t.levels[level][idx]
+	}
+	return hashes, nil
+}
+
+// LeafRange returns the range of key positions of the leaf i.
+func (t *Tree) LeafRange(i int) Range {
+	var size uint64 = 1 << (32 - t.depth)
+	return Range{Start: uint32(uint64(i) * size), End: uint32(uint64(i)*size + size - 1)}
+}
+
+// Diff compares the tree of the local replica with that of peer, which
+// must have the same depth, and returns the ranges of key positions in
+// which they differ, in order and with adjacent ranges merged.
+func Diff(local *Tree, peer Peer) ([]Range, error) {
+	var indexes []int = []int{0}
+
+	for level := 0; level <= local.depth && len(indexes) > 0; level++ {
+		mine, err := local.Nodes(level, indexes)
+		if err != nil {
+			return nil, err
+		}
+		theirs, err := peer.Nodes(level, indexes)
+		if err != nil {
+			return nil, err
+		}
+		if len(theirs) != len(indexes) {
+			return nil, fmt.Errorf("%w: %d hashes for %d nodes", ErrProtocol, len(theirs), len(indexes))
+		}
+
+		var next []int
+		for i, idx := range indexes {
+			if mine[i] == theirs[i] {
+				continue
+			}
+			if level == local.depth {
+				next = append(next, idx)
+			} else {
+				next = append(next, 2*idx, 2*idx+1)
+			}
+		}
+		indexes = next
+	}
+
+	var ranges []Range
+	for _, leaf := range indexes {
+		var r Range = local.LeafRange(leaf)
+		if n := len(ranges) - 1; n >= 0 && uint64(ranges[n].End)+1 == uint64(r.Start) {
+			ranges[n].End = r.End
+		} else {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges, nil
+}
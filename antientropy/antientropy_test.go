@@ -0,0 +1,133 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package antientropy
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// countingPeer counts the hashes requested from a Tree.
+type countingPeer struct {
+	*Tree
+	hashes int
+}
+
+func (p *countingPeer) Nodes(level int, indexes []int) ([]uint32, error) {
+	p.hashes += len(indexes)
+	return p.Tree.Nodes(level, indexes)
+}
+
+// build returns a tree of the keys 0 to n-1 with the value v.
+func build(depth, n int, v string) *Tree {
+	var t *Tree = New(depth)
+	for i := 0; i < n; i++ {
+		t.Add([]byte(strconv.Itoa(i)), []byte(v))
+	}
+	return t
+}
+
+// Test that identical replicas have no differences, built in any order.
+func TestIdentical(t *testing.T) {
+	var a *Tree = build(10, 1000, "v")
+	var b *Tree = New(10)
+	for i := 999; i >= 0; i-- {
+		b.Add([]byte(strconv.Itoa(i)), []byte("v"))
+	}
+
+	if a.Root() != b.Root() {
+		t.Errorf("Roots differ: %08x, %08x", a.Root(), b.Root())
+	}
+	var peer *countingPeer = &countingPeer{Tree: b}
+	ranges, err := Diff(a, peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != 0 || peer.hashes != 1 {
+		t.Errorf("Got %d ranges after %d hashes, expected none after 1", len(ranges), peer.hashes)
+	}
+}
+
+// Test that the divergent keys are found in the returned ranges.
+func TestDiff(t *testing.T) {
+	var a *Tree = build(12, 10000, "v")
+	var b *Tree = build(12, 10000, "v")
+	var changed []string = []string{"17", "4242", "9999"}
+
+	for _, k := range changed {
+		b.Remove([]byte(k), []byte("v"))
+		b.Add([]byte(k), []byte("w"))
+	}
+	b.Add([]byte("new"), nil)
+	changed = append(changed, "new")
+
+	var peer *countingPeer = &countingPeer{Tree: b}
+	ranges, err := Diff(a, peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != len(changed) {
+		t.Errorf("Got %d ranges, expected %d: %v", len(ranges), len(changed), ranges)
+	}
+	for _, k := range changed {
+		var found bool
+		for _, r := range ranges {
+			found = found || r.Contains([]byte(k))
+		}
+		if !found {
+			t.Errorf("Key %s is in none of the ranges", k)
+		}
+	}
+	if peer.hashes > 2*12*len(changed)+1 {
+		t.Errorf("Diff requested %d hashes", peer.hashes)
+	}
+
+	// Undoing the changes makes the trees equal again.
+	for _, k := range changed[:3] {
+		b.Remove([]byte(k), []byte("w"))
+		b.Add([]byte(k), []byte("v"))
+	}
+	b.Remove([]byte("new"), nil)
+	if a.Root() != b.Root() {
+		t.Error("Roots differ after undoing the changes")
+	}
+}
+
+// Test leaf ranges and merging of adjacent ranges.
+func TestRanges(t *testing.T) {
+	var tr *Tree = New(2)
+	if r := tr.LeafRange(3); r.Start != 0xc0000000 || r.End != 0xffffffff {
+		t.Errorf("Got range %08x-%08x", r.Start, r.End)
+	}
+
+	ranges, err := Diff(New(0), New(0))
+	if err != nil || len(ranges) != 0 {
+		t.Errorf("Got %v, %v", ranges, err)
+	}
+	var a *Tree = New(0)
+	a.Add([]byte("x"), nil)
+	ranges, err = Diff(a, New(0))
+	if err != nil || len(ranges) != 1 || ranges[0] != (Range{0, 0xffffffff}) {
+		t.Errorf("Got %v, %v, expected the whole key space", ranges, err)
+	}
+}
+
+type shortPeer struct{}
+
+func (shortPeer) Nodes(level int, indexes []int) ([]uint32, error) {
+	return nil, nil
+}
+
+// Test that bad answers are detected.
+func TestProtocolError(t *testing.T) {
+	if _, err := Diff(New(4), shortPeer{}); !errors.Is(err, ErrProtocol) {
+		t.Errorf("Got %v, expected ErrProtocol", err)
+	}
+	if _, err := New(4).Nodes(5, []int{0}); err == nil {
+		t.Error("Level beyond the depth accepted")
+	}
+}
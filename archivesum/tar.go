@@ -0,0 +1,153 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package archivesum stores NZAAT checksums of the entries of tar and
+// zip archives inside the archives themselves, and verifies them when
+// the entries are read back.
+//
+// In tar archives, the checksum is kept in a PAX extended header record
+// with the key PAXKey and the checksum in the format of
+// nzaat.FormatSum as its value. In zip archives, it is kept in an extra
+// field with the ID ZipExtraID holding the checksum as a little endian
+// 32 bit integer. Tools which don't know the record or field ignore it,
+// so the archives remain readable by any tar or zip implementation.
+package archivesum
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// PAXKey is the key of the PAX record holding the checksum of an entry.
+const PAXKey = "NZAAT.sum"
+
+var (
+	// ErrMismatch is returned, wrapped, when the contents of an entry
+	// don't match its stored checksum.
+	ErrMismatch = errors.New("archivesum: checksum mismatch")
+
+	// ErrMissing is returned, wrapped, in strict mode for an entry
+	// without a checksum.
+	ErrMissing = errors.New("archivesum: entry has no checksum")
+)
+
+// SetTarSum records sum as the checksum of the entry described by hdr.
+func SetTarSum(hdr *tar.Header, sum uint32) {
+	if hdr.PAXRecords == nil {
+		hdr.PAXRecords = make(map[string]string)
+	}
+	hdr.PAXRecords[PAXKey] = nzaat.FormatSum(sum)
+}
+
+// TarSum returns the checksum recorded for the entry described by hdr.
+// It returns false if there is none, and an error wrapping
+// nzaat.ErrInvalidSum if it is malformed.
+func TarSum(hdr *tar.Header) (uint32, bool, error) {
+	s, ok := hdr.PAXRecords[PAXKey]
+	if !ok {
+		return 0, false, nil
+	}
+	sum, err := nzaat.ParseSum(s)
+	if err != nil {
+		return 0, false, fmt.Errorf("archivesum: %s: %w", hdr.Name, err)
+	}
+	return sum, true, nil
+}
+
+// TarWriter writes tar archives whose regular files carry their NZAAT
+// checksums. All methods of tar.Writer are available; headers written
+// through WriteHeader are passed on unchanged.
+type TarWriter struct {
+	*tar.Writer
+}
+
+// NewTarWriter returns a TarWriter writing an archive to w.
+func NewTarWriter(w io.Writer) *TarWriter {
+	return &TarWriter{Writer: tar.NewWriter(w)}
+}
+
+// WriteFile adds a regular file described by hdr with the contents
+// read from r, starting at its current offset. Since the checksum must
+// be written ahead of the data, r is read twice: once to compute the
+// checksum and the size, which replace those in hdr, and again from
+// the same offset to copy the data.
+func (w *TarWriter) WriteFile(hdr *tar.Header, r io.ReadSeeker) error {
+	var d nzaat.Digest
+
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(&d, r)
+	if err != nil {
+		return err
+	}
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+
+	hdr.Size = n
+	SetTarSum(hdr, d.Sum32())
+	if err = w.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.CopyN(w, r, n)
+	return err
+}
+
+// TarReader reads a tar archive and verifies the checksums of its
+// entries. The checksum of an entry is compared once its contents have
+// been read up to the end, so the read reaching the end returns an
+// error wrapping ErrMismatch instead of io.EOF for a damaged entry.
+// Entries which are skipped by calling Next early are not verified.
+type TarReader struct {
+	tr     *tar.Reader
+	strict bool
+	hdr    *tar.Header
+	want   uint32
+	check  bool
+	d      nzaat.Digest
+}
+
+// NewTarReader returns a TarReader reading the archive from r. If
+// strict is set, regular files without a checksum fail with
+// ErrMissing when they are read; otherwise they are read unverified.
+func NewTarReader(r io.Reader, strict bool) *TarReader {
+	return &TarReader{tr: tar.NewReader(r), strict: strict}
+}
+
+// Next advances to the next entry of the archive, like tar.Reader.Next.
+func (r *TarReader) Next() (*tar.Header, error) {
+	hdr, err := r.tr.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	r.hdr = hdr
+	r.d.Reset()
+	if r.want, r.check, err = TarSum(hdr); err != nil {
+		return nil, err
+	}
+	return hdr, nil
+}
+
+// Read reads from the current entry, verifying its checksum at the end.
+func (r *TarReader) Read(p []byte) (int, error) {
+	if r.hdr != nil && !r.check && r.strict && r.hdr.Typeflag == tar.TypeReg {
+		return 0, fmt.Errorf("%w: %s", ErrMissing, r.hdr.Name)
+	}
+
+	n, err := r.tr.Read(p)
+	r.d.Write(p[:n])
+	if err == io.EOF && r.check && r.d.Sum32() != r.want {
+		return n, fmt.Errorf("%w: %s: checksum %s, expected %s", ErrMismatch, r.hdr.Name,
+			nzaat.FormatSum(r.d.Sum32()), nzaat.FormatSum(r.want))
+	}
+	return n, err
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package archivesum
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// writeTar returns an archive with a directory and two files.
+func writeTar(t *testing.T) []byte {
+	var buf bytes.Buffer
+	var w *TarWriter = NewTarWriter(&buf)
+
+	if err := w.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dir/hello", "dir/abc"} {
+		var data string = strings.Repeat(name, 100)
+		if err := w.WriteFile(&tar.Header{Name: name, Mode: 0644}, strings.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readAll reads every entry of the archive and returns the first error.
+func readAll(archive []byte, strict bool) error {
+	var r *TarReader = NewTarReader(bytes.NewReader(archive), strict)
+	for {
+		if _, err := r.Next(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return err
+		}
+	}
+}
+
+// Test that an intact archive verifies and is readable by archive/tar.
+func TestTar(t *testing.T) {
+	var archive []byte = writeTar(t)
+
+	if err := readAll(archive, true); err != nil {
+		t.Errorf("Reading intact archive: %v", err)
+	}
+
+	var tr *tar.Reader = tar.NewReader(bytes.NewReader(archive))
+	tr.Next()
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum, ok, err := TarSum(hdr); !ok || err != nil || hdr.Size != 900 {
+		t.Errorf("Header of %s has sum %08x, %v, %v and size %d", hdr.Name, sum, ok, err, hdr.Size)
+	}
+}
+
+// Test that files are written from the current offset of their reader.
+func TestTarWriteFileOffset(t *testing.T) {
+	var buf bytes.Buffer
+	var w *TarWriter = NewTarWriter(&buf)
+	var r *strings.Reader = strings.NewReader("skipped contents")
+
+	r.Seek(8, io.SeekStart)
+	if err := w.WriteFile(&tar.Header{Name: "file", Mode: 0644}, r); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	var tr *TarReader = NewTarReader(bytes.NewReader(buf.Bytes()), true)
+	if _, err := tr.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := io.ReadAll(tr); err != nil || string(data) != "contents" {
+		t.Errorf("Read back %q, %v", data, err)
+	}
+}
+
+// Test that damaged contents and missing checksums are detected.
+func TestTarDamage(t *testing.T) {
+	var archive []byte = writeTar(t)
+	var i int = bytes.LastIndex(archive, []byte("dir/abc"))
+
+	archive[i] ^= 1
+	if err := readAll(archive, false); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Reading damaged archive: %v", err)
+	}
+
+	var buf bytes.Buffer
+	var w *tar.Writer = tar.NewWriter(&buf)
+	w.WriteHeader(&tar.Header{Name: "plain", Mode: 0644, Size: 1})
+	w.Write([]byte("x"))
+	w.Close()
+	if err := readAll(buf.Bytes(), false); err != nil {
+		t.Errorf("Reading archive without checksums: %v", err)
+	}
+	if err := readAll(buf.Bytes(), true); !errors.Is(err, ErrMissing) {
+		t.Errorf("Strictly reading archive without checksums: %v", err)
+	}
+}
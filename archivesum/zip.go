@@ -0,0 +1,142 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package archivesum
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// ZipExtraID is the ID of the zip extra field holding the checksum of
+// an entry. It spells "NZ" and lies outside the ranges reserved by the
+// zip specification.
+const ZipExtraID = 0x5a4e
+
+// zipExtraSize is the size of the data of the checksum field.
+const zipExtraSize = 4
+
+// SetZipSum records sum as the checksum of the entry described by fh,
+// replacing any checksum recorded before.
+func SetZipSum(fh *zip.FileHeader, sum uint32) {
+	var extra []byte
+	for b := fh.Extra; len(b) >= 4; {
+		var size int = 4 + int(binary.LittleEndian.Uint16(b[2:]))
+		if size > len(b) {
+			break
+		}
+		if binary.LittleEndian.Uint16(b) != ZipExtraID {
+			extra = append(extra, b[:size]...)
+		}
+		b = b[size:]
+	}
+
+	extra = binary.LittleEndian.AppendUint16(extra, ZipExtraID)
+	extra = binary.LittleEndian.AppendUint16(extra, zipExtraSize)
+	fh.Extra = binary.LittleEndian.AppendUint32(extra, sum)
+}
+
+// ZipSum returns the checksum recorded for the entry described by fh.
+// It returns false if there is none, and an error wrapping
+// nzaat.ErrInvalidSum if the field is malformed.
+func ZipSum(fh *zip.FileHeader) (uint32, bool, error) {
+	for b := fh.Extra; len(b) >= 4; {
+		var id uint16 = binary.LittleEndian.Uint16(b)
+		var size int = int(binary.LittleEndian.Uint16(b[2:]))
+		if 4+size > len(b) {
+			break
+		}
+		if id == ZipExtraID {
+			if size != zipExtraSize {
+				return 0, false, fmt.Errorf("archivesum: %s: %w: extra field of %d bytes",
+					fh.Name, nzaat.ErrInvalidSum, size)
+			}
+			return binary.LittleEndian.Uint32(b[4:]), true, nil
+		}
+		b = b[4+size:]
+	}
+	return 0, false, nil
+}
+
+// ZipWriter writes zip archives whose files carry their NZAAT checksums
+// in an extra field. All methods of zip.Writer are available; files
+// created through them get no checksum.
+type ZipWriter struct {
+	*zip.Writer
+}
+
+// NewZipWriter returns a ZipWriter writing an archive to w.
+func NewZipWriter(w io.Writer) *ZipWriter {
+	return &ZipWriter{Writer: zip.NewWriter(w)}
+}
+
+// WriteFile adds a file described by fh with the contents read from r,
+// starting at its current offset. Like TarWriter.WriteFile, it reads r
+// twice, since the checksum is written ahead of the data.
+func (w *ZipWriter) WriteFile(fh *zip.FileHeader, r io.ReadSeeker) error {
+	var d nzaat.Digest
+
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(&d, r); err != nil {
+		return err
+	}
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+
+	SetZipSum(fh, d.Sum32())
+	fw, err := w.CreateHeader(fh)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, r)
+	return err
+}
+
+// zipFile verifies the checksum of a zip entry while it is read.
+type zipFile struct {
+	io.ReadCloser
+	name string
+	want uint32
+	d    nzaat.Digest
+}
+
+func (f *zipFile) Read(p []byte) (int, error) {
+	n, err := f.ReadCloser.Read(p)
+	f.d.Write(p[:n])
+	if err == io.EOF && f.d.Sum32() != f.want {
+		return n, fmt.Errorf("%w: %s: checksum %s, expected %s", ErrMismatch, f.name,
+			nzaat.FormatSum(f.d.Sum32()), nzaat.FormatSum(f.want))
+	}
+	return n, err
+}
+
+// OpenZip opens the file f of a zip archive for reading, verifying its
+// checksum like TarReader: the read reaching the end returns an error
+// wrapping ErrMismatch for damaged contents. If strict is set, files
+// without a checksum fail to open with ErrMissing; otherwise they are
+// read unverified, apart from the CRC-32 check of archive/zip.
+func OpenZip(f *zip.File, strict bool) (io.ReadCloser, error) {
+	sum, ok, err := ZipSum(&f.FileHeader)
+	if err != nil {
+		return nil, err
+	}
+	if !ok && strict && f.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %s", ErrMissing, f.Name)
+	}
+
+	rc, err := f.Open()
+	if err != nil || !ok {
+		return rc, err
+	}
+	return &zipFile{ReadCloser: rc, name: f.Name, want: sum}, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package archivesum
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// writeZip returns an archive with two files carrying checksums and
+// one without.
+func writeZip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	var w *ZipWriter = NewZipWriter(&buf)
+
+	for _, name := range []string{"hello", "abc"} {
+		var fh *zip.FileHeader = &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Unix(1e9, 0)}
+		if err := w.WriteFile(fh, strings.NewReader(strings.Repeat(name, 100))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fw, err := w.Create("plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte("x"))
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readZip reads every file of the archive and returns the first error.
+func readZip(archive []byte, strict bool) error {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		rc, err := OpenZip(f, strict)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(io.Discard, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Test that intact archives verify, that other extra fields are kept,
+// and that files without checksums are only rejected in strict mode.
+func TestZip(t *testing.T) {
+	var archive []byte = writeZip(t)
+
+	if err := readZip(archive, false); err != nil {
+		t.Errorf("Reading intact archive: %v", err)
+	}
+	if err := readZip(archive, true); !errors.Is(err, ErrMissing) {
+		t.Errorf("Strictly reading archive with a plain file: %v", err)
+	}
+
+	zr, _ := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if sum, ok, err := ZipSum(&zr.File[1].FileHeader); !ok || err != nil ||
+		sum != nzaat.Checksum([]byte(strings.Repeat("abc", 100))) {
+		t.Errorf("Checksum of abc is %08x, %v, %v", sum, ok, err)
+	}
+	if !zr.File[0].Modified.Equal(time.Unix(1e9, 0)) {
+		t.Errorf("Modification time is %v", zr.File[0].Modified)
+	}
+}
+
+// Test that files are written from the current offset of their reader.
+func TestZipWriteFileOffset(t *testing.T) {
+	var buf bytes.Buffer
+	var w *ZipWriter = NewZipWriter(&buf)
+	var r *strings.Reader = strings.NewReader("skipped contents")
+
+	r.Seek(8, io.SeekStart)
+	if err := w.WriteFile(&zip.FileHeader{Name: "file"}, r); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := OpenZip(zr.File[0], true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if data, err := io.ReadAll(rc); err != nil || string(data) != "contents" {
+		t.Errorf("Read back %q, %v", data, err)
+	}
+}
+
+// Test that a wrong checksum is detected and that SetZipSum replaces
+// an earlier checksum.
+func TestZipMismatch(t *testing.T) {
+	var archive []byte = writeZip(t)
+	var field []byte = []byte{0x4e, 0x5a, zipExtraSize, 0}
+
+	// Change the copies in both the local and the central header.
+	for i := 0; ; i++ {
+		var j int = bytes.Index(archive[i:], field)
+		if j < 0 {
+			break
+		}
+		i += j
+		archive[i+len(field)] ^= 1
+	}
+	if err := readZip(archive, false); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Reading archive with a wrong checksum: %v", err)
+	}
+
+	var fh zip.FileHeader
+	SetZipSum(&fh, 1)
+	SetZipSum(&fh, 2)
+	if sum, ok, _ := ZipSum(&fh); !ok || sum != 2 || len(fh.Extra) != 8 {
+		t.Errorf("Checksum is %d, %v with %d bytes of extra fields", sum, ok, len(fh.Extra))
+	}
+}
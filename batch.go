@@ -0,0 +1,83 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// batchChunk is the number of items a worker of ChecksumBatch takes at
+// once, large enough to keep the coordination cost low for tiny items.
+const batchChunk = 256
+
+// checksum4 computes the checksums of four items at once, hashing the
+// length they have in common in parallel lanes and the rest one by one.
+func checksum4(items [][]byte, sums []uint32) {
+	var s [4]uint32
+	var n int = min(len(items[0]), len(items[1]), len(items[2]), len(items[3]))
+
+	if n > 0 {
+		update4(&s, items[0][:n], items[1], items[2], items[3])
+	}
+	for j := range s {
+		var d Digest = Digest(update(s[j], items[j][n:]))
+		sums[j] = d.Sum32()
+	}
+}
+
+// ChecksumBatch returns the NZAAT checksums of items, in the same order,
+// computed by up to parallelism goroutines. A parallelism of 0 or less
+// uses GOMAXPROCS goroutines. Every goroutine hashes four items at a
+// time in parallel lanes, using SIMD instructions where available.
+func ChecksumBatch(items [][]byte, parallelism int) []uint32 {
+	var sums []uint32 = make([]uint32, len(items))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	parallelism = min(parallelism, (len(items)+batchChunk-1)/batchChunk)
+
+	var work = func() {
+		for {
+			var end int = int(next.Add(batchChunk))
+			var start int = end - batchChunk
+			if start >= len(items) {
+				return
+			}
+			end = min(end, len(items))
+			for i := start; i < end; {
+				if end-i >= 4 {
+					checksum4(items[i:i+4], sums[i:i+4])
+					i += 4
+					continue
+				}
+				var d Digest
+				d.Write(items[i])
+				sums[i] = d.Sum32()
+				i++
+			}
+		}
+	}
+
+	if parallelism <= 1 {
+		work()
+		return sums
+	}
+
+	wg.Add(parallelism)
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			defer wg.Done()
+			work()
+		}()
+	}
+	wg.Wait()
+	return sums
+}
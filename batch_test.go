@@ -0,0 +1,47 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test that the batch results match Checksum for every parallelism.
+func TestChecksumBatch(t *testing.T) {
+	var items [][]byte = make([][]byte, 1000)
+	for i := range items {
+		items[i] = []byte(strconv.Itoa(i))
+	}
+
+	for _, p := range []int{-1, 0, 1, 3, 100} {
+		var sums []uint32 = ChecksumBatch(items, p)
+		if len(sums) != len(items) {
+			t.Fatalf("Parallelism %d: got %d sums", p, len(sums))
+		}
+		for i, sum := range sums {
+			if sum != Checksum(items[i]) {
+				t.Fatalf("Parallelism %d: wrong sum for item %d", p, i)
+			}
+		}
+	}
+
+	if sums := ChecksumBatch(nil, 4); len(sums) != 0 {
+		t.Errorf("Got %d sums for no items", len(sums))
+	}
+}
+
+func BenchmarkChecksumBatch(b *testing.B) {
+	var items [][]byte = make([][]byte, 4096)
+	for i := range items {
+		items[i] = make([]byte, 64)
+	}
+
+	b.SetBytes(int64(len(items) * 64))
+	for i := 0; i < b.N; i++ {
+		ChecksumBatch(items, 1)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/fnv"
+	"hash/maphash"
+	"strconv"
+	"testing"
+)
+
+// benchSizes are the input lengths the comparison benchmarks run on,
+// from single octets up to 1 MiB.
+var benchSizes = []int{1, 8, 16, 64, 512, 4 << 10, 64 << 10, 1 << 20}
+
+// benchHashes are the checksums compared against each other. Each
+// function must return the 32-bit sum of p.
+var benchHashes = []struct {
+	name string
+	sum  func(p []byte) uint32
+}{
+	{"nzaat", Checksum},
+	{"nzat", ChecksumNZAT},
+	{"crc32c", func(p []byte) uint32 {
+		return crc32.Checksum(p, castagnoli)
+	}},
+	{"fnv1a", func(p []byte) uint32 {
+		var h hash.Hash32 = benchFNV
+		h.Reset()
+		h.Write(p)
+		return h.Sum32()
+	}},
+	{"adler32", adler32.Checksum},
+	{"maphash", func(p []byte) uint32 {
+		return uint32(maphash.Bytes(benchSeed, p))
+	}},
+}
+
+var (
+	castagnoli *crc32.Table = crc32.MakeTable(crc32.Castagnoli)
+	benchFNV   hash.Hash32  = fnv.New32a()
+	benchSeed  maphash.Seed = maphash.MakeSeed()
+)
+
+// BenchmarkCompare hashes inputs of every size in benchSizes with
+// NZAAT and the hashes of the standard library, reporting throughput
+// and allocations, e.g.
+//
+//	go test -run - -bench Compare/size=64/ -benchmem
+func BenchmarkCompare(b *testing.B) {
+	var buf []byte = make([]byte, benchSizes[len(benchSizes)-1])
+	for i := range buf {
+		buf[i] = byte(i * 7)
+	}
+
+	for _, size := range benchSizes {
+		for _, h := range benchHashes {
+			b.Run("size="+strconv.Itoa(size)+"/"+h.name, func(b *testing.B) {
+				var p []byte = buf[:size]
+
+				b.SetBytes(int64(size))
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					updateSink = h.sum(p)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package bloom implements Bloom filters with NZAAT. The k bit
+// positions of a key are derived by double hashing from two seeded
+// NZAAT checksums of the key, so a filter is fully described by its
+// size, its number of hash functions and its seed, and filters with the
+// same parameters are compatible across processes.
+package bloom
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// secondSeed is xored into the seed for the second hash of a key.
+const secondSeed = 0x626c6f6d
+
+// Filter is a Bloom filter. It is not safe for concurrent use while it
+// is being changed.
+type Filter struct {
+	bits   []byte
+	m      uint64
+	k      int
+	seed   uint32
+	frozen bool
+
+	// prefix1 and prefix2 are the states after absorbing the two seeds.
+	prefix1, prefix2 nzaat.Digest
+}
+
+// New returns an empty filter of m bits using k hash functions. It
+// panics if m or k are not positive.
+func New(m uint64, k int) *Filter {
+	return NewSeeded(m, k, 0)
+}
+
+// NewSeeded returns an empty filter like New, whose hash functions are
+// selected by seed.
+func NewSeeded(m uint64, k int, seed uint32) *Filter {
+	if m == 0 || k <= 0 {
+		panic("bloom: size and number of hash functions must be positive")
+	}
+	return newFilter(make([]byte, (m+7)/8), m, k, seed)
+}
+
+func newFilter(b []byte, m uint64, k int, seed uint32) *Filter {
+	var f *Filter = &Filter{bits: b, m: m, k: k, seed: seed}
+	f.prefix1.WriteUint32(seed, binary.BigEndian)
+	f.prefix2.WriteUint32(seed^secondSeed, binary.BigEndian)
+	return f
+}
+
+// Bits returns the size of the filter in bits.
+func (f *Filter) Bits() uint64 {
+	return f.m
+}
+
+// Hashes returns the number of hash functions of the filter.
+func (f *Filter) Hashes() int {
+	return f.k
+}
+
+// Seed returns the seed of the hash functions of the filter.
+func (f *Filter) Seed() uint32 {
+	return f.seed
+}
+
+// hashes returns the two hashes of key positions are derived from.
+func (f *Filter) hashes(key []byte) (uint64, uint64) {
+	var d1, d2 nzaat.Digest = f.prefix1, f.prefix2
+	d1.Write(key)
+	d2.Write(key)
+	return uint64(d1.Sum32())<<32 | uint64(d2.Sum32()), uint64(d2.Sum32())<<32 | uint64(d1.Sum32()) | 1
+}
+
+// position returns the i'th bit position for the hashes h1 and h2.
+func (f *Filter) position(h1, h2 uint64, i int) uint64 {
+	hi, _ := bits.Mul64(h1+uint64(i)*h2, f.m)
+	return hi
+}
+
+// Add adds key to the filter. It panics on filters returned by View.
+func (f *Filter) Add(key []byte) {
+	if f.frozen {
+		panic("bloom: Add on a read-only filter")
+	}
+
+	var h1, h2 uint64 = f.hashes(key)
+	for i := 0; i < f.k; i++ {
+		var p uint64 = f.position(h1, h2, i)
+		f.bits[p>>3] |= 1 << (p & 7)
+	}
+}
+
+// AddString adds the bytes of key to the filter.
+func (f *Filter) AddString(key string) {
+	f.Add([]byte(key))
+}
+
+// Test reports whether key may have been added to the filter. If it
+// returns false, key was definitely not added.
+func (f *Filter) Test(key []byte) bool {
+	var h1, h2 uint64 = f.hashes(key)
+	for i := 0; i < f.k; i++ {
+		var p uint64 = f.position(h1, h2, i)
+		if f.bits[p>>3]&(1<<(p&7)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestString reports whether the bytes of key may have been added.
+func (f *Filter) TestString(key string) bool {
+	return f.Test([]byte(key))
+}
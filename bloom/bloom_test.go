@@ -0,0 +1,50 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package bloom
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test that added keys are found and the false positive rate is close
+// to the expected one.
+func TestFilter(t *testing.T) {
+	// 10 bits per key and 7 hashes give a false positive rate of 0.8%.
+	var f *Filter = New(100000, 7)
+
+	for i := 0; i < 10000; i++ {
+		f.AddString("key" + strconv.Itoa(i))
+	}
+	for i := 0; i < 10000; i++ {
+		if !f.TestString("key" + strconv.Itoa(i)) {
+			t.Fatalf("Key %d not found", i)
+		}
+	}
+
+	var fp int
+	for i := 0; i < 100000; i++ {
+		if f.TestString("other" + strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	if fp > 1200 {
+		t.Errorf("Got %d false positives of 100000, expected about 820", fp)
+	}
+}
+
+// Test that the seed selects different hash functions.
+func TestSeed(t *testing.T) {
+	var a, b *Filter = NewSeeded(1000, 3, 1), NewSeeded(1000, 3, 2)
+	a.AddString("x")
+	b.AddString("x")
+	if string(a.bits) == string(b.bits) {
+		t.Error("Seeds 1 and 2 set the same bits")
+	}
+	if a.Seed() != 1 || a.Bits() != 1000 || a.Hashes() != 3 {
+		t.Errorf("Got parameters %d, %d, %d", a.Seed(), a.Bits(), a.Hashes())
+	}
+}
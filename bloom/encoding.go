@@ -0,0 +1,104 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Version is the version of the encoding written by MarshalBinary.
+const Version = 1
+
+const (
+	magic      = "NZBF"
+	headerSize = 24
+)
+
+var (
+	// ErrFormat is returned, wrapped, when decoding malformed data.
+	ErrFormat = errors.New("bloom: malformed filter")
+
+	// ErrVersion is returned, wrapped, when decoding a filter of an
+	// unknown version.
+	ErrVersion = errors.New("bloom: unsupported version")
+
+	// ErrChecksum is returned when the checksum of an encoded filter
+	// does not match.
+	ErrChecksum = errors.New("bloom: checksum mismatch")
+)
+
+// MarshalBinary encodes the filter. The encoding is the magic number
+// "NZBF", the version octet, three reserved zero octets, the number of
+// hash functions and the seed as 4 little-endian octets each, and the
+// number of bits as 8 little-endian octets, followed by the bit array,
+// in which bit i is bit i mod 8 of octet i/8, and finally the NZAAT
+// checksum of everything before it as 4 big-endian octets.
+//
+// The header is 24 octets long, so the bit array of a filter stored at
+// an aligned offset is aligned as well, and View can use an encoded
+// filter in place, for example from a memory-mapped file.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	var b []byte = make([]byte, headerSize, headerSize+len(f.bits)+4)
+
+	copy(b, magic)
+	b[4] = Version
+	binary.LittleEndian.PutUint32(b[8:], uint32(f.k))
+	binary.LittleEndian.PutUint32(b[12:], f.seed)
+	binary.LittleEndian.PutUint64(b[16:], f.m)
+	b = append(b, f.bits...)
+	return binary.BigEndian.AppendUint32(b, nzaat.Checksum(b)), nil
+}
+
+// decode checks an encoded filter and returns its parameters and bits.
+func decode(data []byte) ([]byte, uint64, int, uint32, error) {
+	if len(data) < headerSize+4 || string(data[:4]) != magic {
+		return nil, 0, 0, 0, fmt.Errorf("%w: bad header", ErrFormat)
+	}
+	if data[4] != Version {
+		return nil, 0, 0, 0, fmt.Errorf("%w: %d", ErrVersion, data[4])
+	}
+
+	var k uint32 = binary.LittleEndian.Uint32(data[8:])
+	var seed uint32 = binary.LittleEndian.Uint32(data[12:])
+	var m uint64 = binary.LittleEndian.Uint64(data[16:])
+	if m == 0 || k == 0 || k > 1<<16 || (m+7)/8 != uint64(len(data)-headerSize-4) {
+		return nil, 0, 0, 0, fmt.Errorf("%w: %d bits and %d hashes in %d octets", ErrFormat, m, k, len(data))
+	}
+
+	var n int = len(data) - 4
+	if nzaat.Checksum(data[:n]) != binary.BigEndian.Uint32(data[n:]) {
+		return nil, 0, 0, 0, ErrChecksum
+	}
+	return data[headerSize:n], m, int(k), seed, nil
+}
+
+// UnmarshalBinary replaces f by the filter encoded in data, as written
+// by MarshalBinary.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	b, m, k, seed, err := decode(data)
+	if err != nil {
+		return err
+	}
+	*f = *newFilter(append([]byte(nil), b...), m, k, seed)
+	return nil
+}
+
+// View returns a read-only filter using the encoded filter in data in
+// place, without copying the bit array. data must not be changed while
+// the filter is in use. Add panics on the returned filter.
+func View(data []byte) (*Filter, error) {
+	b, m, k, seed, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	var f *Filter = newFilter(b, m, k, seed)
+	f.frozen = true
+	return f, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package bloom
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// Test that encoded filters decode to equivalent filters.
+func TestMarshal(t *testing.T) {
+	var f *Filter = NewSeeded(1234, 5, 42)
+	for i := 0; i < 100; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != headerSize+155+4 {
+		t.Errorf("Got %d octets, expected %d", len(data), headerSize+155+4)
+	}
+
+	var g Filter
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	v, err := View(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []*Filter{&g, v} {
+		if h.Bits() != 1234 || h.Hashes() != 5 || h.Seed() != 42 {
+			t.Errorf("Got parameters %d, %d, %d", h.Bits(), h.Hashes(), h.Seed())
+		}
+		for i := 0; i < 100; i++ {
+			if !h.TestString(strconv.Itoa(i)) {
+				t.Fatalf("Key %d not found after decoding", i)
+			}
+		}
+	}
+
+	g.AddString("more")
+	if !g.TestString("more") {
+		t.Error("Decoded filter can't be added to")
+	}
+}
+
+// Test that views are read-only.
+func TestViewReadOnly(t *testing.T) {
+	data, _ := New(64, 2).MarshalBinary()
+	v, err := View(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Add on a view did not panic")
+		}
+	}()
+	v.AddString("x")
+}
+
+// Test that damaged and unknown encodings are rejected.
+func TestUnmarshalErrors(t *testing.T) {
+	data, _ := New(64, 2).MarshalBinary()
+	var f Filter
+
+	var damaged []byte = append([]byte(nil), data...)
+	damaged[headerSize] ^= 1
+	if err := f.UnmarshalBinary(damaged); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Damaged bits: got %v, expected ErrChecksum", err)
+	}
+
+	var version []byte = append([]byte(nil), data...)
+	version[4] = 2
+	if err := f.UnmarshalBinary(version); !errors.Is(err, ErrVersion) {
+		t.Errorf("Version 2: got %v, expected ErrVersion", err)
+	}
+
+	for _, d := range [][]byte{nil, data[:20], data[:len(data)-1], []byte("XXXX" + string(data[4:]))} {
+		if err := f.UnmarshalBinary(d); !errors.Is(err, ErrFormat) {
+			t.Errorf("Got %v, expected ErrFormat", err)
+		}
+	}
+}
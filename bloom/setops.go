@@ -0,0 +1,76 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package bloom
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrIncompatible is returned when combining filters which differ in
+// size, number of hash functions or seed.
+var ErrIncompatible = errors.New("bloom: incompatible filters")
+
+// compatible returns ErrIncompatible unless f and g have the same
+// parameters.
+func (f *Filter) compatible(g *Filter) error {
+	if f.m != g.m || f.k != g.k || f.seed != g.seed {
+		return ErrIncompatible
+	}
+	return nil
+}
+
+// Union adds all keys of g to f, so that f tests positive for every key
+// added to either filter. This merges filters of the keys seen at
+// different places without exchanging the keys. It panics on filters
+// returned by View.
+func (f *Filter) Union(g *Filter) error {
+	if f.frozen {
+		panic("bloom: Union on a read-only filter")
+	}
+	if err := f.compatible(g); err != nil {
+		return err
+	}
+	for i := range f.bits {
+		f.bits[i] |= g.bits[i]
+	}
+	return nil
+}
+
+// Intersect clears all bits of f which are not set in g, so that f
+// tests positive for every key added to both filters. The result has a
+// higher false positive rate than a filter built from the intersection
+// of the keys. It panics on filters returned by View.
+func (f *Filter) Intersect(g *Filter) error {
+	if f.frozen {
+		panic("bloom: Intersect on a read-only filter")
+	}
+	if err := f.compatible(g); err != nil {
+		return err
+	}
+	for i := range f.bits {
+		f.bits[i] &= g.bits[i]
+	}
+	return nil
+}
+
+// EstimateCardinality estimates the number of distinct keys added to
+// the filter from the number of bits set, X, as -(m/k)·ln(1 - X/m). The
+// estimate is +Inf if all bits are set.
+func (f *Filter) EstimateCardinality() float64 {
+	var m float64 = float64(f.m)
+	return -m / float64(f.k) * math.Log1p(-float64(f.ones())/m)
+}
+
+// ones returns the number of bits set in the filter.
+func (f *Filter) ones() uint64 {
+	var x int
+	for _, b := range f.bits {
+		x += bits.OnesCount8(b)
+	}
+	return uint64(x)
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package bloom
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// fill returns a filter of the keys from to to-1.
+func fill(from, to int) *Filter {
+	var f *Filter = NewSeeded(50000, 5, 9)
+	for i := from; i < to; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	return f
+}
+
+// Test union, intersection and the cardinality estimates.
+func TestUnionIntersect(t *testing.T) {
+	var a, b *Filter = fill(0, 3000), fill(2000, 5000)
+
+	var u *Filter = fill(0, 0)
+	if err := u.Union(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.Union(b); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5000; i++ {
+		if !u.TestString(strconv.Itoa(i)) {
+			t.Fatalf("Key %d missing from the union", i)
+		}
+	}
+	if n := u.EstimateCardinality(); math.Abs(n-5000) > 150 {
+		t.Errorf("Union: estimated %g keys, expected about 5000", n)
+	}
+
+	if err := a.Intersect(b); err != nil {
+		t.Fatal(err)
+	}
+	for i := 2000; i < 3000; i++ {
+		if !a.TestString(strconv.Itoa(i)) {
+			t.Fatalf("Key %d missing from the intersection", i)
+		}
+	}
+	var fp int
+	for i := 0; i < 2000; i++ {
+		if a.TestString(strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	if fp > 100 {
+		t.Errorf("%d of 2000 keys only in one filter are in the intersection", fp)
+	}
+}
+
+// Test the estimate of empty and full filters.
+func TestEstimateCardinalityExtremes(t *testing.T) {
+	if n := New(100, 3).EstimateCardinality(); n != 0 {
+		t.Errorf("Empty filter: estimated %g keys", n)
+	}
+	var f *Filter = New(8, 1)
+	f.bits[0] = 0xff
+	if n := f.EstimateCardinality(); !math.IsInf(n, 1) {
+		t.Errorf("Full filter: estimated %g keys, expected +Inf", n)
+	}
+}
+
+// Test that filters with different parameters are not combined.
+func TestIncompatible(t *testing.T) {
+	for _, g := range []*Filter{New(100, 3), NewSeeded(100, 4, 1), NewSeeded(101, 3, 1)} {
+		if err := NewSeeded(100, 3, 1).Union(g); !errors.Is(err, ErrIncompatible) {
+			t.Errorf("Union: got %v, expected ErrIncompatible", err)
+		}
+		if err := NewSeeded(100, 3, 1).Intersect(g); !errors.Is(err, ErrIncompatible) {
+			t.Errorf("Intersect: got %v, expected ErrIncompatible", err)
+		}
+	}
+}
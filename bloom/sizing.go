@@ -0,0 +1,55 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package bloom
+
+import "math"
+
+// OptimalParameters returns the number of bits m and hash functions k
+// of the smallest filter holding n keys with a false positive rate of
+// p: m = ⌈-n·ln(p) / ln(2)²⌉ and k = round(m/n · ln(2)). Since k must
+// be an integer, the resulting rate can be slightly above p. It panics
+// unless 0 < p < 1.
+func OptimalParameters(n uint64, p float64) (uint64, int) {
+	if !(p > 0 && p < 1) {
+		panic("bloom: false positive rate must be between 0 and 1")
+	}
+	if n == 0 {
+		n = 1
+	}
+
+	var m float64 = math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	var k float64 = math.Round(m / float64(n) * math.Ln2)
+	return uint64(m), max(int(k), 1)
+}
+
+// NewWithEstimate returns an empty filter sized by OptimalParameters
+// for n keys and a false positive rate of p.
+func NewWithEstimate(n uint64, p float64) *Filter {
+	m, k := OptimalParameters(n, p)
+	return New(m, k)
+}
+
+// FalsePositiveRate returns the expected false positive rate of a
+// filter of m bits with k hash functions after adding n distinct keys,
+// (1 - e^(-kn/m))^k.
+func FalsePositiveRate(m uint64, k int, n uint64) float64 {
+	return math.Pow(-math.Expm1(-float64(k)*float64(n)/float64(m)), float64(k))
+}
+
+// FillRatio returns the fraction of the bits of the filter which are
+// set.
+func (f *Filter) FillRatio() float64 {
+	return float64(f.ones()) / float64(f.m)
+}
+
+// EstimateFalsePositiveRate estimates the current false positive rate
+// of the filter from its fill ratio r as r^k, the probability that all
+// k bits probed for a key which was never added are set. Unlike
+// FalsePositiveRate it needs no count of the keys added, so it can be
+// used to monitor a live filter.
+func (f *Filter) EstimateFalsePositiveRate() float64 {
+	return math.Pow(f.FillRatio(), float64(f.k))
+}
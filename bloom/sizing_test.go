@@ -0,0 +1,61 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package bloom
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// Test the parameters for well-known configurations.
+func TestOptimalParameters(t *testing.T) {
+	for _, c := range []struct {
+		n uint64
+		p float64
+		m uint64
+		k int
+	}{
+		{1000, 0.01, 9586, 7},
+		{1000000, 0.001, 14377588, 10},
+		{100, 0.5, 145, 1},
+	} {
+		if m, k := OptimalParameters(c.n, c.p); m != c.m || k != c.k {
+			t.Errorf("n = %d, p = %g: got m = %d, k = %d, expected m = %d, k = %d", c.n, c.p, m, k, c.m, c.k)
+		}
+	}
+	if m, k := OptimalParameters(1000, 0.01); FalsePositiveRate(m, k, 1000) > 0.0101 {
+		t.Errorf("Optimal filter has a false positive rate of %g", FalsePositiveRate(m, k, 1000))
+	}
+}
+
+// Test that the estimated and measured false positive rates of a
+// filter filled to capacity agree with the target.
+func TestEstimateFalsePositiveRate(t *testing.T) {
+	var f *Filter = NewWithEstimate(10000, 0.02)
+	var fp int
+
+	if res := f.EstimateFalsePositiveRate(); res != 0 {
+		t.Errorf("Empty filter estimated at %g", res)
+	}
+	for i := 0; i < 10000; i++ {
+		f.AddString("key-" + strconv.Itoa(i))
+	}
+	for i := 0; i < 100000; i++ {
+		if f.TestString("other-" + strconv.Itoa(i)) {
+			fp++
+		}
+	}
+
+	var est float64 = f.EstimateFalsePositiveRate()
+	var measured float64 = float64(fp) / 100000
+	if math.Abs(est-0.02) > 0.004 || math.Abs(measured-0.02) > 0.004 {
+		t.Errorf("Estimated %g, measured %g, expected about 0.02", est, measured)
+	}
+	if r := f.FillRatio(); math.Abs(r-0.5) > 0.05 {
+		t.Errorf("Fill ratio of a filter at capacity is %g", r)
+	}
+}
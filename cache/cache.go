@@ -0,0 +1,187 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package cache provides a concurrent LRU cache which is split into a
+// number of independent shards by the NZAAT checksum of the key, so
+// that goroutines working on different keys rarely contend for a lock.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Options describe the limits of a Cache.
+type Options struct {
+	// Shards is the number of independent LRU lists. Defaults to 16,
+	// and is reduced to MaxEntries if that is smaller.
+	Shards int
+
+	// MaxEntries is the maximum number of entries in the cache, split
+	// as evenly as possible across all shards. 0 means no limit.
+	MaxEntries int
+
+	// TTL is the time after which an entry expires. 0 means entries
+	// never expire.
+	TTL time.Duration
+
+	// RandomSeed selects shards with a randomly seeded hash, so that
+	// clients choosing the keys cannot pile them all into one shard.
+	RandomSeed bool
+}
+
+type entry[K ~string, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+type shard[K ~string, V any] struct {
+	mtx   sync.Mutex
+	items map[K]*list.Element
+	lru   *list.List
+	max   int
+}
+
+// Cache is a sharded LRU cache. It is safe for concurrent use.
+type Cache[K ~string, V any] struct {
+	shards []*shard[K, V]
+	ttl    time.Duration
+	now    func() time.Time
+	seed   nzaat.Digest
+}
+
+// New returns a new, empty Cache with the given limits.
+func New[K ~string, V any](opts Options) *Cache[K, V] {
+	var n int = opts.Shards
+	if n <= 0 {
+		n = 16
+	}
+	if opts.MaxEntries > 0 && n > opts.MaxEntries {
+		n = opts.MaxEntries
+	}
+
+	var c *Cache[K, V] = &Cache[K, V]{
+		shards: make([]*shard[K, V], n),
+		ttl:    opts.TTL,
+		now:    time.Now,
+	}
+	if opts.RandomSeed {
+		c.seed = nzaat.MakeSeed().Digest()
+	}
+
+	for i := range c.shards {
+		// Hand the remainder out one by one, so the shard limits add
+		// up to exactly MaxEntries.
+		var max int
+		if opts.MaxEntries > 0 {
+			max = opts.MaxEntries / n
+			if i < opts.MaxEntries%n {
+				max++
+			}
+		}
+
+		c.shards[i] = &shard[K, V]{
+			items: make(map[K]*list.Element),
+			lru:   list.New(),
+			max:   max,
+		}
+	}
+	return c
+}
+
+func (c *Cache[K, V]) shard(key K) *shard[K, V] {
+	var h uint32 = nzaat.Final(nzaat.UpdateString(c.seed, string(key)))
+	return c.shards[h%uint32(len(c.shards))]
+}
+
+// Get returns the value stored for key and whether it was found. The
+// entry becomes the most recently used one of its shard.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var s *shard[K, V] = c.shard(key)
+	var zero V
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	el, ok := s.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	var e *entry[K, V] = el.Value.(*entry[K, V])
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		s.remove(el)
+		return zero, false
+	}
+
+	s.lru.MoveToFront(el)
+	return e.value, true
+}
+
+// Put stores value under key, evicting the least recently used entry
+// of the shard if it is full.
+func (c *Cache[K, V]) Put(key K, value V) {
+	var s *shard[K, V] = c.shard(key)
+	var expires time.Time
+
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		var e *entry[K, V] = el.Value.(*entry[K, V])
+		e.value = value
+		e.expires = expires
+		s.lru.MoveToFront(el)
+		return
+	}
+
+	s.items[key] = s.lru.PushFront(&entry[K, V]{
+		key:     key,
+		value:   value,
+		expires: expires,
+	})
+
+	if s.max > 0 && s.lru.Len() > s.max {
+		s.remove(s.lru.Back())
+	}
+}
+
+// Delete removes key from the cache.
+func (c *Cache[K, V]) Delete(key K) {
+	var s *shard[K, V] = c.shard(key)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		s.remove(el)
+	}
+}
+
+// Len returns the number of entries in the cache, including expired
+// entries which have not been removed yet.
+func (c *Cache[K, V]) Len() int {
+	var n int
+
+	for _, s := range c.shards {
+		s.mtx.Lock()
+		n += s.lru.Len()
+		s.mtx.Unlock()
+	}
+	return n
+}
+
+func (s *shard[K, V]) remove(el *list.Element) {
+	s.lru.Remove(el)
+	delete(s.items, el.Value.(*entry[K, V]).key)
+}
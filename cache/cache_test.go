@@ -0,0 +1,118 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test that the least recently used entry is evicted first.
+func TestEviction(t *testing.T) {
+	var c *Cache[string, int] = New[string, int](Options{Shards: 1, MaxEntries: 2})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Put("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Least recently used entry was not evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Recently used entry lost, got %d", v)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+}
+
+// Test that the cache never holds more than MaxEntries entries, even
+// when they do not divide evenly across the shards.
+func TestMaxEntries(t *testing.T) {
+	for _, max := range []int{1, 5, 20, 100} {
+		var c *Cache[string, int] = New[string, int](Options{MaxEntries: max})
+		for i := 0; i < 10*max; i++ {
+			c.Put(strconv.Itoa(i), i)
+		}
+		if c.Len() > max {
+			t.Errorf("Cache with MaxEntries %d holds %d entries", max, c.Len())
+		}
+	}
+}
+
+// Test that entries expire after their TTL.
+func TestTTL(t *testing.T) {
+	var c *Cache[string, int] = New[string, int](Options{TTL: time.Minute})
+	var now time.Time = time.Unix(1000, 0)
+
+	c.now = func() time.Time { return now }
+	c.Put("a", 1)
+
+	now = now.Add(59 * time.Second)
+	if _, ok := c.Get("a"); !ok {
+		t.Error("Entry expired too early")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Entry did not expire")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expired entry was not removed")
+	}
+}
+
+// Test concurrent use from several goroutines.
+func TestConcurrent(t *testing.T) {
+	var c *Cache[string, int] = New[string, int](Options{MaxEntries: 64})
+	var wg sync.WaitGroup
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				var k string = strconv.Itoa(g*1000 + i)
+				c.Put(k, i)
+				c.Get(k)
+				c.Delete(k)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if c.Len() != 0 {
+		t.Errorf("Expected an empty cache, got %d entries", c.Len())
+	}
+}
+
+// Test that keys crafted to share a shard under the unseeded hash are
+// spread out again with a random seed.
+func TestRandomSeed(t *testing.T) {
+	var plain *Cache[string, int] = New[string, int](Options{MaxEntries: 64})
+	var seeded *Cache[string, int] = New[string, int](Options{MaxEntries: 64, RandomSeed: true})
+	var n int
+
+	for i := 0; n < 64; i++ {
+		var k string = strconv.Itoa(i)
+		if nzaat.Checksum([]byte(k))%16 == 0 {
+			plain.Put(k, i)
+			seeded.Put(k, i)
+			n++
+		}
+	}
+	if plain.Len() != 4 {
+		t.Errorf("Expected 4 entries in one shard, got %d", plain.Len())
+	}
+	if seeded.Len() < 20 {
+		t.Errorf("Only %d entries kept with a random seed", seeded.Len())
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package canonurl canonicalizes URLs and fingerprints them with NZAAT,
+// so that crawler frontiers and link databases treat different
+// spellings of the same URL as one.
+//
+// Canonicalization lower-cases the scheme and host, drops the port if
+// it is the default one of the scheme, makes an empty path "/", decodes
+// percent-encoded unreserved characters in the path, sorts the query
+// parameters by their name as written and drops the fragment. The
+// query is not decoded, so parameters without a value and characters
+// such as ';' are kept exactly. Everything else is kept as it is,
+// since servers are free to treat e.g. the case of paths as
+// significant.
+package canonurl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// ErrNotAbsolute is returned, wrapped, for URLs without a scheme or
+// host.
+var ErrNotAbsolute = errors.New("canonurl: URL is not absolute")
+
+// defaultPorts maps schemes to the port implied if none is given.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ftp":   "21",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+// Canonicalize returns the canonical form of the absolute URL raw.
+func Canonicalize(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("%w: %q", ErrNotAbsolute, raw)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	var host, port string = strings.ToLower(u.Hostname()), u.Port()
+	if port == defaultPorts[u.Scheme] {
+		port = ""
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	} else {
+		u.Host = host
+	}
+
+	if u.Path == "" {
+		u.Path = "/"
+		u.RawPath = ""
+	} else {
+		u.RawPath = normalizeEscapes(u.EscapedPath())
+	}
+
+	u.RawQuery = sortQuery(u.RawQuery)
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String(), nil
+}
+
+// Fingerprint returns the 64 bit NZAAT fingerprint, as computed by
+// nzaat.Fingerprint64, of the canonical form of raw. At the numbers
+// of URLs a crawler handles, 32 bits would collide far too often.
+func Fingerprint(raw string) (uint64, error) {
+	c, err := Canonicalize(raw)
+	if err != nil {
+		return 0, err
+	}
+	return nzaat.Fingerprint64([]byte(c)), nil
+}
+
+// sortQuery sorts the &-separated parameters of the raw query q by their
+// raw name, keeping parameters with the same name in their order, and
+// drops empty parameters.
+func sortQuery(q string) string {
+	var params []string
+	for _, p := range strings.Split(q, "&") {
+		if p != "" {
+			params = append(params, p)
+		}
+	}
+
+	sort.SliceStable(params, func(i, j int) bool {
+		a, _, _ := strings.Cut(params[i], "=")
+		b, _, _ := strings.Cut(params[j], "=")
+		return a < b
+	})
+	return strings.Join(params, "&")
+}
+
+// isUnreserved reports whether c is an unreserved character of RFC
+// 3986, which means the same whether it is percent-encoded or not.
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// normalizeEscapes decodes the percent-encoded unreserved characters in
+// the escaped path p and upper-cases the hex digits of the remaining
+// escapes, as recommended by RFC 3986, section 6.2.2.
+func normalizeEscapes(p string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(p); i++ {
+		if p[i] != '%' || i+2 >= len(p) {
+			b.WriteByte(p[i])
+			continue
+		}
+
+		var esc string = strings.ToUpper(p[i : i+3])
+		if c, err := url.PathUnescape(esc); err == nil && isUnreserved(c[0]) {
+			b.WriteByte(c[0])
+		} else {
+			b.WriteString(esc)
+		}
+		i += 2
+	}
+	return b.String()
+}
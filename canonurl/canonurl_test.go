@@ -0,0 +1,54 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package canonurl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test the canonical forms of some URLs.
+func TestCanonicalize(t *testing.T) {
+	for _, c := range []struct {
+		in, out string
+	}{
+		{"HTTP://Example.COM", "http://example.com/"},
+		{"http://example.com:80/a/B?", "http://example.com/a/B"},
+		{"https://example.com:443/#top", "https://example.com/"},
+		{"https://example.com:8443/x", "https://example.com:8443/x"},
+		{"http://example.com/s?b=2&a=1&a=0#frag", "http://example.com/s?a=1&a=0&b=2"},
+		{"http://example.com/s?b&a=1;c=2&&x=%2f", "http://example.com/s?a=1;c=2&b&x=%2f"},
+		{"http://[2001:DB8::1]:80/", "http://[2001:db8::1]/"},
+		{"http://user@Example.com/%7Euser", "http://user@example.com/~user"},
+		{"http://example.com/a%2fb%c3%a4", "http://example.com/a%2Fb%C3%A4"},
+	} {
+		res, err := Canonicalize(c.in)
+		if err != nil {
+			t.Errorf("Canonicalize(%q): %v", c.in, err)
+		} else if res != c.out {
+			t.Errorf("Canonicalize(%q) returned %q, expected %q", c.in, res, c.out)
+		}
+	}
+}
+
+// Test that equivalent URLs share a fingerprint and that relative URLs
+// are rejected.
+func TestFingerprint(t *testing.T) {
+	a, _ := Fingerprint("http://example.com:80/?y=1&x=2#a")
+	b, _ := Fingerprint("HTTP://EXAMPLE.com/?x=2&y=1")
+
+	if a != b {
+		t.Errorf("Equivalent URLs have fingerprints %016x and %016x", a, b)
+	}
+	if want := nzaat.Fingerprint64([]byte("http://example.com/?x=2&y=1")); a != want {
+		t.Errorf("Fingerprint returned %016x, expected %016x", a, want)
+	}
+	if _, err := Fingerprint("/relative/path"); !errors.Is(err, ErrNotAbsolute) {
+		t.Errorf("Relative URL gave %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// libnzaat exports NZAAT as a C library, so that programs in other
+// languages compute exactly the checksums of this package. Build it with
+//
+//	go build -buildmode=c-shared -o libnzaat.so ./cmd/libnzaat
+//
+// and use it through the declarations in nzaat.h:
+//
+//	uint32_t state = nzaat_init();
+//	state = nzaat_update(state, header, header_len);
+//	state = nzaat_update(state, body, body_len);
+//	uint32_t sum = nzaat_final(state);
+//
+// The state is a plain integer, so nothing needs to be freed and the
+// functions are safe to call from any number of threads. From Python:
+//
+//	lib = ctypes.CDLL("./libnzaat.so")
+//	lib.nzaat_checksum.restype = ctypes.c_uint32
+//	lib.nzaat_checksum.argtypes = [ctypes.c_char_p, ctypes.c_size_t]
+//	lib.nzaat_checksum(b"abc", 3)  # 0xc3e39e2d
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// bytes returns the n bytes at data without copying them.
+func bytes(data unsafe.Pointer, n C.size_t) []byte {
+	if n == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(data), n)
+}
+
+//export nzaat_init
+func nzaat_init() C.uint32_t {
+	return 0
+}
+
+//export nzaat_update
+func nzaat_update(state C.uint32_t, data unsafe.Pointer, n C.size_t) C.uint32_t {
+	return C.uint32_t(nzaat.Update(nzaat.Digest(state), bytes(data, n)))
+}
+
+//export nzaat_final
+func nzaat_final(state C.uint32_t) C.uint32_t {
+	return C.uint32_t(nzaat.Final(nzaat.Digest(state)))
+}
+
+//export nzaat_final_nzat
+func nzaat_final_nzat(state C.uint32_t) C.uint32_t {
+	return C.uint32_t(nzaat.FinalNZAT(nzaat.Digest(state)))
+}
+
+//export nzaat_checksum
+func nzaat_checksum(data unsafe.Pointer, n C.size_t) C.uint32_t {
+	return C.uint32_t(nzaat.Checksum(bytes(data, n)))
+}
+
+//export nzaat_checksum_nzat
+func nzaat_checksum_nzat(data unsafe.Pointer, n C.size_t) C.uint32_t {
+	return C.uint32_t(nzaat.ChecksumNZAT(bytes(data, n)))
+}
+
+func main() {}
@@ -0,0 +1,68 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test building the shared library and calling it from C through
+// nzaat.h.
+func TestCShared(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping the C build in short mode")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("Only testing the shared library on Linux")
+	}
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("No C compiler: ", err)
+	}
+
+	var dir string = t.TempDir()
+	var lib string = filepath.Join(dir, "libnzaat.so")
+	var prog string = filepath.Join(dir, "sums")
+
+	var cmd *exec.Cmd = exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"),
+		"build", "-buildmode=c-shared", "-o", lib, ".")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Building the library: %v\n%s", err, out)
+	}
+	cmd = exec.Command(cc, "-std=c99", "-Wall", "-Werror", "-I.", "-o", prog,
+		"testdata/sums.c", "-L"+dir, "-lnzaat")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Building the C program: %v\n%s", err, out)
+	}
+
+	var inputs []string = []string{"", "a", "abc", "message digest", strings.Repeat("0123456789", 10)}
+	cmd = exec.Command(prog, inputs...)
+	cmd.Env = append(os.Environ(), "LD_LIBRARY_PATH="+dir)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Running the C program: %v", err)
+	}
+
+	var lines []string = strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(lines) != len(inputs) {
+		t.Fatalf("Got %d lines of output, expected %d:\n%s", len(lines), len(inputs), out)
+	}
+	for i, in := range inputs {
+		var sum uint32 = nzaat.Checksum([]byte(in))
+		var want string = fmt.Sprintf("%08x %08x %08x", sum, nzaat.ChecksumNZAT([]byte(in)), sum)
+		if lines[i] != want {
+			t.Errorf("%q: got %q, expected %q", in, lines[i], want)
+		}
+	}
+}
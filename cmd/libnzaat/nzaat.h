@@ -0,0 +1,44 @@
+/*
+ * Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+ * All rights reserved.
+ * Use of this source code is governed by a BSD-style license that can
+ * be found in the LICENSE file.
+ *
+ * C interface of libnzaat, built from cmd/libnzaat with
+ * go build -buildmode=c-shared.
+ */
+
+#ifndef NZAAT_H
+#define NZAAT_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Returns the initial state of a checksum computation. */
+uint32_t nzaat_init(void);
+
+/* Returns the state after absorbing the len bytes at data. */
+uint32_t nzaat_update(uint32_t state, const void *data, size_t len);
+
+/* Returns the NZAAT checksum of the input absorbed into state. */
+uint32_t nzaat_final(uint32_t state);
+
+/* Returns the NZAT checksum, which is never 0, of the input absorbed
+ * into state. */
+uint32_t nzaat_final_nzat(uint32_t state);
+
+/* Returns the NZAAT checksum of the len bytes at data. */
+uint32_t nzaat_checksum(const void *data, size_t len);
+
+/* Returns the NZAT checksum of the len bytes at data. */
+uint32_t nzaat_checksum_nzat(const void *data, size_t len);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* NZAAT_H */
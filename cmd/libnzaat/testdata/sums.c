@@ -0,0 +1,28 @@
+/*
+ * Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+ * All rights reserved.
+ * Use of this source code is governed by a BSD-style license that can
+ * be found in the LICENSE file.
+ *
+ * Prints the checksums of each argument through libnzaat, one line of
+ * NZAAT, NZAT and streamed NZAAT per argument.
+ */
+
+#include <stdio.h>
+#include <string.h>
+
+#include "nzaat.h"
+
+int main(int argc, char **argv) {
+	for (int i = 1; i < argc; i++) {
+		size_t len = strlen(argv[i]);
+		uint32_t state = nzaat_init();
+
+		state = nzaat_update(state, argv[i], len / 2);
+		state = nzaat_update(state, argv[i] + len / 2, len - len / 2);
+		printf("%08x %08x %08x\n", (unsigned)nzaat_checksum(argv[i], len),
+		    (unsigned)nzaat_checksum_nzat(argv[i], len),
+		    (unsigned)nzaat_final(state));
+	}
+	return 0;
+}
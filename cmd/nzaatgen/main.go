@@ -0,0 +1,193 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// nzaatgen precomputes the NZAAT checksums of string constants, so that
+// code dispatching on hashed keys doesn't have to hash them at run time.
+//
+// It scans the Go files of a package for constant declarations marked
+// with a //nzaat:hash directive, either on a whole const block or on a
+// single constant:
+//
+//	//nzaat:hash
+//	const (
+//		KeyUser  = "user"
+//		KeyOrder = "order"
+//	)
+//
+// and writes a file declaring a uint32 constant holding the checksum of
+// each marked string, named after the string constant with a suffix:
+//
+//	const (
+//		KeyUserHash  uint32 = 0x...
+//		KeyOrderHash uint32 = 0x...
+//	)
+//
+// It fails if two marked strings have the same checksum. Typical use is
+// a go:generate line in the package:
+//
+//	//go:generate nzaatgen
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+const directive = "//nzaat:hash"
+
+type constant struct {
+	name  string
+	value string
+	sum   uint32
+}
+
+func main() {
+	var output string
+	var suffix string
+
+	flag.StringVar(&output, "o", "nzaat_consts.go", "Name of the file to generate, relative to the package directory")
+	flag.StringVar(&suffix, "suffix", "Hash", "Suffix appended to the names of the generated constants")
+	flag.Parse()
+
+	var dir string = "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	src, err := generate(dir, filepath.Base(output), suffix)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, output), src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func marked(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// generate returns the source of the file with the constants for the
+// package in dir, ignoring the previously generated file output.
+func generate(dir, output, suffix string) ([]byte, error) {
+	var fset *token.FileSet = token.NewFileSet()
+	var consts []constant
+	var pkgName string
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		var name string = e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || name == output ||
+			strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		var f *ast.File
+		if f, err = parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments); err != nil {
+			return nil, err
+		}
+		if pkgName == "" {
+			pkgName = f.Name.Name
+		} else if f.Name.Name != pkgName {
+			return nil, fmt.Errorf("found packages %s and %s in %s", pkgName, f.Name.Name, dir)
+		}
+
+		for _, decl := range f.Decls {
+			var gd, ok = decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				var vs *ast.ValueSpec = spec.(*ast.ValueSpec)
+				if !marked(gd.Doc) && !marked(vs.Doc) {
+					continue
+				}
+				var cs []constant
+				if cs, err = specConstants(fset, vs); err != nil {
+					return nil, err
+				}
+				consts = append(consts, cs...)
+			}
+		}
+	}
+	if pkgName == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	sort.Slice(consts, func(i, j int) bool { return consts[i].name < consts[j].name })
+
+	var seen map[uint32]string = make(map[uint32]string)
+	for _, c := range consts {
+		if other, ok := seen[c.sum]; ok {
+			return nil, fmt.Errorf("%s and %s have the same checksum %08x", other, c.name, c.sum)
+		}
+		seen[c.sum] = c.name
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by nzaatgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	if len(consts) > 0 {
+		fmt.Fprintf(&buf, "// NZAAT checksums of the constants marked with %s.\n", directive)
+		fmt.Fprintf(&buf, "const (\n")
+		for _, c := range consts {
+			fmt.Fprintf(&buf, "\t%s%s uint32 = 0x%08x // %s\n", c.name, suffix, c.sum, strconv.Quote(c.value))
+		}
+		fmt.Fprintf(&buf, ")\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func specConstants(fset *token.FileSet, vs *ast.ValueSpec) ([]constant, error) {
+	var cs []constant
+
+	if len(vs.Values) != len(vs.Names) {
+		return nil, fmt.Errorf("%s: marked constants need explicit string values", fset.Position(vs.Pos()))
+	}
+
+	for i, name := range vs.Names {
+		var lit, ok = vs.Values[i].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return nil, fmt.Errorf("%s: %s is not a string literal", fset.Position(vs.Values[i].Pos()), name.Name)
+		}
+
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("%s: bad string literal", fset.Position(lit.Pos())), err)
+		}
+		if name.Name == "_" {
+			continue
+		}
+		cs = append(cs, constant{name: name.Name, value: value, sum: nzaat.Checksum([]byte(value))})
+	}
+	return cs, nil
+}
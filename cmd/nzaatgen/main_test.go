@@ -0,0 +1,49 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test generating constants for the marked declarations.
+func TestGenerate(t *testing.T) {
+	src, err := generate("testdata/keys", "nzaat_consts.go", "Hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out string = string(src)
+	for _, want := range []string{
+		"package keys",
+		"KeyABCHash   uint32 = 0xc3e39e2d // \"abc\"",
+		"KeyUserHash  uint32 = 0x",
+		"KeyOrderHash uint32 = 0x",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output does not contain %q", want)
+		}
+	}
+	if strings.Contains(out, "KeyIgnored") || strings.Contains(out, "KeyOther") {
+		t.Error("Output contains unmarked constants")
+	}
+}
+
+// Test that non-literal values are rejected.
+func TestGenerateNonLiteral(t *testing.T) {
+	var dir string = t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(
+		"package a\n\n//nzaat:hash\nconst A = \"a\" + \"b\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generate(dir, "nzaat_consts.go", "Hash"); err == nil {
+		t.Error("Non-literal constant was accepted")
+	}
+}
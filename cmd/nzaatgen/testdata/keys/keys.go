@@ -0,0 +1,16 @@
+package keys
+
+//nzaat:hash
+const (
+	KeyUser  = "user"
+	KeyOrder = "order"
+)
+
+const KeyIgnored = "ignored"
+
+const (
+	KeyOther = "other"
+
+	//nzaat:hash
+	KeyABC = "abc"
+)
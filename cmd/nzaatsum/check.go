@@ -0,0 +1,157 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+type checkOptions struct {
+	ignoreMissing bool
+	quiet         bool
+	status        bool
+	strict        bool
+}
+
+// parseLine splits a checksum line into the sum and the file name,
+// undoing the escaping of formatLine. The binary mode marker "*" of
+// md5sum is accepted in front of the name.
+func parseLine(line string) (uint32, string, bool) {
+	var escaped bool = strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+
+	sumText, name, ok := strings.Cut(line, " ")
+	if !ok || len(sumText) != 8 || len(name) < 2 || (name[0] != ' ' && name[0] != '*') {
+		return 0, "", false
+	}
+	name = name[1:]
+
+	sum, err := nzaat.ParseSum(sumText)
+	if err != nil {
+		return 0, "", false
+	}
+
+	if escaped {
+		var sb strings.Builder
+		for i := 0; i < len(name); i++ {
+			if name[i] != '\\' {
+				sb.WriteByte(name[i])
+				continue
+			}
+			if i++; i == len(name) {
+				return 0, "", false
+			}
+			switch name[i] {
+			case '\\':
+				sb.WriteByte('\\')
+			case 'n':
+				sb.WriteByte('\n')
+			default:
+				return 0, "", false
+			}
+		}
+		name = sb.String()
+	}
+
+	return sum, name, true
+}
+
+// checkList verifies the checksum lines read from the file list, or
+// stdin if list is "-", and returns the exit status.
+func checkList(newHash func() hash.Hash32, list string, stdin io.Reader, stdout, stderr io.Writer, opts checkOptions) int {
+	var r io.Reader = stdin
+	var malformed, failed, unreadable, checked int
+
+	if list != "-" {
+		f, err := os.Open(list)
+		if err != nil {
+			fmt.Fprintf(stderr, "nzaatsum: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var report = func(name, result string) {
+		if !opts.status {
+			fmt.Fprintf(stdout, "%s: %s\n", name, result)
+		}
+	}
+
+	var scanner *bufio.Scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var line string = strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		want, name, ok := parseLine(line)
+		if !ok {
+			malformed++
+			continue
+		}
+
+		sum, err := sumFile(newHash(), name, stdin)
+		if errors.Is(err, fs.ErrNotExist) && opts.ignoreMissing {
+			continue
+		}
+		checked++
+
+		switch {
+		case err != nil:
+			if !opts.status {
+				fmt.Fprintf(stderr, "nzaatsum: %v\n", err)
+			}
+			report(name, "FAILED open or read")
+			unreadable++
+		case sum != want:
+			report(name, "FAILED")
+			failed++
+		case !opts.quiet:
+			report(name, "OK")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "nzaatsum: %s: %v\n", list, err)
+		return 1
+	}
+
+	if !opts.status {
+		warn(stderr, malformed, "line is", "lines are", "improperly formatted")
+		warn(stderr, unreadable, "listed file", "listed files", "could not be read")
+		warn(stderr, failed, "computed checksum", "computed checksums", "did NOT match")
+	}
+	if checked == 0 {
+		if !opts.status {
+			fmt.Fprintf(stderr, "nzaatsum: %s: no file was verified\n", list)
+		}
+		return 1
+	}
+	if failed > 0 || unreadable > 0 || (opts.strict && malformed > 0) {
+		return 1
+	}
+	return 0
+}
+
+func warn(w io.Writer, n int, one, many, what string) {
+	switch {
+	case n == 1:
+		fmt.Fprintf(w, "nzaatsum: WARNING: 1 %s %s\n", one, what)
+	case n > 1:
+		fmt.Fprintf(w, "nzaatsum: WARNING: %d %s %s\n", n, many, what)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test parsing lines, including ones written by formatLine.
+func TestParseLine(t *testing.T) {
+	for _, name := range []string{"plain", "with space", "a\\b\nc"} {
+		sum, parsed, ok := parseLine(formatLine(0xc3e39e2d, name))
+		if !ok || sum != 0xc3e39e2d || parsed != name {
+			t.Errorf("Line for %q parsed as %08x %q %v", name, sum, parsed, ok)
+		}
+	}
+	if _, name, ok := parseLine("c3e39e2d *bin"); !ok || name != "bin" {
+		t.Errorf("Binary mode line parsed as %q %v", name, ok)
+	}
+	for _, line := range []string{"c3e39e2d", "c3e39e2d name", "xyz  name", "\\c3e39e2d  a\\"} {
+		if _, _, ok := parseLine(line); ok {
+			t.Errorf("Malformed line %q was accepted", line)
+		}
+	}
+}
+
+// Test checking a list with a good, a modified and a missing file.
+func TestCheck(t *testing.T) {
+	var dir string = t.TempDir()
+	var good string = filepath.Join(dir, "good")
+	var bad string = filepath.Join(dir, "bad")
+	var missing string = filepath.Join(dir, "missing")
+	var stdout, stderr bytes.Buffer
+
+	for _, name := range []string{good, bad} {
+		if err := os.WriteFile(name, []byte("abc"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var list string = "c3e39e2d  " + good + "\n" +
+		"00000000  " + bad + "\n" +
+		"c3e39e2d  " + missing + "\n" +
+		"garbage\n"
+
+	if status := run([]string{"-c"}, strings.NewReader(list), &stdout, &stderr); status != 1 {
+		t.Errorf("Expected exit status 1, got %d", status)
+	}
+	for _, want := range []string{good + ": OK\n", bad + ": FAILED\n", missing + ": FAILED open or read\n"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Output %q does not contain %q", stdout.String(), want)
+		}
+	}
+	for _, want := range []string{"1 line is improperly formatted", "1 listed file could not be read", "1 computed checksum did NOT match"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("Errors %q do not contain %q", stderr.String(), want)
+		}
+	}
+
+	stdout.Reset()
+	list = "c3e39e2d  " + good + "\nc3e39e2d  " + missing + "\n"
+	if status := run([]string{"-c", "-quiet", "-ignore-missing"}, strings.NewReader(list), &stdout, &stderr); status != 0 {
+		t.Errorf("Expected exit status 0, got %d", status)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Unexpected output %q in quiet mode", stdout.String())
+	}
+
+	list = "c3e39e2d  " + good + "\ngarbage\n"
+	if status := run([]string{"-c", "-strict", "-status"}, strings.NewReader(list), &stdout, &stderr); status != 1 {
+		t.Errorf("Expected exit status 1 in strict mode, got %d", status)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// nzaatsum prints the NZAAT checksums of files, in the same format as
+// md5sum and friends: one "<hex>  <name>" line per file. Without file
+// arguments, or for the argument "-", it reads standard input.
+//
+// With -c, the arguments are instead lists of checksum lines as written
+// by nzaatsum, and each listed file is hashed again and reported as OK
+// or FAILED. The exit status is 1 if any file did not match or could
+// not be read, as with coreutils.
+//
+// With -r, directory arguments are walked and every regular file below
+// them is hashed, so whole trees can be listed and later verified with
+// -c. The -include and -exclude flags, which may be repeated, restrict
+// the files by glob, and -symlinks selects how links are treated.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/caoimhechaos/golang-nzaat"
+	_ "github.com/caoimhechaos/golang-nzaat/oaat"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments and returns its
+// exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var flags *flag.FlagSet = flag.NewFlagSet("nzaatsum", flag.ContinueOnError)
+	var algorithm string
+	var opts checkOptions
+	var walk walkOptions
+	var check bool
+	var recursive bool
+	var status int
+
+	flags.SetOutput(stderr)
+	flags.StringVar(&algorithm, "a", "nzaat", "Hash to use, one of "+strings.Join(nzaat.Names(), ", "))
+	flags.BoolVar(&check, "c", false, "Read checksums from the files and check them")
+	flags.BoolVar(&opts.ignoreMissing, "ignore-missing", false, "In check mode, don't fail or report status for missing files")
+	flags.BoolVar(&opts.quiet, "quiet", false, "In check mode, don't print OK for each successfully verified file")
+	flags.BoolVar(&opts.status, "status", false, "In check mode, don't output anything, the exit status shows success")
+	flags.BoolVar(&opts.strict, "strict", false, "In check mode, exit non-zero for improperly formatted lines")
+	flags.BoolVar(&recursive, "r", false, "Hash all files in directories given as arguments")
+	flags.BoolVar(&recursive, "recursive", false, "Same as -r")
+	flags.Var(&walk.include, "include", "With -r, only hash files matching this glob")
+	flags.Var(&walk.exclude, "exclude", "With -r, skip files and directories matching this glob")
+	flags.StringVar(&walk.symlinks, "symlinks", symlinksFiles, "With -r, how to treat symbolic links: skip, files or follow")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if walk.symlinks != symlinksSkip && walk.symlinks != symlinksFiles && walk.symlinks != symlinksFollow {
+		fmt.Fprintf(stderr, "nzaatsum: unknown symlink policy %q\n", walk.symlinks)
+		return 2
+	}
+
+	newHash, ok := nzaat.Get(algorithm)
+	if !ok {
+		fmt.Fprintf(stderr, "nzaatsum: unknown hash %q\n", algorithm)
+		return 2
+	}
+
+	var names []string = flags.Args()
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+
+	if check {
+		for _, name := range names {
+			if checkList(newHash, name, stdin, stdout, stderr, opts) != 0 {
+				status = 1
+			}
+		}
+		return status
+	}
+
+	var hashFile = func(name string, err error) {
+		var sum uint32
+		if err == nil {
+			sum, err = sumFile(newHash(), name, stdin)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "nzaatsum: %v\n", err)
+			status = 1
+			return
+		}
+		fmt.Fprintln(stdout, formatLine(sum, name))
+	}
+
+	for _, name := range names {
+		if recursive {
+			walk.walk(name, hashFile)
+		} else {
+			hashFile(name, nil)
+		}
+	}
+
+	return status
+}
+
+// sumFile hashes the file name with h, or stdin if name is "-".
+func sumFile(h hash.Hash32, name string, stdin io.Reader) (uint32, error) {
+	var r io.Reader = stdin
+
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if _, err := io.Copy(h, r); err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return h.Sum32(), nil
+}
+
+// formatLine returns the checksum line for name. Like coreutils, names
+// containing backslashes or newlines are escaped and the line is then
+// prefixed with a backslash.
+func formatLine(sum uint32, name string) string {
+	if strings.ContainsAny(name, "\\\n") {
+		name = strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(name)
+		return "\\" + nzaat.FormatSum(sum) + "  " + name
+	}
+	return nzaat.FormatSum(sum) + "  " + name
+}
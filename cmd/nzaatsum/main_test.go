@@ -0,0 +1,70 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test hashing standard input.
+func TestStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if status := run(nil, strings.NewReader("abc"), &stdout, &stderr); status != 0 {
+		t.Fatalf("Exit status %d: %s", status, stderr.String())
+	}
+	if stdout.String() != "c3e39e2d  -\n" {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+}
+
+// Test hashing files, including a missing one.
+func TestFiles(t *testing.T) {
+	var dir string = t.TempDir()
+	var name string = filepath.Join(dir, "abc")
+	var stdout, stderr bytes.Buffer
+
+	if err := os.WriteFile(name, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var status int = run([]string{name, filepath.Join(dir, "missing")}, nil, &stdout, &stderr)
+	if status != 1 {
+		t.Errorf("Expected exit status 1, got %d", status)
+	}
+	if stdout.String() != "c3e39e2d  "+name+"\n" {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if stderr.Len() == 0 {
+		t.Error("No error reported for the missing file")
+	}
+}
+
+// Test selecting another registered hash.
+func TestAlgorithm(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if status := run([]string{"-a", "oaat"}, strings.NewReader("a"), &stdout, &stderr); status != 0 {
+		t.Fatalf("Exit status %d: %s", status, stderr.String())
+	}
+	if stdout.String() != "ca2e9442  -\n" {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if status := run([]string{"-a", "md5"}, nil, &stdout, &stderr); status != 2 {
+		t.Errorf("Expected exit status 2 for an unknown hash, got %d", status)
+	}
+}
+
+// Test escaping of names with special characters.
+func TestFormatLine(t *testing.T) {
+	if l := formatLine(0x1234, "a\\b\nc"); l != "\\00001234  a\\\\b\\nc" {
+		t.Errorf("Unexpected line %q", l)
+	}
+}
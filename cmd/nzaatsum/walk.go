@@ -0,0 +1,134 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Policies for symbolic links found while walking directories.
+const (
+	symlinksSkip   = "skip"   // Ignore all symbolic links.
+	symlinksFiles  = "files"  // Hash links to files, don't enter links to directories.
+	symlinksFollow = "follow" // Hash links to files and walk links to directories.
+)
+
+// globList is a flag which can be given several times, collecting
+// path.Match patterns.
+type globList []string
+
+func (g *globList) String() string {
+	return strings.Join(*g, ",")
+}
+
+func (g *globList) Set(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("%q: %w", pattern, err)
+	}
+	*g = append(*g, pattern)
+	return nil
+}
+
+// match reports whether the slash separated path rel, relative to the
+// directory being walked, matches any of the patterns. Patterns with a
+// slash are matched against the whole of rel, others against its last
+// element only.
+func (g globList) match(rel string) bool {
+	for _, pattern := range g {
+		var subject string = path.Base(rel)
+		if strings.Contains(pattern, "/") {
+			subject = rel
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type walkOptions struct {
+	include  globList
+	exclude  globList
+	symlinks string
+}
+
+// walk calls fn with the name of each regular file in the tree rooted
+// at root which passes the filters, in lexical order, or with an error
+// for names which could not be examined. Excluded directories are not
+// entered. If root is not a directory, fn is called for it alone.
+func (o *walkOptions) walk(root string, fn func(name string, err error)) {
+	if root == "-" {
+		fn(root, nil)
+		return
+	}
+
+	fi, err := os.Stat(root)
+	if err != nil || !fi.IsDir() {
+		fn(root, err)
+		return
+	}
+
+	o.walkDir(root, "", make(map[string]bool), fn)
+}
+
+// walkDir walks dir, which is at rel in the tree. ancestors holds the
+// resolved paths of the directories above it, so cycles through links
+// are not followed.
+func (o *walkOptions) walkDir(dir, rel string, ancestors map[string]bool, fn func(string, error)) {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		fn(dir, err)
+		return
+	}
+	if ancestors[real] {
+		return
+	}
+	ancestors[real] = true
+	defer delete(ancestors, real)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		fn(dir, err)
+		return
+	}
+
+	for _, e := range entries {
+		var name string = filepath.Join(dir, e.Name())
+		var erel string = path.Join(rel, e.Name())
+		var mode fs.FileMode = e.Type()
+
+		if o.exclude.match(erel) {
+			continue
+		}
+
+		if mode&fs.ModeSymlink != 0 {
+			if o.symlinks == symlinksSkip {
+				continue
+			}
+			fi, err := os.Stat(name)
+			if err != nil {
+				fn(name, err)
+				continue
+			}
+			mode = fi.Mode()
+			if mode.IsDir() && o.symlinks != symlinksFollow {
+				continue
+			}
+		}
+
+		switch {
+		case mode.IsDir():
+			o.walkDir(name, erel, ancestors, fn)
+		case mode.IsRegular() && (len(o.include) == 0 || o.include.match(erel)):
+			fn(name, nil)
+		}
+	}
+}
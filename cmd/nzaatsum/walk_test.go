@@ -0,0 +1,78 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Create a small tree with a link to a file, a link to a directory
+// outside the tree and a link back to the root of the tree.
+func makeTree(t *testing.T) string {
+	var dir string = t.TempDir()
+	var other string = t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(other, "e.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.txt", "b.log", "sub/c.txt", "vendor/d.txt"} {
+		var p string = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("a.txt", filepath.Join(dir, "link.txt")); err != nil {
+		t.Skip("Symbolic links not supported: ", err)
+	}
+	if err := os.Symlink("..", filepath.Join(dir, "sub", "up")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(other, filepath.Join(dir, "ext")); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func walked(t *testing.T, dir string, o walkOptions) []string {
+	var names []string
+	o.walk(dir, func(name string, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		rel, _ := filepath.Rel(dir, name)
+		names = append(names, filepath.ToSlash(rel))
+	})
+	return names
+}
+
+// Test the filters and symlink policies.
+func TestWalk(t *testing.T) {
+	var dir string = makeTree(t)
+
+	for _, c := range []struct {
+		opts walkOptions
+		want []string
+	}{
+		{walkOptions{symlinks: symlinksFiles}, []string{"a.txt", "b.log", "link.txt", "sub/c.txt", "vendor/d.txt"}},
+		{walkOptions{symlinks: symlinksSkip}, []string{"a.txt", "b.log", "sub/c.txt", "vendor/d.txt"}},
+		{walkOptions{symlinks: symlinksSkip, include: globList{"*.txt"}, exclude: globList{"vendor"}}, []string{"a.txt", "sub/c.txt"}},
+		{walkOptions{symlinks: symlinksSkip, exclude: globList{"sub/*.txt", "*.log"}}, []string{"a.txt", "vendor/d.txt"}},
+		// The link back to the root is not followed, it would be a cycle.
+		{walkOptions{symlinks: symlinksFollow, include: globList{"?.txt"}}, []string{"a.txt", "ext/e.txt", "sub/c.txt", "vendor/d.txt"}},
+	} {
+		if got := walked(t, dir, c.opts); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Walk with %+v gave %v, expected %v", c.opts, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// nzaatvectors writes the golden test vectors of NZAAT and NZAT as JSON
+// or CSV, for testing implementations in other languages.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/caoimhechaos/golang-nzaat/vectors"
+)
+
+func main() {
+	var format string
+	var output string
+	var seed uint64
+
+	flag.StringVar(&format, "format", "json", "Output format, json or csv")
+	flag.StringVar(&output, "o", "", "File to write to instead of stdout")
+	flag.Uint64Var(&seed, "seed", vectors.DefaultSeed, "Seed for the random inputs")
+	flag.Parse()
+
+	var f *os.File = os.Stdout
+	var err error
+	if output != "" {
+		if f, err = os.Create(output); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var w *bufio.Writer = bufio.NewWriter(f)
+	var vs []vectors.Vector = vectors.Generate(seed)
+
+	switch format {
+	case "json":
+		err = vectors.WriteJSON(w, vs)
+	case "csv":
+		err = vectors.WriteCSV(w, vs)
+	default:
+		log.Fatal("Unknown format ", format)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build js && wasm
+
+// nzaatwasm exports NZAAT to JavaScript, so that browser code can
+// compute the same checksums the backend verifies. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o nzaat.wasm ./cmd/nzaatwasm
+//
+// and load it with wasm_exec.js from the Go distribution. Once it runs,
+// it defines a global object nzaat with the functions
+//
+//	checksum(data)         NZAAT checksum of data
+//	checksumNZAT(data)     NZAT checksum of data
+//	update(state, data)    state after absorbing data, starting from 0
+//	final(state)           NZAAT checksum of a state
+//	finalNZAT(state)       NZAT checksum of a state
+//	formatSum(sum)         sum as 8 hex digits
+//
+// where data is a string, hashed as UTF-8, a Uint8Array or an
+// ArrayBuffer, and states and checksums are numbers. The functions
+// return undefined for arguments of other types. Since the state is a
+// plain number, a file is hashed in pieces without keeping anything
+// alive on the Go side:
+//
+//	let state = 0;
+//	for await (const chunk of file.stream()) {
+//		state = nzaat.update(state, chunk);
+//	}
+//	const sum = nzaat.formatSum(nzaat.final(state));
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// chunkSize is the size of the pieces typed arrays are copied to Go in.
+const chunkSize = 64 << 10
+
+// update absorbs data into d. It returns false if data is of a type
+// which cannot be hashed.
+func update(d *nzaat.Digest, data js.Value) bool {
+	switch {
+	case data.Type() == js.TypeString:
+		*d = nzaat.UpdateString(*d, data.String())
+		return true
+	case data.InstanceOf(js.Global().Get("ArrayBuffer")):
+		data = js.Global().Get("Uint8Array").New(data)
+	case !data.InstanceOf(js.Global().Get("Uint8Array")):
+		return false
+	}
+
+	var buf []byte = make([]byte, min(data.Length(), chunkSize))
+	for off := 0; off < data.Length(); off += chunkSize {
+		var n int = js.CopyBytesToGo(buf, data.Call("subarray", off, off+chunkSize))
+		*d = nzaat.Update(*d, buf[:n])
+	}
+	return true
+}
+
+// checksum returns a function hashing its argument and finalizing the
+// result with final.
+func checksum(final func(nzaat.Digest) uint32) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		var d nzaat.Digest
+		if len(args) < 1 || !update(&d, args[0]) {
+			return js.Undefined()
+		}
+		return final(d)
+	})
+}
+
+// finalize returns a function applying final to a state.
+func finalize(final func(nzaat.Digest) uint32) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) < 1 || args[0].Type() != js.TypeNumber {
+			return js.Undefined()
+		}
+		return final(nzaat.Digest(uint32(args[0].Float())))
+	})
+}
+
+// register defines the object nzaat on global.
+func register(global js.Value) {
+	var obj js.Value = js.Global().Get("Object").New()
+
+	obj.Set("checksum", checksum(nzaat.Final))
+	obj.Set("checksumNZAT", checksum(nzaat.FinalNZAT))
+	obj.Set("update", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) < 2 || args[0].Type() != js.TypeNumber {
+			return js.Undefined()
+		}
+		var d nzaat.Digest = nzaat.Digest(uint32(args[0].Float()))
+		if !update(&d, args[1]) {
+			return js.Undefined()
+		}
+		return uint32(d)
+	}))
+	obj.Set("final", finalize(nzaat.Final))
+	obj.Set("finalNZAT", finalize(nzaat.FinalNZAT))
+	obj.Set("formatSum", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) < 1 || args[0].Type() != js.TypeNumber {
+			return js.Undefined()
+		}
+		return nzaat.FormatSum(uint32(args[0].Float()))
+	}))
+
+	global.Set("nzaat", obj)
+}
+
+func main() {
+	register(js.Global())
+	select {}
+}
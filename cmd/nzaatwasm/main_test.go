@@ -0,0 +1,91 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build js && wasm
+
+// Run these tests with
+//
+//	GOOS=js GOARCH=wasm go test -exec "$(go env GOROOT)/lib/wasm/go_js_wasm_exec" ./cmd/nzaatwasm
+
+package main
+
+import (
+	"os"
+	"syscall/js"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+	"github.com/caoimhechaos/golang-nzaat/vectors"
+)
+
+// bytes returns b as a Uint8Array.
+func bytes(b []byte) js.Value {
+	var a js.Value = js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(a, b)
+	return a
+}
+
+// Test the exported functions against the published golden vectors.
+func TestVectors(t *testing.T) {
+	var vs []vectors.Vector
+	var obj js.Value = js.Global().Get("Object").New()
+
+	f, err := os.Open("../../vectors/testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if vs, err = vectors.ReadJSON(f); err != nil {
+		t.Fatal(err)
+	}
+
+	register(obj)
+	var nz js.Value = obj.Get("nzaat")
+
+	for _, v := range vs {
+		if got := uint32(nz.Call("checksum", bytes(v.Input)).Float()); got != v.NZAAT {
+			t.Errorf("checksum(%s): got %08x, expected %08x", v.Name, got, v.NZAAT)
+		}
+		if got := uint32(nz.Call("checksumNZAT", bytes(v.Input).Get("buffer")).Float()); got != v.NZAT {
+			t.Errorf("checksumNZAT(%s): got %08x, expected %08x", v.Name, got, v.NZAT)
+		}
+
+		var half int = len(v.Input) / 2
+		var state js.Value = nz.Call("update", 0, bytes(v.Input[:half]))
+		state = nz.Call("update", state, bytes(v.Input[half:]))
+		if got := uint32(nz.Call("final", state).Float()); got != v.NZAAT {
+			t.Errorf("update(%s): got %08x, expected %08x", v.Name, got, v.NZAAT)
+		}
+		if got := uint32(nz.Call("finalNZAT", state).Float()); got != v.NZAT {
+			t.Errorf("update(%s): got NZAT %08x, expected %08x", v.Name, got, v.NZAT)
+		}
+	}
+}
+
+// Test hashing strings, large arrays and invalid arguments.
+func TestArguments(t *testing.T) {
+	var obj js.Value = js.Global().Get("Object").New()
+	register(obj)
+	var nz js.Value = obj.Get("nzaat")
+
+	if got := nz.Call("formatSum", nz.Call("checksum", "abc")).String(); got != "c3e39e2d" {
+		t.Errorf("checksum(\"abc\"): got %s, expected c3e39e2d", got)
+	}
+
+	var big []byte = make([]byte, 3*chunkSize+17)
+	for i := range big {
+		big[i] = byte(i * 7)
+	}
+	if got, want := uint32(nz.Call("checksum", bytes(big)).Float()), nzaat.Checksum(big); got != want {
+		t.Errorf("checksum of %d bytes: got %08x, expected %08x", len(big), got, want)
+	}
+
+	if got := nz.Call("checksum", 42); !got.IsUndefined() {
+		t.Errorf("checksum(42): got %v, expected undefined", got)
+	}
+	if got := nz.Call("update", "x", "abc"); !got.IsUndefined() {
+		t.Errorf("update(\"x\", \"abc\"): got %v, expected undefined", got)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+)
+
+// contentIDEncoding is the RFC 4648 base32 alphabet in lower case and
+// without padding, so content IDs can be used in URLs and file names
+// and on case-insensitive file systems.
+var contentIDEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").
+	WithPadding(base32.NoPadding)
+
+// contentID64Seed seeds the second half of 64-bit content IDs.
+const contentID64Seed = 0x6e7a6964
+
+// ContentID returns a compact textual identifier for data: the NZAAT
+// checksum of data in big-endian order, encoded as 7 characters of
+// lower case base32.
+func ContentID(data []byte) string {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], Checksum(data))
+	return contentIDEncoding.EncodeToString(b[:])
+}
+
+// ContentID64 returns a 13 character identifier for data like
+// ContentID, but from 64 bits of hash: the NZAAT checksum followed by
+// a second checksum of data computed with a fixed seed. Since the two
+// halves come from independent states, this has far fewer collisions
+// than ContentID for large numbers of objects.
+func ContentID64(data []byte) string {
+	var h Hash
+	var b [8]byte
+
+	h.SetSeed(NewSeed(contentID64Seed))
+	h.Write(data)
+
+	binary.BigEndian.PutUint32(b[:4], Checksum(data))
+	binary.BigEndian.PutUint32(b[4:], h.Sum32())
+	return contentIDEncoding.EncodeToString(b[:])
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test the format of content IDs.
+func TestContentID(t *testing.T) {
+	var id string = ContentID([]byte("abc"))
+
+	if id != "yprz4li" {
+		t.Errorf("ContentID(\"abc\") = %q", id)
+	}
+
+	var id64 string = ContentID64([]byte("abc"))
+	if len(id64) != 13 || strings.Trim(id64, "abcdefghijklmnopqrstuvwxyz234567") != "" {
+		t.Errorf("ContentID64(\"abc\") = %q", id64)
+	}
+	if ContentID64([]byte("abd")) == id64 {
+		t.Error("Different data gave the same ID")
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package countmin estimates the frequencies of keys in a stream with
+// Count-Min sketches. Each of the d rows of a sketch has w counters,
+// and the column of a key in every row is derived by double hashing
+// from two seeded NZAAT checksums of the key. The estimate of a key is
+// the smallest of its counters, which never underestimates and, with
+// probability 1-δ, overestimates by at most εN for a stream of N
+// counts, where w = ⌈e/ε⌉ and d = ⌈ln(1/δ)⌉.
+//
+// In conservative update mode, an addition only raises the counters of
+// a key as far as needed for its new estimate, which leaves the
+// guarantees intact but considerably lowers the overestimation. Such
+// sketches can't have counts removed.
+//
+// Sketches with the same dimensions and seed can be merged, so sketches
+// built on several hosts combine into the sketch of all their streams.
+package countmin
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// secondSeed is xored into the seed for the second hash of a key.
+const secondSeed = 0x636d7332
+
+// ErrIncompatible is returned when merging sketches which differ in
+// dimensions, seed or update mode.
+var ErrIncompatible = errors.New("countmin: incompatible sketches")
+
+// Sketch is a Count-Min sketch. It is not safe for concurrent use.
+type Sketch struct {
+	w, d         int
+	seed         uint32
+	conservative bool
+	counts       []uint64
+	total        uint64
+
+	// prefix1 and prefix2 are the states after absorbing the two seeds.
+	prefix1, prefix2 nzaat.Digest
+}
+
+// New returns an empty sketch of d rows of w counters, with hash
+// functions selected by seed. It panics if w or d are not positive.
+func New(w, d int, seed uint32) *Sketch {
+	if w <= 0 || d <= 0 {
+		panic("countmin: dimensions must be positive")
+	}
+
+	var s *Sketch = &Sketch{w: w, d: d, seed: seed, counts: make([]uint64, w*d)}
+	s.prefix1.WriteUint32(seed, binary.BigEndian)
+	s.prefix2.WriteUint32(seed^secondSeed, binary.BigEndian)
+	return s
+}
+
+// NewWithError returns an empty sketch which overestimates by at most
+// epsilon times the total count with probability 1-delta.
+func NewWithError(epsilon, delta float64, seed uint32) *Sketch {
+	return New(int(math.Ceil(math.E/epsilon)), int(math.Ceil(math.Log(1/delta))), seed)
+}
+
+// NewConservative returns an empty sketch like New using conservative
+// update.
+func NewConservative(w, d int, seed uint32) *Sketch {
+	var s *Sketch = New(w, d, seed)
+	s.conservative = true
+	return s
+}
+
+// Width returns the number of counters per row.
+func (s *Sketch) Width() int {
+	return s.w
+}
+
+// Depth returns the number of rows.
+func (s *Sketch) Depth() int {
+	return s.d
+}
+
+// Conservative reports whether the sketch uses conservative update.
+func (s *Sketch) Conservative() bool {
+	return s.conservative
+}
+
+// Total returns the sum of all counts added.
+func (s *Sketch) Total() uint64 {
+	return s.total
+}
+
+// cells returns the indexes of the counters of key, one per row.
+func (s *Sketch) cells(key []byte, idx []int) {
+	var d1, d2 nzaat.Digest = s.prefix1, s.prefix2
+	d1.Write(key)
+	d2.Write(key)
+
+	var h1 uint64 = uint64(d1.Sum32())<<32 | uint64(d2.Sum32())
+	var h2 uint64 = uint64(d2.Sum32())<<32 | uint64(d1.Sum32()) | 1
+	for i := range idx {
+		col, _ := bits.Mul64(h1+uint64(i)*h2, uint64(s.w))
+		idx[i] = i*s.w + int(col)
+	}
+}
+
+// Add adds count occurrences of key.
+func (s *Sketch) Add(key []byte, count uint64) {
+	var idx []int = make([]int, s.d)
+	s.cells(key, idx)
+	s.total += count
+
+	if !s.conservative {
+		for _, i := range idx {
+			s.counts[i] += count
+		}
+		return
+	}
+
+	var est uint64 = s.min(idx) + count
+	for _, i := range idx {
+		if s.counts[i] < est {
+			s.counts[i] = est
+		}
+	}
+}
+
+// AddString adds count occurrences of the bytes of key.
+func (s *Sketch) AddString(key string, count uint64) {
+	s.Add([]byte(key), count)
+}
+
+// min returns the smallest of the counters at idx.
+func (s *Sketch) min(idx []int) uint64 {
+	var m uint64 = math.MaxUint64
+	for _, i := range idx {
+		m = min(m, s.counts[i])
+	}
+	return m
+}
+
+// Count returns the estimated number of occurrences of key. It is never
+// less than the true number.
+func (s *Sketch) Count(key []byte) uint64 {
+	var idx []int = make([]int, s.d)
+	s.cells(key, idx)
+	return s.min(idx)
+}
+
+// CountString returns the estimated number of occurrences of the bytes
+// of key.
+func (s *Sketch) CountString(key string) uint64 {
+	return s.Count([]byte(key))
+}
+
+// Merge adds all counts of t to s, so that s becomes the sketch of both
+// streams. Merging sketches with conservative update adds their
+// counters, which keeps the estimates from falling below the true
+// counts, though they are not as tight as those of a single sketch of
+// both streams.
+func (s *Sketch) Merge(t *Sketch) error {
+	if s.w != t.w || s.d != t.d || s.seed != t.seed || s.conservative != t.conservative {
+		return ErrIncompatible
+	}
+	for i, c := range t.counts {
+		s.counts[i] += c
+	}
+	s.total += t.total
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package countmin
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// zipf adds a skewed stream to s, key i occurring 10000/(i+1) times,
+// and returns the true counts.
+func zipf(s *Sketch, from, to int) map[string]uint64 {
+	var counts map[string]uint64 = make(map[string]uint64)
+	for i := from; i < to; i++ {
+		var key string = "key" + strconv.Itoa(i)
+		var n uint64 = uint64(10000 / (i + 1))
+		s.AddString(key, n)
+		counts[key] += n
+	}
+	return counts
+}
+
+// overestimate returns the summed overestimation of all keys.
+func overestimate(t *testing.T, s *Sketch, counts map[string]uint64) uint64 {
+	t.Helper()
+
+	var over uint64
+	for key, n := range counts {
+		var est uint64 = s.CountString(key)
+		if est < n {
+			t.Fatalf("Key %s: estimated %d, less than the true %d", key, est, n)
+		}
+		over += est - n
+	}
+	return over
+}
+
+// Test that conservative update never underestimates and overestimates
+// less than plain update.
+func TestConservative(t *testing.T) {
+	var plain, cons *Sketch = New(200, 4, 1), NewConservative(200, 4, 1)
+	var counts map[string]uint64 = zipf(plain, 0, 2000)
+	zipf(cons, 0, 2000)
+
+	var po, co uint64 = overestimate(t, plain, counts), overestimate(t, cons, counts)
+	t.Logf("Overestimation: plain %d, conservative %d", po, co)
+	if co >= po {
+		t.Errorf("Conservative update overestimates by %d, plain by %d", co, po)
+	}
+	if plain.Total() != cons.Total() {
+		t.Errorf("Totals differ: %d, %d", plain.Total(), cons.Total())
+	}
+}
+
+// Test the error bound of NewWithError.
+func TestErrorBound(t *testing.T) {
+	var s *Sketch = NewWithError(0.001, 0.01, 7)
+	if s.Width() != 2719 || s.Depth() != 5 {
+		t.Errorf("Got %dx%d, expected 2719x5", s.Width(), s.Depth())
+	}
+
+	var counts map[string]uint64 = zipf(s, 0, 5000)
+	var bound uint64 = s.Total() / 1000
+	var bad int
+	for key, n := range counts {
+		if s.CountString(key)-n > bound {
+			bad++
+		}
+	}
+	if bad > len(counts)/100 {
+		t.Errorf("%d of %d keys exceed the error bound", bad, len(counts))
+	}
+}
+
+// Test that merged sketches equal a sketch of both streams.
+func TestMerge(t *testing.T) {
+	var a, b, both *Sketch = New(100, 3, 2), New(100, 3, 2), New(100, 3, 2)
+	zipf(a, 0, 500)
+	zipf(b, 250, 1000)
+	zipf(both, 0, 500)
+	zipf(both, 250, 1000)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		var key string = "key" + strconv.Itoa(i)
+		if a.CountString(key) != both.CountString(key) {
+			t.Fatalf("Key %s: merged %d, combined %d", key, a.CountString(key), both.CountString(key))
+		}
+	}
+
+	var c, d *Sketch = NewConservative(100, 3, 2), NewConservative(100, 3, 2)
+	var counts map[string]uint64 = zipf(c, 0, 500)
+	for k, n := range zipf(d, 250, 1000) {
+		counts[k] += n
+	}
+	if err := c.Merge(d); err != nil {
+		t.Fatal(err)
+	}
+	overestimate(t, c, counts)
+
+	for _, o := range []*Sketch{New(101, 3, 2), New(100, 4, 2), New(100, 3, 3), NewConservative(100, 3, 2)} {
+		if err := New(100, 3, 2).Merge(o); !errors.Is(err, ErrIncompatible) {
+			t.Errorf("Got %v, expected ErrIncompatible", err)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package cuckoo provides a generic hash map with cuckoo hashing, for
+// lookup tables where the worst case latency matters more than the
+// average.
+//
+// Every key has exactly two possible slots, one in each of two tables,
+// chosen by two differently seeded NZAAT checksums of the key. Inserting
+// a key into an occupied slot evicts its occupant into its other slot,
+// and so on. In the rare case that this does not settle down, the
+// homeless entry goes into a small stash, and only once the stash is
+// full are the tables grown. A lookup therefore examines at most two
+// slots and the stash, whatever the keys.
+//
+// Maps holding keys from untrusted sources should be created with
+// NewSeeded and a random seed, so that nobody can choose keys which
+// all compete for the same slots.
+package cuckoo
+
+import "github.com/caoimhechaos/golang-nzaat"
+
+const (
+	// minSize is the initial number of slots in each table.
+	minSize = 8
+
+	// maxKicks is the number of evictions after which an insertion
+	// gives up and uses the stash.
+	maxKicks = 64
+
+	// stashSize is the number of entries the stash can hold.
+	stashSize = 4
+
+	// secondSeed is xored into the seed for the second table.
+	secondSeed = 0x63756b6f
+)
+
+type slot[K ~string, V any] struct {
+	key  K
+	val  V
+	h    [2]uint32
+	used bool
+}
+
+// Map is a hash map with cuckoo hashing. The zero value is an empty
+// map ready to use. A Map is not safe for concurrent use.
+type Map[K ~string, V any] struct {
+	tables [2][]slot[K, V]
+	stash  []slot[K, V]
+	count  int
+	seeds  [2]nzaat.Digest
+	seeded bool
+}
+
+// New returns a new, empty map.
+func New[K ~string, V any]() *Map[K, V] {
+	return new(Map[K, V])
+}
+
+// NewSeeded returns a new, empty map whose two hash functions are
+// selected by seed. Use nzaat.MakeSeed for a seed which cannot be
+// guessed by whoever supplies the keys.
+func NewSeeded[K ~string, V any](seed nzaat.Seed) *Map[K, V] {
+	var m *Map[K, V] = new(Map[K, V])
+	m.setSeed(seed)
+	return m
+}
+
+func (m *Map[K, V]) setSeed(seed nzaat.Seed) {
+	m.seeds[0] = seed.Digest()
+	m.seeds[1] = nzaat.NewSeed(seed.Uint32() ^ secondSeed).Digest()
+	m.seeded = true
+}
+
+func (m *Map[K, V]) hashes(k K) [2]uint32 {
+	if !m.seeded {
+		m.setSeed(nzaat.NewSeed(0))
+	}
+	return [2]uint32{
+		nzaat.Final(nzaat.UpdateString(m.seeds[0], string(k))),
+		nzaat.Final(nzaat.UpdateString(m.seeds[1], string(k))),
+	}
+}
+
+// lookup returns the slot holding k, or nil.
+func (m *Map[K, V]) lookup(k K) *slot[K, V] {
+	if m.count == 0 {
+		return nil
+	}
+
+	var h [2]uint32 = m.hashes(k)
+	var mask uint32 = uint32(len(m.tables[0]) - 1)
+
+	for t := range m.tables {
+		var s *slot[K, V] = &m.tables[t][h[t]&mask]
+		if s.used && s.h == h && s.key == k {
+			return s
+		}
+	}
+	for i := range m.stash {
+		if m.stash[i].h == h && m.stash[i].key == k {
+			return &m.stash[i]
+		}
+	}
+	return nil
+}
+
+// Get returns the value stored under k and whether k was found.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	var zero V
+
+	if s := m.lookup(k); s != nil {
+		return s.val, true
+	}
+	return zero, false
+}
+
+// Put stores v under k, replacing any previous value.
+func (m *Map[K, V]) Put(k K, v V) {
+	if s := m.lookup(k); s != nil {
+		s.val = v
+		return
+	}
+
+	// Cuckoo hashing with two tables becomes unreliable above half
+	// occupancy, so grow ahead of that.
+	if m.count+1 > len(m.tables[0]) {
+		m.resize(max(2*len(m.tables[0]), minSize))
+	}
+	m.insert(slot[K, V]{key: k, val: v, h: m.hashes(k), used: true})
+	m.count++
+}
+
+// insert places e into the tables, the stash, or a grown table.
+func (m *Map[K, V]) insert(e slot[K, V]) {
+	var mask uint32 = uint32(len(m.tables[0]) - 1)
+	var t int
+
+	for n := 0; n < maxKicks; n++ {
+		var s *slot[K, V] = &m.tables[t][e.h[t]&mask]
+		if !s.used {
+			*s = e
+			return
+		}
+		e, *s = *s, e
+		t ^= 1
+	}
+
+	if len(m.stash) < stashSize {
+		m.stash = append(m.stash, e)
+		return
+	}
+	m.resize(2 * len(m.tables[0]))
+	m.insert(e)
+}
+
+// resize moves all entries into tables of size slots each.
+func (m *Map[K, V]) resize(size int) {
+	var old [2][]slot[K, V] = m.tables
+	var stash []slot[K, V] = m.stash
+
+	m.tables = [2][]slot[K, V]{make([]slot[K, V], size), make([]slot[K, V], size)}
+	m.stash = nil
+
+	for _, table := range old {
+		for _, e := range table {
+			if e.used {
+				m.insert(e)
+			}
+		}
+	}
+	for _, e := range stash {
+		m.insert(e)
+	}
+}
+
+// Delete removes k from the map. It reports whether k was present.
+func (m *Map[K, V]) Delete(k K) bool {
+	var s *slot[K, V] = m.lookup(k)
+	if s == nil {
+		return false
+	}
+
+	*s = slot[K, V]{}
+	for i := range m.stash {
+		if &m.stash[i] == s {
+			m.stash[i] = m.stash[len(m.stash)-1]
+			m.stash = m.stash[:len(m.stash)-1]
+			break
+		}
+	}
+	m.count--
+	return true
+}
+
+// Len returns the number of entries in the map.
+func (m *Map[K, V]) Len() int {
+	return m.count
+}
+
+// Range calls f for each entry of the map, in no particular order,
+// until f returns false. The map must not be modified by f.
+func (m *Map[K, V]) Range(f func(K, V) bool) {
+	for _, table := range m.tables {
+		for _, e := range table {
+			if e.used && !f(e.key, e.val) {
+				return
+			}
+		}
+	}
+	for _, e := range m.stash {
+		if !f(e.key, e.val) {
+			return
+		}
+	}
+}
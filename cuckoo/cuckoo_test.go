@@ -0,0 +1,111 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package cuckoo
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test putting, getting and deleting a few entries in the zero Map.
+func TestPutGetDelete(t *testing.T) {
+	var m Map[string, int]
+
+	if _, ok := m.Get("a"); ok {
+		t.Error("Empty map returned a value")
+	}
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("a", 3)
+
+	if v, ok := m.Get("a"); !ok || v != 3 {
+		t.Errorf("Get(\"a\") returned %d, %v", v, ok)
+	}
+	if !m.Delete("a") || m.Delete("a") {
+		t.Error("Delete gave wrong results")
+	}
+	if m.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", m.Len())
+	}
+}
+
+// Test the map against a Go map through growth, stash use and
+// deletions.
+func TestManyEntries(t *testing.T) {
+	var m *Map[string, int] = NewSeeded[string, int](nzaat.NewSeed(7))
+	var ref map[string]int = make(map[string]int)
+
+	for i := 0; i < 20000; i++ {
+		var k string = strconv.Itoa(i * 31)
+		m.Put(k, i)
+		ref[k] = i
+		if i%3 == 0 {
+			var d string = strconv.Itoa(i / 2 * 31)
+			_, present := ref[d]
+			if m.Delete(d) != present {
+				t.Fatalf("Delete(%q) disagrees", d)
+			}
+			delete(ref, d)
+		}
+	}
+
+	if m.Len() != len(ref) {
+		t.Fatalf("Expected %d entries, got %d", len(ref), m.Len())
+	}
+	for k, want := range ref {
+		if v, ok := m.Get(k); !ok || v != want {
+			t.Fatalf("Get(%q) returned %d, %v, expected %d", k, v, ok, want)
+		}
+	}
+
+	var n int
+	m.Range(func(k string, v int) bool {
+		if ref[k] != v {
+			t.Errorf("Range returned %q = %d", k, v)
+		}
+		n++
+		return true
+	})
+	if n != len(ref) {
+		t.Errorf("Range returned %d entries, expected %d", n, len(ref))
+	}
+}
+
+// Test that entries in the stash are found and deleted.
+func TestStash(t *testing.T) {
+	var m *Map[string, int] = New[string, int]()
+
+	m.Put("a", 1)
+	var s slot[string, int] = slot[string, int]{key: "b", val: 2, h: m.hashes("b"), used: true}
+	m.stash = append(m.stash, s)
+	m.count++
+
+	if v, ok := m.Get("b"); !ok || v != 2 {
+		t.Errorf("Stashed entry returned %d, %v", v, ok)
+	}
+	if !m.Delete("b") || len(m.stash) != 0 {
+		t.Error("Stashed entry was not deleted")
+	}
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(\"a\") returned %d, %v", v, ok)
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	var m *Map[string, int] = New[string, int]()
+	var keys []string
+
+	for i := 0; i < 1<<16; i++ {
+		keys = append(keys, strconv.Itoa(i))
+		m.Put(keys[i], i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(keys[i&(1<<16-1)])
+	}
+}
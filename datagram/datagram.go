@@ -0,0 +1,48 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package datagram protects datagrams, for example of custom UDP
+// protocols, with a 4 byte big endian NZAAT checksum trailer.
+package datagram
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Overhead is the number of bytes Seal adds to a datagram.
+const Overhead = 4
+
+var (
+	// ErrShort is returned by Open for packets too short to carry a
+	// trailer.
+	ErrShort = errors.New("datagram: packet too short")
+
+	// ErrChecksum is returned by Open if the trailer doesn't match.
+	ErrChecksum = errors.New("datagram: checksum mismatch")
+)
+
+// Seal appends the checksum of buf to buf and returns the extended
+// slice, like append. Leaving Overhead bytes of spare capacity in buf
+// avoids a reallocation.
+func Seal(buf []byte) []byte {
+	return binary.BigEndian.AppendUint32(buf, nzaat.Checksum(buf))
+}
+
+// Open verifies the trailer of a packet produced by Seal and returns
+// the payload, which shares the memory of pkt.
+func Open(pkt []byte) ([]byte, error) {
+	if len(pkt) < Overhead {
+		return nil, ErrShort
+	}
+
+	var payload []byte = pkt[:len(pkt)-Overhead]
+	if nzaat.Checksum(payload) != binary.BigEndian.Uint32(pkt[len(payload):]) {
+		return nil, ErrChecksum
+	}
+	return payload, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package datagram
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Test sealing and opening a packet.
+func TestSealOpen(t *testing.T) {
+	var pkt []byte = Seal([]byte("abc"))
+
+	if !bytes.Equal(pkt, []byte{'a', 'b', 'c', 0xc3, 0xe3, 0x9e, 0x2d}) {
+		t.Errorf("Unexpected packet %x", pkt)
+	}
+	if p, err := Open(pkt); err != nil || string(p) != "abc" {
+		t.Errorf("Open gave %q, %v", p, err)
+	}
+}
+
+// Test that damaged and short packets are rejected.
+func TestOpenErrors(t *testing.T) {
+	var pkt []byte = Seal([]byte("abc"))
+
+	for i := range pkt {
+		pkt[i] ^= 0x10
+		if _, err := Open(pkt); err != ErrChecksum {
+			t.Errorf("Flipped bit in byte %d: got %v", i, err)
+		}
+		pkt[i] ^= 0x10
+	}
+	if _, err := Open(pkt[:3]); err != ErrShort {
+		t.Errorf("Short packet: got %v", err)
+	}
+}
+
+// Test that sealing with spare capacity doesn't allocate.
+func TestSealNoAlloc(t *testing.T) {
+	var buf []byte = make([]byte, 64, 64+Overhead)
+
+	if n := testing.AllocsPerRun(100, func() { Seal(buf) }); n != 0 {
+		t.Errorf("Seal allocated %v times", n)
+	}
+}
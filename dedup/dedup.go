@@ -0,0 +1,268 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package dedup stores data with duplicate blocks removed. A Writer
+// splits its input into blocks, either of a fixed size or at boundaries
+// defined by the content, so that insertions only affect the blocks
+// around them. Blocks which the Index has not seen before are written
+// to the data stream; for every block, new or not, a reference to its
+// place in the data stream is written to the reference stream. Restore
+// reassembles the original input from the two.
+//
+// The reference stream is a sequence of pairs of uvarints, the offset
+// and the size of a block in the data stream.
+//
+// Blocks are identified by 64 bits of hash, the NZAAT checksum and a
+// seeded NZAAT checksum of the block, so with billions of distinct
+// blocks, collisions which would silently corrupt data become likely.
+package dedup
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// DefaultBlockSize is the block size used if none is given.
+const DefaultBlockSize = 8192
+
+// keySeed seeds the second half of block keys.
+const keySeed = 0x64656475
+
+// ErrFormat is returned, wrapped, by Restore for malformed reference
+// streams.
+var ErrFormat = errors.New("dedup: malformed reference stream")
+
+// Ref is the place of a block in the data stream.
+type Ref struct {
+	Offset int64
+	Size   int
+}
+
+// Index remembers the blocks which were already written to the data
+// stream, by their keys.
+type Index interface {
+	Lookup(key uint64) (Ref, bool)
+	Add(key uint64, ref Ref)
+}
+
+// MemoryIndex is an Index held in memory.
+type MemoryIndex map[uint64]Ref
+
+func (m MemoryIndex) Lookup(key uint64) (Ref, bool) {
+	ref, ok := m[key]
+	return ref, ok
+}
+
+func (m MemoryIndex) Add(key uint64, ref Ref) {
+	m[key] = ref
+}
+
+// Key returns the key identifying a block with the contents p.
+func Key(p []byte) uint64 {
+	var h nzaat.Hash
+	h.SetSeed(nzaat.NewSeed(keySeed))
+	h.Write(p)
+	return uint64(nzaat.Checksum(p))<<32 | uint64(h.Sum32())
+}
+
+// Options configures a Writer.
+type Options struct {
+	// BlockSize is the size of the blocks, or their average size with
+	// ContentDefined. Defaults to DefaultBlockSize.
+	BlockSize int
+
+	// ContentDefined selects content-defined blocks: the input is cut
+	// where a rolling hash of the last 32 octets has a certain number
+	// of leading zero bits, but not into blocks shorter than a quarter
+	// or longer than four times BlockSize.
+	ContentDefined bool
+
+	// Index is the index of the blocks already in the data stream.
+	// Defaults to a new MemoryIndex. To add to an existing data
+	// stream, pass its index and set Offset to its size.
+	Index Index
+
+	// Offset is the offset in the data stream at which the Writer
+	// starts writing.
+	Offset int64
+}
+
+// Writer deduplicates the data written to it. Close must be called to
+// write the last block.
+type Writer struct {
+	data  io.Writer
+	refs  *bufio.Writer
+	index Index
+	off   int64
+	buf   []byte
+	err   error
+
+	size       int
+	cdc        bool
+	min, max   int
+	mask, hash uint32
+}
+
+// NewWriter returns a Writer writing new blocks to data and references
+// to refs.
+func NewWriter(data, refs io.Writer, opts Options) *Writer {
+	var w *Writer = &Writer{
+		data:  data,
+		refs:  bufio.NewWriter(refs),
+		index: opts.Index,
+		off:   opts.Offset,
+		size:  opts.BlockSize,
+		cdc:   opts.ContentDefined,
+	}
+	if w.index == nil {
+		w.index = MemoryIndex{}
+	}
+	if w.size <= 0 {
+		w.size = DefaultBlockSize
+	}
+	if w.cdc {
+		var bits int
+		for 1<<bits < w.size {
+			bits++
+		}
+		w.mask = ^uint32(0) << (32 - bits)
+		w.min, w.max = w.size/4, w.size*4
+	}
+	return w
+}
+
+// Write splits p into blocks and writes out all completed ones.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	var n int = len(p)
+	for len(p) > 0 {
+		var cut int = w.boundary(p)
+		if cut < 0 {
+			w.buf = append(w.buf, p...)
+			break
+		}
+		w.buf = append(w.buf, p[:cut]...)
+		p = p[cut:]
+		if w.err = w.flush(); w.err != nil {
+			return n - len(p), w.err
+		}
+	}
+	return n, nil
+}
+
+// boundary returns the number of octets of p completing the current
+// block, or -1 if p does not complete it.
+func (w *Writer) boundary(p []byte) int {
+	if !w.cdc {
+		if len(w.buf)+len(p) < w.size {
+			return -1
+		}
+		return w.size - len(w.buf)
+	}
+
+	for i, b := range p {
+		w.hash = w.hash<<1 + gear[b]
+		var n int = len(w.buf) + i + 1
+		if n >= w.max || (n >= w.min && w.hash&w.mask == 0) {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// flush writes out the buffered block.
+func (w *Writer) flush() error {
+	var key uint64 = Key(w.buf)
+	ref, ok := w.index.Lookup(key)
+	if !ok || ref.Size != len(w.buf) {
+		ref = Ref{Offset: w.off, Size: len(w.buf)}
+		if _, err := w.data.Write(w.buf); err != nil {
+			return err
+		}
+		w.off += int64(len(w.buf))
+		w.index.Add(key, ref)
+	}
+
+	var b [2 * binary.MaxVarintLen64]byte
+	var n int = binary.PutUvarint(b[:], uint64(ref.Offset))
+	n += binary.PutUvarint(b[n:], uint64(ref.Size))
+	if _, err := w.refs.Write(b[:n]); err != nil {
+		return err
+	}
+
+	w.buf = w.buf[:0]
+	w.hash = 0
+	return nil
+}
+
+// Offset returns the size of the data stream written so far, including
+// the initial Offset.
+func (w *Writer) Offset() int64 {
+	return w.off
+}
+
+// Close writes the last, partial block and flushes the reference
+// stream. It does not close the underlying writers.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.buf) > 0 {
+		if w.err = w.flush(); w.err != nil {
+			return w.err
+		}
+	}
+	w.err = w.refs.Flush()
+	return w.err
+}
+
+// Restore writes the input of a Writer, reassembled from its data and
+// reference streams, to w.
+func Restore(w io.Writer, data io.ReaderAt, refs io.Reader) error {
+	var br *bufio.Reader = bufio.NewReader(refs)
+
+	for {
+		off, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+		if off > 1<<62 || size > 1<<62 {
+			return fmt.Errorf("%w: block %d+%d", ErrFormat, off, size)
+		}
+
+		n, err := io.Copy(w, io.NewSectionReader(data, int64(off), int64(size)))
+		if err != nil {
+			return err
+		}
+		if n != int64(size) {
+			return fmt.Errorf("dedup: block %d+%d beyond the end of the data: %w", off, size, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+// gear maps octets to the random values added to the rolling hash of
+// content-defined blocks, taken from NZAAT checksums.
+var gear [256]uint32
+
+func init() {
+	for i := range gear {
+		gear[i] = nzaat.Checksum([]byte{'g', byte(i)})
+	}
+}
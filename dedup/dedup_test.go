@@ -0,0 +1,105 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package dedup
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"testing"
+)
+
+// random returns n random octets.
+func random(rng *rand.Rand, n int) []byte {
+	var p []byte = make([]byte, n)
+	for i := range p {
+		p[i] = byte(rng.Uint32())
+	}
+	return p
+}
+
+// store writes input through a Writer in uneven pieces and checks that
+// it is restored. It returns the size of the data stream.
+func store(t *testing.T, input []byte, opts Options, data *bytes.Buffer) int64 {
+	t.Helper()
+
+	var refs bytes.Buffer
+	var start int = data.Len()
+	var w *Writer = NewWriter(data, &refs, opts)
+
+	for p := input; len(p) > 0; {
+		var n int = min(len(p), 1000)
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := Restore(&out, bytes.NewReader(data.Bytes()), &refs); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), input) {
+		t.Fatal("Restored data differs from the input")
+	}
+	return int64(data.Len() - start)
+}
+
+// Test that repeated blocks are stored once.
+func TestFixedBlocks(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(1, 2))
+	var block []byte = random(rng, 512)
+	var input []byte = append(bytes.Repeat(block, 10), random(rng, 100)...)
+	var data bytes.Buffer
+
+	if n := store(t, input, Options{BlockSize: 512}, &data); n != 612 {
+		t.Errorf("Stored %d bytes, expected 612", n)
+	}
+	if n := store(t, nil, Options{BlockSize: 512}, &data); n != 0 {
+		t.Errorf("Stored %d bytes of empty input", n)
+	}
+}
+
+// Test that content-defined blocks find duplicates after an insertion,
+// which fixed blocks cannot.
+func TestContentDefined(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(3, 4))
+	var base []byte = random(rng, 200000)
+	var edited []byte = append(append(append([]byte{}, base[:100000]...), "inserted"...), base[100000:]...)
+
+	for _, cdc := range []bool{false, true} {
+		var data bytes.Buffer
+		var index MemoryIndex = MemoryIndex{}
+		var opts Options = Options{BlockSize: 4096, ContentDefined: cdc, Index: index}
+
+		var first int64 = store(t, base, opts, &data)
+		opts.Offset = first
+		var second int64 = store(t, edited, opts, &data)
+
+		t.Logf("Content defined %v: %d bytes, then %d bytes", cdc, first, second)
+		if cdc && second > 4*4096*2 {
+			t.Errorf("Stored %d new bytes after a small insertion", second)
+		}
+		if !cdc && second < 90000 {
+			t.Errorf("Fixed blocks stored only %d new bytes after an insertion", second)
+		}
+	}
+}
+
+// Test that references beyond the data are rejected.
+func TestRestoreErrors(t *testing.T) {
+	var out bytes.Buffer
+	if err := Restore(&out, bytes.NewReader([]byte("abc")), bytes.NewReader([]byte{0, 10})); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Got %v, expected io.ErrUnexpectedEOF", err)
+	}
+	if err := Restore(&out, bytes.NewReader([]byte("abc")), bytes.NewReader([]byte{0})); !errors.Is(err, ErrFormat) {
+		t.Errorf("Got %v, expected ErrFormat", err)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package delta computes binary deltas in the manner of rsync and
+// rdiff. The holder of an old file sends its Signature, a rolling and
+// an NZAAT checksum of each block; the holder of the new file computes
+// a Delta against the signature alone, consisting of references to old
+// blocks and literal data; and Patch applies the delta to the old file
+// to reconstruct the new one:
+//
+//	sig, err := delta.NewSignature(oldFile, delta.DefaultBlockSize)
+//	// send sig to the other side
+//	d, err := delta.New(sig, newFile)
+//	// send d back
+//	err = delta.Patch(oldFile, d, out)
+//
+// Since a 32 bit block checksum can collide, a delta also carries the
+// NZAAT checksum of the whole new file, and Patch fails with
+// ErrChecksum if the result doesn't match it.
+//
+// Signatures and deltas are serialized with WriteTo and read back with
+// ReadSignature and ReadDelta. Both formats start with a four octet
+// magic number and use uvarints for all sizes and counts.
+package delta
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// DefaultBlockSize is a block size suitable for files of a few
+// megabytes.
+const DefaultBlockSize = 2048
+
+var (
+	// ErrFormat is returned, wrapped, when reading a malformed
+	// signature or delta.
+	ErrFormat = errors.New("delta: malformed input")
+
+	// ErrChecksum is returned by Patch if the reconstructed file does
+	// not have the checksum recorded in the delta.
+	ErrChecksum = errors.New("delta: checksum mismatch")
+
+	// ErrBlock is returned, wrapped, by Patch for references to blocks
+	// beyond the end of the old file.
+	ErrBlock = errors.New("delta: block out of range")
+)
+
+// Block holds the checksums of one block of the old file.
+type Block struct {
+	// Weak is the rolling checksum of the block.
+	Weak uint32
+
+	// Strong is the NZAAT checksum of the block.
+	Strong uint32
+}
+
+// Signature describes an old file by the checksums of its blocks.
+type Signature struct {
+	BlockSize int
+
+	// Size is the size of the old file. All blocks but the last one
+	// are BlockSize bytes long.
+	Size int64
+
+	Blocks []Block
+}
+
+// NewSignature reads the old file from r and returns its signature. It
+// panics if blockSize is not positive.
+func NewSignature(r io.Reader, blockSize int) (*Signature, error) {
+	if blockSize <= 0 {
+		panic("delta: block size must be positive")
+	}
+
+	var sig *Signature = &Signature{BlockSize: blockSize}
+	var buf []byte = make([]byte, blockSize)
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			var rs rollsum
+			rs.init(buf[:n])
+			sig.Blocks = append(sig.Blocks, Block{Weak: rs.sum(), Strong: nzaat.Checksum(buf[:n])})
+			sig.Size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return sig, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// blockLen returns the length of block i of the old file.
+func (sig *Signature) blockLen(i int) int {
+	return int(min(int64(sig.BlockSize), sig.Size-int64(i)*int64(sig.BlockSize)))
+}
+
+// OpKind is the kind of a delta operation.
+type OpKind byte
+
+const (
+	// OpCopy copies Count blocks of the old file, starting at Block.
+	OpCopy OpKind = 'C'
+
+	// OpLiteral inserts Data.
+	OpLiteral OpKind = 'L'
+)
+
+// Op is one operation of a delta.
+type Op struct {
+	Kind  OpKind
+	Block int
+	Count int
+	Data  []byte
+}
+
+// Delta is the list of operations turning an old file into a new one.
+type Delta struct {
+	BlockSize int
+
+	// Size and Sum are the size and NZAAT checksum of the new file.
+	Size int64
+	Sum  uint32
+
+	Ops []Op
+}
+
+// copyBlock appends a copy of old block i, extending the last copy if
+// it ends at i.
+func (d *Delta) copyBlock(i int) {
+	if n := len(d.Ops) - 1; n >= 0 && d.Ops[n].Kind == OpCopy && d.Ops[n].Block+d.Ops[n].Count == i {
+		d.Ops[n].Count++
+		return
+	}
+	d.Ops = append(d.Ops, Op{Kind: OpCopy, Block: i, Count: 1})
+}
+
+// literal appends the literal data p, if there is any.
+func (d *Delta) literal(p []byte) {
+	if len(p) > 0 {
+		d.Ops = append(d.Ops, Op{Kind: OpLiteral, Data: p})
+	}
+}
+
+// New reads the new file from r and returns its delta against the old
+// file described by sig. The new file is held in memory.
+func New(sig *Signature, r io.Reader) (*Delta, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var d *Delta = &Delta{
+		BlockSize: sig.BlockSize,
+		Size:      int64(len(data)),
+		Sum:       nzaat.Checksum(data),
+	}
+	var bs int = sig.BlockSize
+	var full int = int(sig.Size / int64(bs))
+	var index map[uint32][]int = make(map[uint32][]int, len(sig.Blocks))
+	for i, b := range sig.Blocks {
+		index[b.Weak] = append(index[b.Weak], i)
+	}
+
+	// match returns the old block of the same length as p which has the
+	// same checksums, or -1.
+	match := func(weak uint32, p []byte) int {
+		var strong uint32
+		var computed bool
+		for _, i := range index[weak] {
+			if sig.blockLen(i) != len(p) {
+				continue
+			}
+			if !computed {
+				strong, computed = nzaat.Checksum(p), true
+			}
+			if sig.Blocks[i].Strong == strong {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var start, i int
+	var rs rollsum
+	if len(data) >= bs && full > 0 {
+		rs.init(data[:bs])
+		for {
+			if b := match(rs.sum(), data[i:i+bs]); b >= 0 {
+				d.literal(data[start:i])
+				d.copyBlock(b)
+				i += bs
+				start = i
+				if i+bs > len(data) {
+					break
+				}
+				rs.init(data[i : i+bs])
+				continue
+			}
+			if i+bs >= len(data) {
+				break
+			}
+			rs.roll(data[i], data[i+bs])
+			i++
+		}
+	}
+
+	// The short last block of the old file can only match the end of
+	// the new file.
+	if last := len(sig.Blocks) - 1; last >= full && len(data)-start >= sig.blockLen(last) {
+		var tail []byte = data[len(data)-sig.blockLen(last):]
+		var tr rollsum
+		tr.init(tail)
+		if match(tr.sum(), tail) == last {
+			d.literal(data[start : len(data)-len(tail)])
+			d.copyBlock(last)
+			return d, nil
+		}
+	}
+
+	d.literal(data[start:])
+	return d, nil
+}
+
+// Patch applies d to the old file and writes the new file to w. It
+// returns ErrChecksum if the result does not have the checksum recorded
+// in d; in that case, w has already received the wrong data.
+func Patch(old io.ReaderAt, d *Delta, w io.Writer) error {
+	var h nzaat.Digest
+	var mw io.Writer = io.MultiWriter(w, &h)
+	var size int64
+
+	for _, op := range d.Ops {
+		switch op.Kind {
+		case OpCopy:
+			if op.Block < 0 || op.Count <= 0 {
+				return fmt.Errorf("%w: copy of %d blocks at %d", ErrFormat, op.Count, op.Block)
+			}
+			var off int64 = int64(op.Block) * int64(d.BlockSize)
+			var r *io.SectionReader = io.NewSectionReader(old, off, int64(op.Count)*int64(d.BlockSize))
+			n, err := io.Copy(mw, r)
+			if err != nil {
+				return err
+			}
+			if n <= int64(op.Count-1)*int64(d.BlockSize) {
+				return fmt.Errorf("%w: %d", ErrBlock, op.Block+op.Count-1)
+			}
+			size += n
+		case OpLiteral:
+			if _, err := mw.Write(op.Data); err != nil {
+				return err
+			}
+			size += int64(len(op.Data))
+		default:
+			return fmt.Errorf("%w: operation %q", ErrFormat, op.Kind)
+		}
+	}
+
+	if size != d.Size || h.Sum32() != d.Sum {
+		return ErrChecksum
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package delta
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+// roundTrip computes the delta from old to new through a serialized
+// signature and delta, patches old with it and returns the delta.
+func roundTrip(t *testing.T, old, new []byte, blockSize int) *Delta {
+	t.Helper()
+
+	sig, err := NewSignature(bytes.NewReader(old), blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := sig.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if sig, err = ReadSignature(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := New(sig, bytes.NewReader(new))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if _, err := d.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if d, err = ReadDelta(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := Patch(bytes.NewReader(old), d, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), new) {
+		t.Fatalf("Patched file differs from the new file")
+	}
+	return d
+}
+
+// literals returns the number of literal bytes in d.
+func literals(d *Delta) int {
+	var n int
+	for _, op := range d.Ops {
+		n += len(op.Data)
+	}
+	return n
+}
+
+// Test deltas between files with typical edits.
+func TestDelta(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(3, 4))
+	var old []byte = make([]byte, 10000)
+	for i := range old {
+		old[i] = byte(rng.Uint32())
+	}
+	insert := func(p []byte, at int, s string) []byte {
+		return append(append(append([]byte{}, p[:at]...), s...), p[at:]...)
+	}
+
+	var tests = []struct {
+		name     string
+		new      []byte
+		literals int
+	}{
+		{"identical", old, 0},
+		{"empty", nil, 0},
+		{"insert", insert(old, 3000, "inserted text"), 13 + 64},
+		{"delete", append(append([]byte{}, old[:5000]...), old[5100:]...), 64},
+		{"prepend", insert(old, 0, "header"), 6},
+		{"append", append(append([]byte{}, old...), "trailer"...), 7 + 10000%64},
+		{"truncate", old[:7000], 7000 % 64},
+		{"replace", bytes.Repeat([]byte{'x'}, 500), 500},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var d *Delta = roundTrip(t, old, test.new, 64)
+			if got := literals(d); got > test.literals {
+				t.Errorf("Got %d literal bytes, expected at most %d", got, test.literals)
+			}
+		})
+	}
+
+	if d := roundTrip(t, old, old, 64); len(d.Ops) != 1 || d.Ops[0].Kind != OpCopy {
+		t.Errorf("Identical files: got %d operations, expected a single copy", len(d.Ops))
+	}
+	roundTrip(t, nil, old, 64)
+	roundTrip(t, old[:10], old[:20], 64)
+}
+
+// Test that Patch detects a delta applied to the wrong old file.
+func TestPatchWrongFile(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(5, 6))
+	var old []byte = make([]byte, 1600)
+	for i := range old {
+		old[i] = byte(rng.Uint32())
+	}
+	sig, _ := NewSignature(bytes.NewReader(old), 64)
+	d, _ := New(sig, bytes.NewReader(append([]byte("x"), old...)))
+
+	var other []byte = bytes.Clone(old)
+	other[100] = 'X'
+	if err := Patch(bytes.NewReader(other), d, &bytes.Buffer{}); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Got %v, expected ErrChecksum", err)
+	}
+	if err := Patch(bytes.NewReader(old[:64]), d, &bytes.Buffer{}); !errors.Is(err, ErrBlock) {
+		t.Errorf("Got %v, expected ErrBlock", err)
+	}
+}
+
+// Test that malformed input is rejected.
+func TestReadMalformed(t *testing.T) {
+	for _, in := range []string{"", "NZSG", "XXXX\x40\x00\x00", "NZSG\x40\x80\x01\x00", "NZSG\x40\x10\x01"} {
+		if _, err := ReadSignature(bytes.NewReader([]byte(in))); !errors.Is(err, ErrFormat) {
+			t.Errorf("ReadSignature(%q): got %v, expected ErrFormat", in, err)
+		}
+	}
+	for _, in := range []string{"", "NZDL\x40", "NZDL\x40\x05abcd\x01X", "NZDL\x40\x05abcd\x01L\x10abc", "NZDL\x40\x05abcd\x01L\x03a"} {
+		if _, err := ReadDelta(bytes.NewReader([]byte(in))); !errors.Is(err, ErrFormat) {
+			t.Errorf("ReadDelta(%q): got %v, expected ErrFormat", in, err)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package delta
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	signatureMagic = "NZSG"
+	deltaMagic     = "NZDL"
+)
+
+// byteReader is a reader the uvarints can be read from.
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// newByteReader returns r as a byteReader, buffering it if needed.
+func newByteReader(r io.Reader) byteReader {
+	if br, ok := r.(byteReader); ok {
+		return br
+	}
+	return bufio.NewReader(r)
+}
+
+// readMagic reads and checks the magic number at the start of r.
+func readMagic(r io.Reader, magic string) error {
+	var b [4]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	if string(b[:]) != magic {
+		return fmt.Errorf("%w: bad magic %q", ErrFormat, b[:])
+	}
+	return nil
+}
+
+// readUvarint reads a uvarint no larger than max.
+func readUvarint(r io.ByteReader, max uint64) (uint64, error) {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	if v > max {
+		return 0, fmt.Errorf("%w: value %d out of range", ErrFormat, v)
+	}
+	return v, nil
+}
+
+// WriteTo writes the signature to w in its binary format: the magic
+// number "NZSG", the block size, the file size and the number of
+// blocks, followed by the rolling and NZAAT checksums of each block as
+// four big-endian octets each.
+func (sig *Signature) WriteTo(w io.Writer) (int64, error) {
+	var b []byte = []byte(signatureMagic)
+	b = binary.AppendUvarint(b, uint64(sig.BlockSize))
+	b = binary.AppendUvarint(b, uint64(sig.Size))
+	b = binary.AppendUvarint(b, uint64(len(sig.Blocks)))
+	for _, blk := range sig.Blocks {
+		b = binary.BigEndian.AppendUint32(b, blk.Weak)
+		b = binary.BigEndian.AppendUint32(b, blk.Strong)
+	}
+
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadSignature reads a signature written by Signature.WriteTo.
+func ReadSignature(r io.Reader) (*Signature, error) {
+	var br byteReader = newByteReader(r)
+	if err := readMagic(br, signatureMagic); err != nil {
+		return nil, err
+	}
+
+	blockSize, err := readUvarint(br, 1<<30)
+	if err != nil {
+		return nil, err
+	}
+	size, err := readUvarint(br, 1<<62)
+	if err != nil {
+		return nil, err
+	}
+	count, err := readUvarint(br, 1<<62)
+	if err != nil {
+		return nil, err
+	}
+	if blockSize == 0 || count != (size+blockSize-1)/blockSize {
+		return nil, fmt.Errorf("%w: %d blocks of %d bytes for %d bytes", ErrFormat, count, blockSize, size)
+	}
+
+	var sig *Signature = &Signature{BlockSize: int(blockSize), Size: int64(size)}
+	for i := uint64(0); i < count; i++ {
+		var b [8]byte
+		if _, err := io.ReadFull(br, b[:]); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+		sig.Blocks = append(sig.Blocks, Block{
+			Weak:   binary.BigEndian.Uint32(b[:4]),
+			Strong: binary.BigEndian.Uint32(b[4:]),
+		})
+	}
+	return sig, nil
+}
+
+// WriteTo writes the delta to w in its binary format: the magic number
+// "NZDL", the block size, the size of the new file, its NZAAT checksum
+// as four big-endian octets and the number of operations, followed by
+// the operations. A copy is the octet 'C', the first block and the
+// number of blocks; a literal is the octet 'L', the length of the data
+// and the data.
+func (d *Delta) WriteTo(w io.Writer) (int64, error) {
+	var b []byte = []byte(deltaMagic)
+	var total int64
+
+	b = binary.AppendUvarint(b, uint64(d.BlockSize))
+	b = binary.AppendUvarint(b, uint64(d.Size))
+	b = binary.BigEndian.AppendUint32(b, d.Sum)
+	b = binary.AppendUvarint(b, uint64(len(d.Ops)))
+
+	for _, op := range d.Ops {
+		b = append(b, byte(op.Kind))
+		switch op.Kind {
+		case OpCopy:
+			b = binary.AppendUvarint(b, uint64(op.Block))
+			b = binary.AppendUvarint(b, uint64(op.Count))
+		case OpLiteral:
+			b = binary.AppendUvarint(b, uint64(len(op.Data)))
+			n, err := w.Write(b)
+			total += int64(n)
+			if err != nil {
+				return total, err
+			}
+			n, err = w.Write(op.Data)
+			total += int64(n)
+			if err != nil {
+				return total, err
+			}
+			b = b[:0]
+		default:
+			return total, fmt.Errorf("%w: operation %q", ErrFormat, op.Kind)
+		}
+	}
+
+	n, err := w.Write(b)
+	return total + int64(n), err
+}
+
+// ReadDelta reads a delta written by Delta.WriteTo.
+func ReadDelta(r io.Reader) (*Delta, error) {
+	var br byteReader = newByteReader(r)
+	if err := readMagic(br, deltaMagic); err != nil {
+		return nil, err
+	}
+
+	blockSize, err := readUvarint(br, 1<<30)
+	if err != nil {
+		return nil, err
+	}
+	size, err := readUvarint(br, 1<<62)
+	if err != nil {
+		return nil, err
+	}
+	var sum [4]byte
+	if _, err := io.ReadFull(br, sum[:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	count, err := readUvarint(br, 1<<62)
+	if err != nil {
+		return nil, err
+	}
+
+	var d *Delta = &Delta{
+		BlockSize: int(blockSize),
+		Size:      int64(size),
+		Sum:       binary.BigEndian.Uint32(sum[:]),
+	}
+	var literals uint64
+	for i := uint64(0); i < count; i++ {
+		kind, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+
+		switch OpKind(kind) {
+		case OpCopy:
+			block, err := readUvarint(br, 1<<62)
+			if err != nil {
+				return nil, err
+			}
+			n, err := readUvarint(br, 1<<62)
+			if err != nil {
+				return nil, err
+			}
+			d.Ops = append(d.Ops, Op{Kind: OpCopy, Block: int(block), Count: int(n)})
+		case OpLiteral:
+			// Literals can't add up to more than the new file.
+			n, err := readUvarint(br, size-literals)
+			if err != nil {
+				return nil, err
+			}
+			literals += n
+
+			// Read through a LimitReader so that a bogus length
+			// doesn't allocate more than the input really holds.
+			data, err := io.ReadAll(io.LimitReader(br, int64(n)))
+			if err != nil {
+				return nil, err
+			}
+			if uint64(len(data)) != n {
+				return nil, fmt.Errorf("%w: %w", ErrFormat, io.ErrUnexpectedEOF)
+			}
+			d.Ops = append(d.Ops, Op{Kind: OpLiteral, Data: data})
+		default:
+			return nil, fmt.Errorf("%w: operation %q", ErrFormat, kind)
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package delta
+
+// rollsumOffset is added to every octet so that runs of NUL octets of
+// different lengths have different checksums.
+const rollsumOffset = 31
+
+// rollsum is the rolling checksum of rsync over a window of n octets
+// x₁…xₙ: a is the sum of all xᵢ+c, and b the sum of all (n-i+1)(xᵢ+c).
+// Both can be updated in constant time as the window moves by one
+// octet.
+type rollsum struct {
+	a, b uint32
+	n    uint32
+}
+
+// init sets the window to p.
+func (r *rollsum) init(p []byte) {
+	r.a, r.b, r.n = 0, 0, uint32(len(p))
+	for _, c := range p {
+		r.a += uint32(c) + rollsumOffset
+		r.b += r.a
+	}
+}
+
+// roll moves the window by one octet, removing out and adding in.
+func (r *rollsum) roll(out, in byte) {
+	r.a += uint32(in) - uint32(out)
+	r.b += r.a - r.n*(uint32(out)+rollsumOffset)
+}
+
+// sum returns the checksum of the window.
+func (r *rollsum) sum() uint32 {
+	return r.b<<16 | r.a&0xffff
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package delta
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// Test that rolling the window gives the same checksum as computing it
+// from scratch.
+func TestRollsum(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(1, 2))
+	var data []byte = make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+
+	for _, n := range []int{1, 7, 64, 500} {
+		var r rollsum
+		r.init(data[:n])
+		for i := 0; i+n < len(data); i++ {
+			r.roll(data[i], data[i+n])
+
+			var want rollsum
+			want.init(data[i+1 : i+1+n])
+			if r.sum() != want.sum() {
+				t.Fatalf("Window %d at %d: got %08x, expected %08x", n, i+1, r.sum(), want.sum())
+			}
+		}
+	}
+}
+
+// Test that runs of NUL octets of different lengths differ.
+func TestRollsumZeroes(t *testing.T) {
+	var a, b rollsum
+	a.init(make([]byte, 3))
+	b.init(make([]byte, 4))
+	if a.sum() == b.sum() {
+		t.Errorf("Runs of 3 and 4 NULs have the same checksum %08x", a.sum())
+	}
+}
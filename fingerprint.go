@@ -0,0 +1,37 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "encoding/binary"
+
+// fingerprint64Seed is prepended to the input for the low half of
+// Fingerprint64. Its four big-endian octets spell "nzfp".
+const fingerprint64Seed = 0x6e7a6670
+
+// Fingerprint32 returns a 32-bit fingerprint of data for storing and
+// comparing later. Unlike the hash.Hash32 implementations of this
+// package, which may gain variants and options, the fingerprint
+// functions are fixed: their results for any input will never change
+// in any future release, so fingerprints stored today remain valid.
+//
+// Fingerprint32 is the plain NZAAT checksum of data.
+func Fingerprint32(data []byte) uint32 {
+	return Checksum(data)
+}
+
+// Fingerprint64 returns a 64-bit fingerprint of data, with the same
+// guarantee of stability as Fingerprint32. The upper 32 bits are the
+// NZAAT checksum of data, and the lower 32 bits are the NZAAT checksum
+// of the four octets "nzfp" followed by data.
+func Fingerprint64(data []byte) uint64 {
+	var d Digest
+	var seed [4]byte
+
+	binary.BigEndian.PutUint32(seed[:], fingerprint64Seed)
+	d.Write(seed[:])
+	d.Write(data)
+	return uint64(Checksum(data))<<32 | uint64(d.Sum32())
+}
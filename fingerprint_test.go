@@ -0,0 +1,38 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test the fingerprints against fixed values. These values must never
+// change: if this test fails, the change breaks fingerprints stored by
+// users and has to be reverted, not the test updated.
+func TestFingerprintStable(t *testing.T) {
+	var tests = []struct {
+		in   string
+		fp32 uint32
+		fp64 uint64
+	}{
+		{"", 0x00000000, 0x000000002242850b},
+		{"a", 0xc31517c4, 0xc31517c4aab4a6f3},
+		{"abc", 0xc3e39e2d, 0xc3e39e2dcfe1b9d2},
+		{"message digest", 0x434b78b4, 0x434b78b49520d659},
+		{"\x00", 0x20e9c0b3, 0x20e9c0b3bee65533},
+		{strings.Repeat("0123456789", 1000), 0x7888eba3, 0x7888eba3f7ebb72d},
+	}
+
+	for _, test := range tests {
+		if got := Fingerprint32([]byte(test.in)); got != test.fp32 {
+			t.Errorf("Fingerprint32(%.20q): got %08x, expected %08x", test.in, got, test.fp32)
+		}
+		if got := Fingerprint64([]byte(test.in)); got != test.fp64 {
+			t.Errorf("Fingerprint64(%.20q): got %016x, expected %016x", test.in, got, test.fp64)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FoldMode selects how NewFold folds the case of its input.
+type FoldMode int
+
+const (
+	// FoldASCII folds the letters A to Z to lower case and leaves all
+	// other octets alone. It suits HTTP header names, host names and
+	// similar protocol identifiers.
+	FoldASCII FoldMode = iota
+
+	// FoldUnicode decodes the input as UTF-8 and replaces every rune
+	// by a canonical member of its simple case folding orbit, so that
+	// strings for which strings.EqualFold reports true hash the same.
+	// Like FoldASCII, it folds ASCII letters to lower case, so both
+	// modes give the same checksum for ASCII input. Invalid UTF-8 is
+	// hashed unchanged.
+	FoldUnicode
+)
+
+type foldDigest struct {
+	d        Digest
+	mode     FoldMode
+	pending  [utf8.UTFMax]byte
+	npending int
+}
+
+// NewFold returns a new hash.Hash32 computing the NZAAT checksum of its
+// input with the case folded according to mode, without the caller
+// having to make a folded copy of the input.
+func NewFold(mode FoldMode) hash.Hash32 {
+	return &foldDigest{mode: mode}
+}
+
+// ChecksumFold returns the NZAAT checksum of data with the case folded
+// according to mode.
+func ChecksumFold(data []byte, mode FoldMode) uint32 {
+	var h hash.Hash32 = NewFold(mode)
+	h.Write(data)
+	return h.Sum32()
+}
+
+func (f *foldDigest) Reset() {
+	f.d.Reset()
+	f.npending = 0
+}
+
+func (f *foldDigest) Size() int {
+	return 4
+}
+
+func (f *foldDigest) BlockSize() int {
+	return 1
+}
+
+// foldRune returns the smallest rune which is equivalent to r under
+// simple case folding, except that ASCII letters are folded to lower
+// case like FoldASCII does. This also applies to the orbits of runes
+// such as the Kelvin sign, which contain an ASCII letter.
+func foldRune(r rune) rune {
+	var min rune = r
+	for c := unicode.SimpleFold(r); c != r; c = unicode.SimpleFold(c) {
+		if c < min {
+			min = c
+		}
+	}
+	if 'A' <= min && min <= 'Z' {
+		min += 'a' - 'A'
+	}
+	return min
+}
+
+func (f *foldDigest) writeRune(r rune) {
+	var b [utf8.UTFMax]byte
+	f.d.Write(b[:utf8.EncodeRune(b[:], foldRune(r))])
+}
+
+func (f *foldDigest) Write(p []byte) (nn int, err error) {
+	nn = len(p)
+
+	if f.mode == FoldASCII {
+		for _, c := range p {
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			f.d.update(c)
+		}
+		return
+	}
+
+	// Complete a rune split across calls to Write first. If the octets
+	// turn out not to be valid UTF-8, the ones after the first are fed
+	// through again, as they might start a new rune.
+	for f.npending > 0 && len(p) > 0 {
+		f.pending[f.npending] = p[0]
+		f.npending++
+		p = p[1:]
+
+		if utf8.FullRune(f.pending[:f.npending]) {
+			var rest [utf8.UTFMax]byte
+			var n int = f.npending
+
+			copy(rest[:], f.pending[:n])
+			f.npending = 0
+			f.writeRunes(rest[:n])
+		}
+	}
+
+	f.writeRunes(p)
+	return
+}
+
+// writeRunes folds and hashes the runes of p, keeping an incomplete
+// rune at the end for the next call to Write.
+func (f *foldDigest) writeRunes(p []byte) {
+	for len(p) > 0 {
+		if p[0] < utf8.RuneSelf {
+			// ASCII letters fold to lower case, as in
+			// FoldASCII.
+			var c byte = p[0]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			f.d.update(c)
+			p = p[1:]
+			continue
+		}
+		if !utf8.FullRune(p) {
+			f.npending = copy(f.pending[:], p)
+			return
+		}
+
+		r, size := utf8.DecodeRune(p)
+		if r == utf8.RuneError && size == 1 {
+			f.d.update(p[0])
+		} else {
+			f.writeRune(r)
+		}
+		p = p[size:]
+	}
+}
+
+func (f *foldDigest) WriteString(s string) (nn int, err error) {
+	return f.Write([]byte(s))
+}
+
+// Incomplete UTF-8 at the end of the input is hashed unchanged.
+func (f *foldDigest) Sum32() uint32 {
+	var d Digest = f.d
+	d.Write(f.pending[:f.npending])
+	return d.Sum32()
+}
+
+func (f *foldDigest) Sum(in []byte) []byte {
+	var s uint32 = f.Sum32()
+	return append(in, byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
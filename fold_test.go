@@ -0,0 +1,80 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"strings"
+	"testing"
+)
+
+// Test ASCII folding of header names.
+func TestFoldASCII(t *testing.T) {
+	if ChecksumFold([]byte("Content-Type"), FoldASCII) != Checksum([]byte("content-type")) {
+		t.Error("ASCII folding did not hash like the lower case string")
+	}
+	if ChecksumFold([]byte("Straße"), FoldASCII) == ChecksumFold([]byte("STRASSE"), FoldASCII) {
+		t.Error("ASCII folding folded non-ASCII text")
+	}
+}
+
+// Test that strings equal under strings.EqualFold hash equally.
+func TestFoldUnicode(t *testing.T) {
+	for _, pair := range [][2]string{
+		{"Ünïcödé", "üNÏCÖDÉ"},
+		{"k", "K"},
+		{"ſ", "S"},
+		{"Σίσυφος", "ΣΊΣΥΦΟΣ"},
+	} {
+		if !strings.EqualFold(pair[0], pair[1]) {
+			t.Fatalf("Bad test case %q", pair)
+		}
+		if ChecksumFold([]byte(pair[0]), FoldUnicode) != ChecksumFold([]byte(pair[1]), FoldUnicode) {
+			t.Errorf("%q and %q hash differently", pair[0], pair[1])
+		}
+	}
+
+	if ChecksumFold([]byte("abc"), FoldUnicode) == ChecksumFold([]byte("abd"), FoldUnicode) {
+		t.Error("Different strings hash equally")
+	}
+}
+
+// Test that both modes fold ASCII letters to lower case, so they agree
+// on ASCII input.
+func TestFoldModesAgree(t *testing.T) {
+	if ChecksumFold([]byte("Content-Type"), FoldUnicode) != ChecksumFold([]byte("Content-Type"), FoldASCII) {
+		t.Error("The fold modes disagree on ASCII input")
+	}
+	for in, want := range map[string]string{"\u212a": "k", "\u017f": "s"} {
+		if ChecksumFold([]byte(in), FoldUnicode) != Checksum([]byte(want)) {
+			t.Errorf("%q did not fold to %q", in, want)
+		}
+	}
+}
+
+// Test that runes split across writes are decoded correctly.
+func TestFoldSplitWrites(t *testing.T) {
+	var input []byte = []byte("xÜK\xffé\xe2a\xf0\x9fb")
+	var want uint32 = ChecksumFold(input, FoldUnicode)
+
+	for i := 0; i <= len(input); i++ {
+		for j := i; j <= len(input); j++ {
+			var h hash.Hash32 = NewFold(FoldUnicode)
+			h.Write(input[:i])
+			h.Write(input[i:j])
+			h.Write(input[j:])
+			if h.Sum32() != want {
+				t.Errorf("Split at %d, %d gave %x instead of %x", i, j, h.Sum32(), want)
+			}
+		}
+	}
+
+	var h hash.Hash32 = NewFold(FoldUnicode)
+	h.Write([]byte("\xc3"))
+	if h.Sum32() != Checksum([]byte("\xc3")) {
+		t.Error("Incomplete trailing rune was not hashed unchanged")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSum is returned, wrapped, by ParseSum for strings which are
+// not a hexadecimal checksum.
+var ErrInvalidSum = errors.New("nzaat: invalid checksum")
+
+// FormatSum returns sum as 8 lower case hexadecimal digits, which is
+// the hex encoding of the output of Sum.
+func FormatSum(sum uint32) string {
+	return fmt.Sprintf("%08x", sum)
+}
+
+// ParseSum parses a checksum as written by FormatSum or by hex encoding
+// the output of Sum. It is lenient about the details: surrounding white
+// space, a 0x prefix, upper case digits and missing leading zeroes, as
+// produced by formatting with %x or %X, are all accepted.
+func ParseSum(s string) (uint32, error) {
+	var digits string = strings.TrimSpace(s)
+	var sum uint32
+
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits = digits[2:]
+	}
+	if len(digits) == 0 || len(digits) > 8 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSum, s)
+	}
+
+	for i := 0; i < len(digits); i++ {
+		var c byte = digits[i]
+		var v byte
+
+		switch {
+		case '0' <= c && c <= '9':
+			v = c - '0'
+		case 'a' <= c && c <= 'f':
+			v = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			v = c - 'A' + 10
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrInvalidSum, s)
+		}
+		sum = sum<<4 | uint32(v)
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"testing"
+)
+
+// Test that the formats of FormatSum, Sum and %x can be parsed back.
+func TestFormatParseSum(t *testing.T) {
+	var h hash.Hash32 = New()
+	h.Write([]byte("a"))
+
+	if FormatSum(h.Sum32()) != "c31517c4" || FormatSum(1) != "00000001" {
+		t.Errorf("FormatSum returned %q", FormatSum(h.Sum32()))
+	}
+
+	for _, s := range []string{
+		FormatSum(h.Sum32()),
+		hex.EncodeToString(h.Sum(nil)),
+		fmt.Sprintf("%X", h.Sum32()),
+		fmt.Sprintf("%#x", h.Sum32()),
+		" c31517c4\n",
+	} {
+		sum, err := ParseSum(s)
+		if err != nil || sum != 0xc31517c4 {
+			t.Errorf("ParseSum(%q) = %x, %v", s, sum, err)
+		}
+	}
+
+	if sum, err := ParseSum("48009"); err != nil || sum != 0x48009 {
+		t.Errorf("ParseSum(\"48009\") = %x, %v", sum, err)
+	}
+}
+
+// Test that malformed checksums are rejected.
+func TestParseSumInvalid(t *testing.T) {
+	for _, s := range []string{"", "0x", "c31517c4c", "c31517g4", "-1", "c315 17c4"} {
+		if _, err := ParseSum(s); !errors.Is(err, ErrInvalidSum) {
+			t.Errorf("ParseSum(%q) returned %v", s, err)
+		}
+	}
+}
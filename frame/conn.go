@@ -0,0 +1,125 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package frame
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// CorruptError is returned by a Conn when a received message fails its
+// checksum or is malformed.
+type CorruptError struct {
+	// Remote is the address of the peer.
+	Remote net.Addr
+
+	// Message is the index of the bad message, counting from 0.
+	Message uint64
+
+	// Err is ErrChecksum or ErrTooLarge.
+	Err error
+}
+
+func (e *CorruptError) Error() string {
+	return fmt.Sprintf("frame: message %d from %v: %v", e.Message, e.Remote, e.Err)
+}
+
+func (e *CorruptError) Unwrap() error {
+	return e.Err
+}
+
+// Conn is a net.Conn which sends every Write as a checksummed frame and
+// verifies the frames it receives. Both ends of the connection have to
+// use a Conn. Reads and writes may happen concurrently.
+type Conn struct {
+	net.Conn
+
+	rmtx     sync.Mutex
+	r        *Reader
+	pending  []byte
+	received uint64
+	rerr     error
+
+	wmtx sync.Mutex
+	w    *Writer
+}
+
+// NewConn wraps c, accepting messages of up to DefaultMaxSize bytes.
+func NewConn(c net.Conn) *Conn {
+	return NewConnSize(c, DefaultMaxSize)
+}
+
+// NewConnSize wraps c, accepting messages of up to max bytes.
+func NewConnSize(c net.Conn, max int) *Conn {
+	return &Conn{
+		Conn: c,
+		r:    NewReaderSize(bufio.NewReader(c), max),
+		w:    NewWriterSize(c, max),
+	}
+}
+
+// Write sends p as a single message.
+func (c *Conn) Write(p []byte) (int, error) {
+	c.wmtx.Lock()
+	defer c.wmtx.Unlock()
+
+	return c.w.Write(p)
+}
+
+// ReadMessage returns the next message in full. The slice is only valid
+// until the next read. Once a corrupt message has been received, the
+// *CorruptError is returned for all further reads, since the stream
+// can no longer be trusted.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	c.rmtx.Lock()
+	defer c.rmtx.Unlock()
+
+	if len(c.pending) > 0 {
+		var p []byte = c.pending
+		c.pending = nil
+		return p, nil
+	}
+	return c.next()
+}
+
+func (c *Conn) next() ([]byte, error) {
+	if c.rerr != nil {
+		return nil, c.rerr
+	}
+
+	p, err := c.r.ReadFrame()
+	if err == ErrChecksum || err == ErrTooLarge {
+		err = &CorruptError{Remote: c.RemoteAddr(), Message: c.received, Err: err}
+		c.rerr = err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.received++
+	return p, nil
+}
+
+// Read reads the payload of the received messages as a stream, without
+// preserving the message boundaries.
+func (c *Conn) Read(p []byte) (int, error) {
+	c.rmtx.Lock()
+	defer c.rmtx.Unlock()
+
+	for len(c.pending) == 0 {
+		msg, err := c.next()
+		if err != nil {
+			return 0, err
+		}
+		c.pending = msg
+	}
+
+	var n int = copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package frame
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+)
+
+// Test exchanging messages and reading them as a stream.
+func TestConn(t *testing.T) {
+	a, b := net.Pipe()
+	var ca, cb *Conn = NewConn(a), NewConn(b)
+	defer ca.Close()
+	defer cb.Close()
+
+	go func() {
+		ca.Write([]byte("hello"))
+		ca.Write([]byte("world"))
+	}()
+
+	msg, err := cb.ReadMessage()
+	if err != nil || string(msg) != "hello" {
+		t.Errorf("Got %q, %v", msg, err)
+	}
+
+	var buf [3]byte
+	var got []byte
+	for len(got) < 5 {
+		n, err := cb.Read(buf[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, buf[:n]...)
+	}
+	if string(got) != "world" {
+		t.Errorf("Read %q", got)
+	}
+}
+
+// Test that corruption on the wire is reported as a CorruptError.
+func TestConnCorrupt(t *testing.T) {
+	a, b := net.Pipe()
+	var cb *Conn = NewConn(b)
+	defer a.Close()
+	defer cb.Close()
+
+	var buf bytes.Buffer
+	var w *Writer = NewWriter(&buf)
+	w.Write([]byte("good"))
+	w.Write([]byte("bad"))
+	var wire []byte = buf.Bytes()
+	wire[len(wire)-5] ^= 1
+
+	go a.Write(wire)
+
+	if msg, err := cb.ReadMessage(); err != nil || string(msg) != "good" {
+		t.Fatalf("Got %q, %v", msg, err)
+	}
+
+	_, err := cb.ReadMessage()
+	var ce *CorruptError
+	if !errors.As(err, &ce) || !errors.Is(err, ErrChecksum) || ce.Message != 1 {
+		t.Errorf("Expected a CorruptError for message 1, got %v", err)
+	}
+	if _, err2 := cb.Read(make([]byte, 1)); err2 != err {
+		t.Errorf("Error was not sticky: %v", err2)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package frame implements a simple framing of byte streams, with an
+// NZAAT checksum on every frame.
+//
+// A frame is the length of the payload as a big endian 32 bit integer,
+// the payload, and a trailer with the NZAAT checksum of the length and
+// the payload, also big endian.
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// DefaultMaxSize is the largest payload accepted by the Reader and
+// Writer returned by NewReader and NewWriter.
+const DefaultMaxSize = 1 << 20
+
+// Overhead is the number of bytes a frame adds to its payload.
+const Overhead = 8
+
+var (
+	// ErrChecksum is returned for a frame whose trailer doesn't match.
+	ErrChecksum = errors.New("frame: checksum mismatch")
+
+	// ErrTooLarge is returned for frames over the maximum size.
+	ErrTooLarge = errors.New("frame: frame too large")
+)
+
+// Writer writes frames to an io.Writer.
+type Writer struct {
+	w   io.Writer
+	max int
+	buf []byte
+}
+
+// NewWriter returns a Writer writing to w which accepts frames of up to
+// DefaultMaxSize bytes.
+func NewWriter(w io.Writer) *Writer {
+	return NewWriterSize(w, DefaultMaxSize)
+}
+
+// NewWriterSize returns a Writer writing to w which accepts frames of
+// up to max bytes.
+func NewWriterSize(w io.Writer, max int) *Writer {
+	return &Writer{w: w, max: max}
+}
+
+// Write writes p as a single frame, with a single call to the
+// underlying writer. It returns len(p) on success.
+func (w *Writer) Write(p []byte) (int, error) {
+	if len(p) > w.max {
+		return 0, ErrTooLarge
+	}
+
+	var d nzaat.Digest
+	w.buf = binary.BigEndian.AppendUint32(w.buf[:0], uint32(len(p)))
+	w.buf = append(w.buf, p...)
+	d.Write(w.buf)
+	w.buf = binary.BigEndian.AppendUint32(w.buf, d.Sum32())
+
+	if _, err := w.w.Write(w.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Reader reads frames from an io.Reader.
+type Reader struct {
+	r   io.Reader
+	max int
+	buf []byte
+}
+
+// NewReader returns a Reader reading from r which accepts frames of up
+// to DefaultMaxSize bytes.
+func NewReader(r io.Reader) *Reader {
+	return NewReaderSize(r, DefaultMaxSize)
+}
+
+// NewReaderSize returns a Reader reading from r which accepts frames of
+// up to max bytes.
+func NewReaderSize(r io.Reader, max int) *Reader {
+	return &Reader{r: r, max: max}
+}
+
+// ReadFrame returns the payload of the next frame. The slice is only
+// valid until the next call. At the end of the input it returns io.EOF,
+// and io.ErrUnexpectedEOF if the input ends within a frame.
+func (r *Reader) ReadFrame() ([]byte, error) {
+	var hdr [4]byte
+
+	if _, err := io.ReadFull(r.r, hdr[:]); err != nil {
+		return nil, err
+	}
+
+	var size uint32 = binary.BigEndian.Uint32(hdr[:])
+	if uint64(size) > uint64(r.max) {
+		return nil, ErrTooLarge
+	}
+
+	if cap(r.buf) < int(size)+4 {
+		r.buf = make([]byte, size+4)
+	}
+	r.buf = r.buf[:size+4]
+	if _, err := io.ReadFull(r.r, r.buf); err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+
+	var d nzaat.Digest
+	d.Write(hdr[:])
+	d.Write(r.buf[:size])
+	if d.Sum32() != binary.BigEndian.Uint32(r.buf[size:]) {
+		return nil, ErrChecksum
+	}
+
+	return r.buf[:size], nil
+}
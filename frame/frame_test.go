@@ -0,0 +1,60 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package frame
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// Test writing and reading back a few frames.
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	var w *Writer = NewWriter(&buf)
+	var frames []string = []string{"abc", "", "message digest"}
+
+	for _, f := range frames {
+		if n, err := w.Write([]byte(f)); err != nil || n != len(f) {
+			t.Fatalf("Write(%q) = %d, %v", f, n, err)
+		}
+	}
+	if buf.Len() != 3*Overhead+17 {
+		t.Errorf("Unexpected encoded size %d", buf.Len())
+	}
+
+	var r *Reader = NewReader(&buf)
+	for _, f := range frames {
+		if p, err := r.ReadFrame(); err != nil || string(p) != f {
+			t.Errorf("Got %q, %v, expected %q", p, err, f)
+		}
+	}
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
+
+// Test the errors for damaged, truncated and oversized frames.
+func TestErrors(t *testing.T) {
+	var buf bytes.Buffer
+	NewWriter(&buf).Write([]byte("abc"))
+	var good []byte = buf.Bytes()
+
+	var damaged []byte = bytes.Clone(good)
+	damaged[5] ^= 0x80
+	if _, err := NewReader(bytes.NewReader(damaged)).ReadFrame(); err != ErrChecksum {
+		t.Errorf("Damaged frame: got %v", err)
+	}
+	if _, err := NewReader(bytes.NewReader(good[:6])).ReadFrame(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Truncated frame: got %v", err)
+	}
+	if _, err := NewReaderSize(bytes.NewReader(good), 2).ReadFrame(); err != ErrTooLarge {
+		t.Errorf("Oversized frame: got %v", err)
+	}
+	if _, err := NewWriterSize(io.Discard, 2).Write([]byte("abc")); err != ErrTooLarge {
+		t.Errorf("Writing oversized frame: got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"testing"
+)
+
+// Check that the one-shot, streaming and split computations agree. The
+// lengths of the pieces are taken from splits, one octet per piece.
+func FuzzStreaming(f *testing.F) {
+	f.Add([]byte(""), []byte(""))
+	f.Add([]byte("abc"), []byte{1})
+	f.Add([]byte("message digest"), []byte{3, 0, 5, 200})
+	f.Add([]byte("\x00\x00\x00\x00"), []byte{2, 2})
+
+	f.Fuzz(func(t *testing.T, data, splits []byte) {
+		var want uint32 = Checksum(data)
+
+		var h hash.Hash32 = New()
+		h.Write(data)
+		if h.Sum32() != want {
+			t.Fatalf("Streaming %x gave %x, one-shot %x", data, h.Sum32(), want)
+		}
+
+		var d Digest
+		var rest []byte = data
+		for i, s := range splits {
+			var n int = int(s) % (len(rest) + 1)
+			if i%2 == 0 {
+				d.Write(rest[:n])
+			} else {
+				d.WriteString(string(rest[:n]))
+			}
+			d.Sum32()
+			rest = rest[n:]
+		}
+		d.Write(rest)
+		if d.Sum32() != want {
+			t.Fatalf("Split writes %x of %x gave %x, one-shot %x",
+				splits, data, d.Sum32(), want)
+		}
+
+		// NZAT only differs for the states 0 and nzatCollision.
+		var nz uint32 = ChecksumNZAT(data)
+		if nz == 0 {
+			t.Fatalf("NZAT of %x is 0", data)
+		}
+		if d != 0 && d != nzatCollision && nz != want {
+			t.Fatalf("NZAT %x disagrees with NZAAT %x", nz, want)
+		}
+	})
+}
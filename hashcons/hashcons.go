@@ -0,0 +1,101 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package hashcons canonicalizes immutable values, so that equal values
+// are represented by the same pointer and can be compared with ==.
+//
+// Values are identified by a byte encoding supplied by the caller and
+// looked up by the NZAAT checksum of that encoding. Encodings with the
+// same checksum are compared in full, so collisions never merge values.
+// Tables holding values from untrusted sources should be created with
+// NewSeeded and a random seed, so that an attacker cannot predict which
+// encodings collide, and can report long collision chains with
+// SetFloodAlarm.
+package hashcons
+
+import "github.com/caoimhechaos/golang-nzaat"
+
+// An Encoder appends the canonical encoding of v to buf and returns the
+// extended buffer. Two values must have the same encoding if and only
+// if they are to be considered equal.
+type Encoder[T any] func(buf []byte, v T) []byte
+
+type entry[T any] struct {
+	enc []byte
+	val *T
+}
+
+// Table maps values to their canonical instances. Values must not be
+// modified after they have been passed to the Table. A Table is not
+// safe for concurrent use.
+type Table[T any] struct {
+	encode   Encoder[T]
+	entries  map[uint32][]entry[T]
+	buf      []byte
+	count    int
+	seed     nzaat.Digest
+	maxChain int
+	alarm    func(chain int)
+}
+
+// New returns an empty Table using encode to identify values.
+func New[T any](encode Encoder[T]) *Table[T] {
+	return &Table[T]{
+		encode:  encode,
+		entries: make(map[uint32][]entry[T]),
+	}
+}
+
+// NewSeeded returns an empty Table using encode to identify values,
+// which hashes the encodings with the seeded NZAAT hash selected by
+// seed. Use nzaat.MakeSeed for a seed which cannot be guessed by
+// whoever supplies the values.
+func NewSeeded[T any](seed nzaat.Seed, encode Encoder[T]) *Table[T] {
+	var t *Table[T] = New(encode)
+	t.seed = seed.Digest()
+	return t
+}
+
+// SetFloodAlarm arranges for alarm to be called with the length of the
+// collision chain whenever a lookup has to compare more than maxChain
+// encodings with the same hash. With a seed unknown to an attacker this
+// hardly ever happens, so the alarm is a sign that values are being
+// chosen to collide. A nil alarm turns the detection off again.
+func (t *Table[T]) SetFloodAlarm(maxChain int, alarm func(chain int)) {
+	t.maxChain = maxChain
+	t.alarm = alarm
+}
+
+// Intern returns the canonical instance of v. If no value equal to v
+// has been seen before, a pointer to a copy of v becomes canonical.
+func (t *Table[T]) Intern(v T) *T {
+	t.buf = t.encode(t.buf[:0], v)
+
+	var h uint32 = nzaat.Final(nzaat.Update(t.seed, t.buf))
+	var chain []entry[T] = t.entries[h]
+
+	if t.alarm != nil && len(chain) > t.maxChain {
+		t.alarm(len(chain))
+	}
+	for _, e := range chain {
+		if string(e.enc) == string(t.buf) {
+			return e.val
+		}
+	}
+
+	var p *T = new(T)
+	*p = v
+	t.entries[h] = append(t.entries[h], entry[T]{
+		enc: append([]byte(nil), t.buf...),
+		val: p,
+	})
+	t.count++
+	return p
+}
+
+// Len returns the number of distinct values in the table.
+func (t *Table[T]) Len() int {
+	return t.count
+}
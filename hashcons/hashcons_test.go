@@ -0,0 +1,86 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package hashcons
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+type node struct {
+	op          byte
+	left, right *node
+}
+
+// Children are canonical already, so their identity encodes them.
+func encodeNode(buf []byte, n node) []byte {
+	buf = append(buf, n.op)
+	buf = binary.AppendUvarint(buf, uint64(nodeID(n.left)))
+	buf = binary.AppendUvarint(buf, uint64(nodeID(n.right)))
+	return buf
+}
+
+var nodeIDs = map[*node]int{nil: 0}
+
+func nodeID(n *node) int {
+	if id, ok := nodeIDs[n]; ok {
+		return id
+	}
+	nodeIDs[n] = len(nodeIDs)
+	return nodeIDs[n]
+}
+
+// Test that structurally equal trees end up as the same pointer.
+func TestIntern(t *testing.T) {
+	var tab *Table[node] = New(encodeNode)
+
+	var a *node = tab.Intern(node{op: 'x'})
+	var b *node = tab.Intern(node{op: 'x'})
+	if a != b {
+		t.Error("Equal leaves were not canonicalized")
+	}
+
+	var s1 *node = tab.Intern(node{op: '+', left: a, right: b})
+	var s2 *node = tab.Intern(node{op: '+', left: b, right: a})
+	if s1 != s2 {
+		t.Error("Equal trees were not canonicalized")
+	}
+
+	if tab.Intern(node{op: '-', left: a, right: b}) == s1 {
+		t.Error("Different trees were canonicalized to the same value")
+	}
+	if tab.Len() != 3 {
+		t.Errorf("Expected 3 distinct values, got %d", tab.Len())
+	}
+}
+
+// Test that a seeded Table canonicalizes values and raises the flood
+// alarm on long collision chains.
+func TestSeededFloodAlarm(t *testing.T) {
+	var tab *Table[string] = NewSeeded(nzaat.NewSeed(7), func(buf []byte, v string) []byte {
+		return append(buf, v...)
+	})
+	var longest int
+
+	tab.SetFloodAlarm(2, func(chain int) {
+		longest = chain
+	})
+	var a *string = tab.Intern("abc")
+	if tab.Intern("abc") != a {
+		t.Error("Equal values were not canonicalized")
+	}
+
+	var h uint32 = nzaat.Final(nzaat.Update(nzaat.NewSeed(7).Digest(), []byte("abc")))
+	if h == nzaat.Checksum([]byte("abc")) {
+		t.Error("Seed did not change the hash")
+	}
+	tab.entries[h] = append(tab.entries[h], entry[string]{enc: []byte("x")}, entry[string]{enc: []byte("y")})
+	if tab.Intern("abc") != a || longest != 3 {
+		t.Errorf("Expected an alarm for a chain of 3, got %d", longest)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+)
+
+// Hasher hashes keys of any comparable type, for use as the hash
+// function of generic containers. Keys which compare equal with == hash
+// to the same value.
+//
+// Keys are hashed through a canonical encoding rather than their memory
+// representation: integers are encoded as 8 little-endian octets,
+// floating point values by their IEEE 754 bits with -0 folded into +0,
+// strings with a length prefix, arrays and structs field by field, and
+// pointers, channels and unsafe pointers by their address.
+//
+// The zero Hasher is unseeded.
+type Hasher[K comparable] struct {
+	seed   Seed
+	seeded bool
+}
+
+// NewHasher returns a Hasher which uses seed.
+func NewHasher[K comparable](seed Seed) Hasher[K] {
+	return Hasher[K]{seed: seed, seeded: true}
+}
+
+// Hash returns the hash of key.
+func (hs Hasher[K]) Hash(key K) uint32 {
+	var h Hash
+	if hs.seeded {
+		h.SetSeed(hs.seed)
+	}
+
+	switch k := any(key).(type) {
+	case string:
+		writeString(&h.state, k)
+	case int:
+		writeUint64(&h.state, uint64(k))
+	case int32:
+		writeUint64(&h.state, uint64(k))
+	case int64:
+		writeUint64(&h.state, uint64(k))
+	case uint:
+		writeUint64(&h.state, uint64(k))
+	case uint32:
+		writeUint64(&h.state, uint64(k))
+	case uint64:
+		writeUint64(&h.state, k)
+	default:
+		writeComparable(&h.state, reflect.ValueOf(&key).Elem())
+	}
+
+	return h.Sum32()
+}
+
+func writeUint64(d *Digest, v uint64) {
+	d.WriteUint64(v, binary.LittleEndian)
+}
+
+func writeFloat64(d *Digest, f float64) {
+	if f == 0 {
+		f = 0
+	}
+	writeUint64(d, math.Float64bits(f))
+}
+
+func writeString(d *Digest, s string) {
+	d.WriteLengthPrefixedString(s)
+}
+
+// writeComparable adds the canonical encoding of v, which must be of a
+// comparable type, to d.
+func writeComparable(d *Digest, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			d.Write([]byte{1})
+		} else {
+			d.Write([]byte{0})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(d, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(d, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat64(d, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat64(d, real(v.Complex()))
+		writeFloat64(d, imag(v.Complex()))
+	case reflect.String:
+		writeString(d, v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(d, uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeComparable(d, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeComparable(d, v.Field(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			d.Write([]byte{0})
+			return
+		}
+		d.Write([]byte{1})
+		writeString(d, v.Elem().Type().String())
+		writeComparable(d, v.Elem())
+	default:
+		panic("nzaat: cannot hash value of type " + v.Type().String())
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"math"
+	"testing"
+)
+
+type hasherKey struct {
+	name string
+	id   int16
+	f    float64
+	p    *int
+	any  any
+}
+
+// Test that equal keys hash equally and different ones do not.
+func TestHasherStruct(t *testing.T) {
+	var hs Hasher[hasherKey]
+	var x int
+	var a = hasherKey{name: "a", id: 1, f: 0, p: &x, any: "v"}
+	var b = hasherKey{name: "a", id: 1, f: math.Copysign(0, -1), p: &x, any: "v"}
+	var c = hasherKey{name: "a", id: 1, f: 0, p: &x, any: 1}
+
+	if a != b || hs.Hash(a) != hs.Hash(b) {
+		t.Error("Equal keys hash differently")
+	}
+	if hs.Hash(a) == hs.Hash(c) {
+		t.Error("Keys with different interface values hash equally")
+	}
+}
+
+// Test that strings are length-delimited inside composite keys.
+func TestHasherDelimited(t *testing.T) {
+	var hs Hasher[[2]string]
+
+	if hs.Hash([2]string{"ab", "c"}) == hs.Hash([2]string{"a", "bc"}) {
+		t.Error("Composite keys are ambiguous")
+	}
+}
+
+// Test the fast path for integers against the generic encoding.
+func TestHasherInt(t *testing.T) {
+	type myInt int
+	var hi Hasher[int]
+	var hm Hasher[myInt]
+
+	if hi.Hash(-5) != hm.Hash(-5) {
+		t.Error("Fast path and reflection disagree")
+	}
+	if hi.Hash(1) != Checksum([]byte{1, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Error("Integer is not encoded as 8 little-endian octets")
+	}
+}
+
+// Test that seeded Hashers differ from unseeded ones.
+func TestHasherSeed(t *testing.T) {
+	var plain Hasher[string]
+	var seeded Hasher[string] = NewHasher[string](NewSeed(1))
+
+	if plain.Hash("abc") == seeded.Hash("abc") {
+		t.Error("Seed did not change the hash")
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package httpdigest
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// ContentDigest is the header, or trailer, carrying the checksum of a
+// message body, in the dictionary format of RFC 9530:
+//
+//	Content-Digest: nzaat=:w+OeLQ==:
+const ContentDigest = "Content-Digest"
+
+// Algorithm is the key of the checksum in the Content-Digest field.
+const Algorithm = "nzaat"
+
+var (
+	// ErrMismatch is returned when reading a body whose checksum
+	// doesn't match the one announced by the sender.
+	ErrMismatch = errors.New("httpdigest: body checksum mismatch")
+
+	// ErrBadDigest is returned, wrapped, for malformed fields.
+	ErrBadDigest = errors.New("httpdigest: malformed digest")
+)
+
+// FormatDigest returns the Content-Digest field value for sum.
+func FormatDigest(sum uint32) string {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], sum)
+	return Algorithm + "=:" + base64.StdEncoding.EncodeToString(b[:]) + ":"
+}
+
+// ParseDigest finds the NZAAT checksum in the Content-Digest field
+// value. It reports false if the field has no checksum of this
+// algorithm, as when the sender only used others.
+func ParseDigest(value string) (uint32, bool, error) {
+	for _, member := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(member), "=")
+		if key != Algorithm {
+			continue
+		}
+
+		if len(val) < 2 || val[0] != ':' || val[len(val)-1] != ':' {
+			return 0, false, fmt.Errorf("%w: %q", ErrBadDigest, value)
+		}
+		b, err := base64.StdEncoding.DecodeString(val[1 : len(val)-1])
+		if err != nil || len(b) != 4 {
+			return 0, false, fmt.Errorf("%w: %q", ErrBadDigest, value)
+		}
+		return binary.BigEndian.Uint32(b), true, nil
+	}
+	return 0, false, nil
+}
+
+// SetDigest sets the Content-Digest of body in h, for bodies which are
+// available in full before the header is sent.
+func SetDigest(h http.Header, body []byte) {
+	h.Set(ContentDigest, FormatDigest(nzaat.Checksum(body)))
+}
+
+// VerifyingBody wraps a message body and checks it against the
+// Content-Digest of the message when it has been read in full. The
+// field is looked up in the header first, then in the trailer, which is
+// only complete once the body has been read.
+type VerifyingBody struct {
+	rc       io.ReadCloser
+	header   http.Header
+	trailer  http.Header
+	digest   nzaat.Digest
+	done     bool
+	verified bool
+	err      error
+}
+
+// NewVerifyingBody returns a VerifyingBody reading from rc, for the
+// message with the given header and trailer. Either may be nil.
+func NewVerifyingBody(rc io.ReadCloser, header, trailer http.Header) *VerifyingBody {
+	return &VerifyingBody{rc: rc, header: header, trailer: trailer}
+}
+
+// Read reads from the body. At the end of the body, it returns
+// ErrMismatch instead of io.EOF if the checksum doesn't match.
+func (b *VerifyingBody) Read(p []byte) (int, error) {
+	if b.done {
+		return 0, b.err
+	}
+
+	n, err := b.rc.Read(p)
+	b.digest.Write(p[:n])
+	if err == io.EOF {
+		b.done = true
+		b.err = b.check()
+		err = b.err
+	}
+	return n, err
+}
+
+func (b *VerifyingBody) check() error {
+	var value string = b.header.Get(ContentDigest)
+	if value == "" {
+		value = b.trailer.Get(ContentDigest)
+	}
+
+	sum, ok, err := ParseDigest(value)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return io.EOF
+	}
+	if sum != b.digest.Sum32() {
+		return ErrMismatch
+	}
+
+	b.verified = true
+	return io.EOF
+}
+
+// Verified reports whether the body has been read in full and matched
+// its checksum. It is false for messages without a checksum.
+func (b *VerifyingBody) Verified() bool {
+	return b.verified
+}
+
+// Close closes the underlying body.
+func (b *VerifyingBody) Close() error {
+	return b.rc.Close()
+}
+
+// VerifyRequest replaces the body of the incoming request r with a
+// VerifyingBody, so that handlers reading it get ErrMismatch instead of
+// io.EOF if it was damaged.
+func VerifyRequest(r *http.Request) *VerifyingBody {
+	var b *VerifyingBody = NewVerifyingBody(r.Body, r.Header, r.Trailer)
+	r.Body = b
+	return b
+}
+
+// trailerBody computes the checksum of a request body as it is sent,
+// and stores it in the trailer at the end.
+type trailerBody struct {
+	rc      io.ReadCloser
+	trailer http.Header
+	digest  nzaat.Digest
+}
+
+func (b *trailerBody) Read(p []byte) (int, error) {
+	n, err := b.rc.Read(p)
+	b.digest.Write(p[:n])
+	if err == io.EOF {
+		b.trailer.Set(ContentDigest, FormatDigest(b.digest.Sum32()))
+	}
+	return n, err
+}
+
+func (b *trailerBody) Close() error {
+	return b.rc.Close()
+}
+
+// SetRequestTrailer arranges for the outgoing request req to carry the
+// Content-Digest of its body in a trailer, so that streamed bodies can
+// be checked without reading them twice. The body is sent chunked.
+func SetRequestTrailer(req *http.Request) {
+	if req.Body == nil || req.Body == http.NoBody {
+		SetDigest(req.Header, nil)
+		return
+	}
+	if req.Trailer == nil {
+		req.Trailer = make(http.Header)
+	}
+	req.Trailer[ContentDigest] = nil
+	req.Body = &trailerBody{rc: req.Body, trailer: req.Trailer}
+	req.ContentLength = -1
+	req.GetBody = nil
+}
+
+type trailerWriter struct {
+	http.ResponseWriter
+	digest nzaat.Digest
+}
+
+func (tw *trailerWriter) Write(p []byte) (int, error) {
+	n, err := tw.ResponseWriter.Write(p)
+	tw.digest.Write(p[:n])
+	return n, err
+}
+
+// DigestTrailer returns a handler which sends the Content-Digest of the
+// responses of next in a trailer, without buffering them.
+func DigestTrailer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tw *trailerWriter = &trailerWriter{ResponseWriter: w}
+
+		w.Header().Add("Trailer", ContentDigest)
+		next.ServeHTTP(tw, r)
+		w.Header().Set(ContentDigest, FormatDigest(tw.digest.Sum32()))
+	})
+}
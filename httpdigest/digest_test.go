@@ -0,0 +1,87 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package httpdigest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test formatting and parsing field values.
+func TestFormatParseDigest(t *testing.T) {
+	if v := FormatDigest(0xc3e39e2d); v != "nzaat=:w+OeLQ==:" {
+		t.Errorf("Unexpected field value %q", v)
+	}
+
+	sum, ok, err := ParseDigest("sha-256=:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=:, nzaat=:w+OeLQ==:")
+	if err != nil || !ok || sum != 0xc3e39e2d {
+		t.Errorf("Parsed %08x, %v, %v", sum, ok, err)
+	}
+	if _, ok, err = ParseDigest("sha-256=:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=:"); ok || err != nil {
+		t.Errorf("Found a checksum in a field without one: %v, %v", ok, err)
+	}
+	for _, bad := range []string{"nzaat=w+OeLQ==", "nzaat=:w+OeLQ:", "nzaat=:AAAAAAAA:"} {
+		if _, _, err = ParseDigest(bad); !errors.Is(err, ErrBadDigest) {
+			t.Errorf("%q: got %v", bad, err)
+		}
+	}
+}
+
+// Test verifying bodies against header and trailer checksums.
+func TestVerifyingBody(t *testing.T) {
+	var good http.Header = http.Header{ContentDigest: {FormatDigest(0xc3e39e2d)}}
+	var bad http.Header = http.Header{ContentDigest: {FormatDigest(0)}}
+
+	for _, c := range []struct {
+		header, trailer http.Header
+		err             error
+		verified        bool
+	}{
+		{good, nil, nil, true},
+		{nil, good, nil, true},
+		{bad, nil, ErrMismatch, false},
+		{nil, bad, ErrMismatch, false},
+		{nil, nil, nil, false},
+	} {
+		var b *VerifyingBody = NewVerifyingBody(io.NopCloser(strings.NewReader("abc")), c.header, c.trailer)
+		data, err := io.ReadAll(b)
+		if err != c.err || string(data) != "abc" || b.Verified() != c.verified {
+			t.Errorf("Header %v, trailer %v: got %q, %v, verified %v", c.header, c.trailer, data, err, b.Verified())
+		}
+	}
+}
+
+// Test sending a request with a trailer checksum to a verifying server,
+// and a response with a trailer checksum back.
+func TestTrailers(t *testing.T) {
+	var srv *httptest.Server = httptest.NewServer(DigestTrailer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b *VerifyingBody = VerifyRequest(r)
+		data, err := io.ReadAll(r.Body)
+		if err != nil || !b.Verified() {
+			t.Errorf("Server read %q, %v, verified %v", data, err, b.Verified())
+		}
+		w.Write(data)
+	})))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("POST", srv.URL, strings.NewReader("abc"))
+	SetRequestTrailer(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b *VerifyingBody = NewVerifyingBody(resp.Body, resp.Header, resp.Trailer)
+	data, err := io.ReadAll(b)
+	b.Close()
+	if err != nil || string(data) != "abc" || !b.Verified() {
+		t.Errorf("Client read %q, %v, verified %v", data, err, b.Verified())
+	}
+}
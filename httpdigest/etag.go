@@ -0,0 +1,190 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package httpdigest uses NZAAT checksums to add integrity checking and
+// cheap revalidation to HTTP servers and clients.
+package httpdigest
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// ETagOptions configure the ETag middleware.
+type ETagOptions struct {
+	// Weak makes the middleware send weak ETags, for handlers whose
+	// output is only semantically, not byte for byte, stable.
+	Weak bool
+
+	// MaxBuffer is the size up to which responses are buffered.
+	// Larger responses are streamed, with the ETag sent as a trailer,
+	// and can't be answered with 304. Defaults to 1 MiB.
+	MaxBuffer int
+}
+
+// ETag returns a handler which computes the NZAAT checksum of the
+// successful responses of next to GET requests, sends it as ETag, and
+// answers requests with a matching If-None-Match with 304 Not Modified.
+// ETags set by next itself are kept.
+func ETag(next http.Handler, opts ETagOptions) http.Handler {
+	if opts.MaxBuffer <= 0 {
+		opts.MaxBuffer = 1 << 20
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var ew *etagWriter = &etagWriter{w: w, opts: opts}
+		next.ServeHTTP(ew, r)
+		ew.finish(r)
+	})
+}
+
+func formatETag(sum uint32, weak bool) string {
+	var tag string = `"` + nzaat.FormatSum(sum) + `"`
+	if weak {
+		return "W/" + tag
+	}
+	return tag
+}
+
+// matchETag reports whether the If-None-Match header value matches
+// etag, using the weak comparison of RFC 9110.
+func matchETag(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+type etagWriter struct {
+	w      http.ResponseWriter
+	opts   ETagOptions
+	status int
+	buf    bytes.Buffer
+	digest nzaat.Digest
+
+	// passthrough is set for responses which are not hashed, and
+	// streaming once the buffer limit has been exceeded.
+	passthrough bool
+	streaming   bool
+}
+
+func (ew *etagWriter) Header() http.Header {
+	return ew.w.Header()
+}
+
+func (ew *etagWriter) WriteHeader(status int) {
+	if ew.status != 0 {
+		return
+	}
+	ew.status = status
+	if status != http.StatusOK {
+		ew.passthrough = true
+		ew.w.WriteHeader(status)
+	}
+}
+
+func (ew *etagWriter) Write(p []byte) (int, error) {
+	if ew.status == 0 {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if ew.passthrough {
+		return ew.w.Write(p)
+	}
+
+	ew.digest.Write(p)
+	if ew.streaming {
+		return ew.w.Write(p)
+	}
+
+	ew.buf.Write(p)
+	if ew.buf.Len() > ew.opts.MaxBuffer {
+		if err := ew.stream(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// stream sends the headers and the buffered part of the response, and
+// passes everything written later straight on, with the ETag sent as a
+// trailer.
+func (ew *etagWriter) stream() error {
+	ew.streaming = true
+	if ew.Header().Get("ETag") == "" {
+		ew.Header().Add("Trailer", "ETag")
+	}
+	ew.Header().Del("Content-Length")
+	ew.w.WriteHeader(ew.status)
+	if _, err := ew.w.Write(ew.buf.Bytes()); err != nil {
+		return err
+	}
+	ew.buf = bytes.Buffer{}
+	return nil
+}
+
+// FlushError sends what has been written so far to the client, which
+// means streaming the response like one exceeding MaxBuffer.
+func (ew *etagWriter) FlushError() error {
+	if ew.status == 0 {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if !ew.passthrough && !ew.streaming {
+		if err := ew.stream(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(ew.w).Flush()
+}
+
+func (ew *etagWriter) Flush() {
+	ew.FlushError()
+}
+
+// Unwrap returns the underlying ResponseWriter, so that
+// http.ResponseController can reach its other features.
+func (ew *etagWriter) Unwrap() http.ResponseWriter {
+	return ew.w
+}
+
+func (ew *etagWriter) finish(r *http.Request) {
+	var h http.Header = ew.Header()
+
+	if ew.passthrough {
+		return
+	}
+	if ew.streaming {
+		if h.Get("ETag") == "" {
+			h.Set("ETag", formatETag(ew.digest.Sum32(), ew.opts.Weak))
+		}
+		return
+	}
+
+	if h.Get("ETag") == "" {
+		h.Set("ETag", formatETag(ew.digest.Sum32(), ew.opts.Weak))
+	}
+	if inm := r.Header.Get("If-None-Match"); inm != "" && matchETag(inm, h.Get("ETag")) {
+		h.Del("Content-Type")
+		h.Del("Content-Length")
+		ew.w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.Set("Content-Length", strconv.Itoa(ew.buf.Len()))
+	ew.w.WriteHeader(http.StatusOK)
+	ew.w.Write(ew.buf.Bytes())
+}
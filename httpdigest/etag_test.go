@@ -0,0 +1,131 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package httpdigest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+var abcHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, "abc")
+})
+
+// Test that responses get an ETag and matching requests a 304.
+func TestETag(t *testing.T) {
+	var h http.Handler = ETag(abcHandler, ETagOptions{})
+
+	var rec *httptest.ResponseRecorder = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != 200 || rec.Body.String() != "abc" || rec.Header().Get("ETag") != `"c3e39e2d"` {
+		t.Errorf("Unexpected response %d %q with ETag %q", rec.Code, rec.Body.String(), rec.Header().Get("ETag"))
+	}
+	if rec.Header().Get("Content-Length") != "3" {
+		t.Errorf("Unexpected Content-Length %q", rec.Header().Get("Content-Length"))
+	}
+
+	for inm, want := range map[string]int{
+		`"c3e39e2d"`:             304,
+		`W/"c3e39e2d"`:           304,
+		`"00000000", "c3e39e2d"`: 304,
+		`*`:                      304,
+		`"00000000"`:             200,
+	} {
+		var req *http.Request = httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("If-None-Match", inm)
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("If-None-Match %s: got status %d, expected %d", inm, rec.Code, want)
+		}
+		if want == 304 && rec.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: 304 with body %q", inm, rec.Body.String())
+		}
+	}
+}
+
+// Test weak ETags, and that other methods and statuses pass through.
+func TestETagPassthrough(t *testing.T) {
+	var rec *httptest.ResponseRecorder = httptest.NewRecorder()
+	ETag(abcHandler, ETagOptions{Weak: true}).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Header().Get("ETag") != `W/"c3e39e2d"` {
+		t.Errorf("Unexpected weak ETag %q", rec.Header().Get("ETag"))
+	}
+
+	rec = httptest.NewRecorder()
+	ETag(abcHandler, ETagOptions{}).ServeHTTP(rec, httptest.NewRequest("POST", "/", nil))
+	if rec.Header().Get("ETag") != "" || rec.Body.String() != "abc" {
+		t.Error("POST response was modified")
+	}
+
+	rec = httptest.NewRecorder()
+	ETag(http.NotFoundHandler(), ETagOptions{}).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != 404 || rec.Header().Get("ETag") != "" {
+		t.Errorf("404 response got status %d, ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+// Test that large responses are streamed with the ETag as a trailer.
+func TestETagTrailer(t *testing.T) {
+	var body string = strings.Repeat("abc", 100)
+	var srv *httptest.Server = httptest.NewServer(ETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 100; i++ {
+			io.WriteString(w, "abc")
+		}
+	}), ETagOptions{MaxBuffer: 10}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(data) != body {
+		t.Errorf("Unexpected body of %d bytes", len(data))
+	}
+	if want := formatETag(nzaat.Checksum([]byte(body)), false); resp.Trailer.Get("ETag") != want {
+		t.Errorf("Trailer ETag %q, expected %q", resp.Trailer.Get("ETag"), want)
+	}
+}
+
+// Test that handlers can flush through the middleware and reach the
+// underlying ResponseWriter.
+func TestETagFlush(t *testing.T) {
+	var srv *httptest.Server = httptest.NewServer(ETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rc *http.ResponseController = http.NewResponseController(w)
+		io.WriteString(w, "abc")
+		if err := rc.Flush(); err != nil {
+			t.Errorf("Flush: %v", err)
+		}
+		if err := rc.SetWriteDeadline(time.Now().Add(time.Minute)); err != nil {
+			t.Errorf("SetWriteDeadline: %v", err)
+		}
+		io.WriteString(w, "def")
+	}), ETagOptions{}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(data) != "abcdef" {
+		t.Errorf("Unexpected body %q", data)
+	}
+	if want := formatETag(nzaat.Checksum([]byte("abcdef")), false); resp.Trailer.Get("ETag") != want {
+		t.Errorf("Trailer ETag %q, expected %q", resp.Trailer.Get("ETag"), want)
+	}
+}
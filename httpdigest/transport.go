@@ -0,0 +1,93 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package httpdigest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrNoDigest is returned when reading a response body without a
+	// checksum from a Transport with RequireDigest set.
+	ErrNoDigest = errors.New("httpdigest: response has no checksum")
+
+	// ErrUncompressed is returned instead of ErrNoDigest for bodies
+	// which the underlying transport has transparently decompressed,
+	// since their checksum covers the compressed content, which is no
+	// longer available. Setting Accept-Encoding on the request turns
+	// the transparent decompression off.
+	ErrUncompressed = errors.New("httpdigest: decompressed response cannot be verified")
+)
+
+// Transport is an http.RoundTripper which verifies the bodies of the
+// responses carrying a Content-Digest while they are streamed. Reading
+// a damaged body fails with ErrMismatch at its end instead of returning
+// io.EOF, so the download is not mistaken for a complete one.
+type Transport struct {
+	// Base is the RoundTripper making the requests. Defaults to
+	// http.DefaultTransport.
+	Base http.RoundTripper
+
+	// RequireDigest makes responses without a checksum fail with
+	// ErrNoDigest at the end of their body, and those which were
+	// transparently decompressed with ErrUncompressed.
+	RequireDigest bool
+}
+
+// RoundTrip implements http.RoundTripper. It asks the server for an
+// NZAAT Content-Digest using Want-Content-Digest, unless the request
+// already says what it wants.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var base http.RoundTripper = t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.Header.Get("Want-Content-Digest") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Want-Content-Digest", Algorithm+"=10")
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.Body == nil || resp.Body == http.NoBody || req.Method == http.MethodHead {
+		return resp, err
+	}
+
+	// The checksum covers the content as sent, which is gone once the
+	// transport has transparently decompressed it.
+	var header, trailer http.Header = resp.Header, resp.Trailer
+	if resp.Uncompressed {
+		header, trailer = nil, nil
+	}
+
+	var body *VerifyingBody = NewVerifyingBody(resp.Body, header, trailer)
+	if t.RequireDigest {
+		resp.Body = &requiringBody{VerifyingBody: body, uncompressed: resp.Uncompressed}
+	} else {
+		resp.Body = body
+	}
+	return resp, nil
+}
+
+// requiringBody fails bodies which ended without being verified.
+type requiringBody struct {
+	*VerifyingBody
+	uncompressed bool
+}
+
+func (b *requiringBody) Read(p []byte) (int, error) {
+	n, err := b.VerifyingBody.Read(p)
+	if err == io.EOF && !b.Verified() {
+		if b.uncompressed {
+			err = ErrUncompressed
+		} else {
+			err = ErrNoDigest
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package httpdigest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test downloading good, damaged and unchecked responses.
+func TestTransport(t *testing.T) {
+	var srv *httptest.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Want-Content-Digest") != "nzaat=10" {
+			t.Errorf("Unexpected Want-Content-Digest %q", r.Header.Get("Want-Content-Digest"))
+		}
+		switch r.URL.Path {
+		case "/gzip":
+			var buf bytes.Buffer
+			var zw *gzip.Writer = gzip.NewWriter(&buf)
+			io.WriteString(zw, "abc")
+			zw.Close()
+			SetDigest(w.Header(), buf.Bytes())
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(buf.Bytes())
+			return
+		case "/good":
+			SetDigest(w.Header(), []byte("abc"))
+		case "/bad":
+			SetDigest(w.Header(), []byte("abd"))
+		}
+		io.WriteString(w, "abc")
+	}))
+	defer srv.Close()
+
+	for _, c := range []struct {
+		path    string
+		require bool
+		err     error
+	}{
+		{"/good", true, nil},
+		{"/bad", false, ErrMismatch},
+		{"/none", false, nil},
+		{"/none", true, ErrNoDigest},
+		{"/gzip", false, nil},
+		{"/gzip", true, ErrUncompressed},
+	} {
+		var client *http.Client = &http.Client{Transport: &Transport{RequireDigest: c.require}}
+		resp, err := client.Get(srv.URL + c.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != c.err {
+			t.Errorf("%s, require %v: got %v, expected %v", c.path, c.require, err, c.err)
+		}
+	}
+}
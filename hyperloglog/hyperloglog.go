@@ -0,0 +1,203 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package hyperloglog estimates the number of distinct keys in a stream
+// with HyperLogLog sketches over 64 bits of NZAAT-derived hash: the
+// NZAAT checksum of a key followed by a seeded NZAAT checksum of it.
+//
+// Sketches with the same precision and seed can be merged, so per-shard
+// sketches combine into the sketch of the union of the shards, and they
+// are stored in a compact encoding of 6 bits per register.
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+const (
+	// MinPrecision and MaxPrecision bound the precision of a sketch.
+	MinPrecision = 4
+	MaxPrecision = 18
+
+	// Version is the version of the encoding written by MarshalBinary.
+	Version = 1
+
+	magic      = "NZHL"
+	headerSize = 10
+)
+
+var (
+	// ErrIncompatible is returned when merging sketches which differ in
+	// precision or seed.
+	ErrIncompatible = errors.New("hyperloglog: incompatible sketches")
+
+	// ErrFormat is returned, wrapped, when decoding malformed data.
+	ErrFormat = errors.New("hyperloglog: malformed sketch")
+
+	// ErrVersion is returned, wrapped, when decoding a sketch of an
+	// unknown version.
+	ErrVersion = errors.New("hyperloglog: unsupported version")
+
+	// ErrChecksum is returned when the checksum of an encoded sketch
+	// does not match.
+	ErrChecksum = errors.New("hyperloglog: checksum mismatch")
+)
+
+// Sketch is a HyperLogLog sketch. It is not safe for concurrent use.
+type Sketch struct {
+	p    uint8
+	seed uint32
+	regs []uint8
+}
+
+// New returns an empty sketch with 2^precision registers, which has a
+// standard error of about 1.04/√(2^precision): 0.8% at precision 14.
+// It panics unless precision is between MinPrecision and MaxPrecision.
+func New(precision int, seed uint32) *Sketch {
+	if precision < MinPrecision || precision > MaxPrecision {
+		panic(fmt.Sprintf("hyperloglog: precision %d out of range", precision))
+	}
+	return &Sketch{p: uint8(precision), seed: seed, regs: make([]uint8, 1<<precision)}
+}
+
+// Precision returns the precision of the sketch.
+func (s *Sketch) Precision() int {
+	return int(s.p)
+}
+
+// Seed returns the seed of the sketch.
+func (s *Sketch) Seed() uint32 {
+	return s.seed
+}
+
+// hash returns the 64-bit hash of key.
+func (s *Sketch) hash(key []byte) uint64 {
+	var h nzaat.Hash
+	h.SetSeed(nzaat.NewSeed(s.seed))
+	h.Write(key)
+	return uint64(nzaat.Checksum(key))<<32 | uint64(h.Sum32())
+}
+
+// Add adds key to the sketch.
+func (s *Sketch) Add(key []byte) {
+	var h uint64 = s.hash(key)
+	var idx uint64 = h >> (64 - s.p)
+	var rank uint8 = uint8(bits.LeadingZeros64(h<<s.p|1<<(s.p-1))) + 1
+	if rank > s.regs[idx] {
+		s.regs[idx] = rank
+	}
+}
+
+// AddString adds the bytes of key to the sketch.
+func (s *Sketch) AddString(key string) {
+	s.Add([]byte(key))
+}
+
+// Count returns the estimated number of distinct keys added.
+func (s *Sketch) Count() uint64 {
+	var m float64 = float64(len(s.regs))
+	var sum float64
+	var zeros int
+
+	for _, r := range s.regs {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(s.regs) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+
+	var e float64 = alpha * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		e = m * math.Log(m/float64(zeros))
+	}
+	return uint64(e + 0.5)
+}
+
+// Merge adds all keys of t to s, so that s becomes the sketch of the
+// union of both streams.
+func (s *Sketch) Merge(t *Sketch) error {
+	if s.p != t.p || s.seed != t.seed {
+		return ErrIncompatible
+	}
+	for i, r := range t.regs {
+		if r > s.regs[i] {
+			s.regs[i] = r
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the sketch: the magic number "NZHL", the version
+// octet, the precision octet and the seed as 4 little-endian octets,
+// followed by the registers in 6 bits each, four registers packed into
+// three octets starting with the most significant bit, and finally the
+// NZAAT checksum of everything before it as 4 big-endian octets.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	var b []byte = make([]byte, headerSize, headerSize+len(s.regs)*3/4+4)
+
+	copy(b, magic)
+	b[4] = Version
+	b[5] = s.p
+	binary.LittleEndian.PutUint32(b[6:], s.seed)
+	for i := 0; i < len(s.regs); i += 4 {
+		var v uint32 = uint32(s.regs[i])<<18 | uint32(s.regs[i+1])<<12 |
+			uint32(s.regs[i+2])<<6 | uint32(s.regs[i+3])
+		b = append(b, byte(v>>16), byte(v>>8), byte(v))
+	}
+	return binary.BigEndian.AppendUint32(b, nzaat.Checksum(b)), nil
+}
+
+// UnmarshalBinary replaces s by the sketch encoded in data, as written
+// by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize+4 || string(data[:4]) != magic {
+		return fmt.Errorf("%w: bad header", ErrFormat)
+	}
+	if data[4] != Version {
+		return fmt.Errorf("%w: %d", ErrVersion, data[4])
+	}
+
+	var p uint8 = data[5]
+	if p < MinPrecision || p > MaxPrecision || len(data) != headerSize+(1<<p)*3/4+4 {
+		return fmt.Errorf("%w: precision %d in %d octets", ErrFormat, p, len(data))
+	}
+	var n int = len(data) - 4
+	if nzaat.Checksum(data[:n]) != binary.BigEndian.Uint32(data[n:]) {
+		return ErrChecksum
+	}
+
+	var regs []uint8 = make([]uint8, 1<<p)
+	for i, j := 0, headerSize; i < len(regs); i, j = i+4, j+3 {
+		var v uint32 = uint32(data[j])<<16 | uint32(data[j+1])<<8 | uint32(data[j+2])
+		regs[i], regs[i+1], regs[i+2], regs[i+3] = uint8(v>>18), uint8(v>>12&63), uint8(v>>6&63), uint8(v&63)
+	}
+	for _, r := range regs {
+		if int(r) > 64-int(p)+1 {
+			return fmt.Errorf("%w: register value %d", ErrFormat, r)
+		}
+	}
+
+	*s = Sketch{p: p, seed: binary.LittleEndian.Uint32(data[6:]), regs: regs}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package hyperloglog
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// fill returns a sketch of the keys from to to-1.
+func fill(from, to int) *Sketch {
+	var s *Sketch = New(14, 5)
+	for i := from; i < to; i++ {
+		s.AddString("key" + strconv.Itoa(i))
+	}
+	return s
+}
+
+// near reports whether got is within 3% of want.
+func near(got uint64, want int) bool {
+	return math.Abs(float64(got)-float64(want)) <= 0.03*float64(want)
+}
+
+// Test the estimates across the small and large ranges.
+func TestCount(t *testing.T) {
+	if n := New(10, 0).Count(); n != 0 {
+		t.Errorf("Empty sketch: got %d", n)
+	}
+	for _, n := range []int{10, 1000, 100000, 1000000} {
+		var s *Sketch = fill(0, n)
+		if got := s.Count(); !near(got, n) {
+			t.Errorf("Got %d for %d keys", got, n)
+		}
+	}
+
+	// Duplicates are not counted.
+	var s *Sketch = fill(0, 1000)
+	for i := 0; i < 1000; i++ {
+		s.AddString("key" + strconv.Itoa(i))
+	}
+	if got := s.Count(); !near(got, 1000) {
+		t.Errorf("Got %d for 1000 keys added twice", got)
+	}
+}
+
+// Test that merging sketches of overlapping shards estimates the union.
+func TestMerge(t *testing.T) {
+	var a *Sketch = fill(0, 60000)
+	if err := a.Merge(fill(40000, 100000)); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Count(); !near(got, 100000) {
+		t.Errorf("Got %d for the union of 100000 keys", got)
+	}
+
+	if err := New(14, 5).Merge(New(12, 5)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Different precision: got %v, expected ErrIncompatible", err)
+	}
+	if err := New(14, 5).Merge(New(14, 6)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Different seed: got %v, expected ErrIncompatible", err)
+	}
+}
+
+// Test encoding and decoding sketches.
+func TestMarshal(t *testing.T) {
+	var s *Sketch = fill(0, 5000)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != headerSize+12288+4 {
+		t.Errorf("Got %d octets, expected %d", len(data), headerSize+12288+4)
+	}
+
+	var u Sketch
+	if err := u.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if u.Count() != s.Count() || u.Precision() != 14 || u.Seed() != 5 {
+		t.Errorf("Decoded sketch differs: %d keys, precision %d, seed %d", u.Count(), u.Precision(), u.Seed())
+	}
+	for i := range s.regs {
+		if s.regs[i] != u.regs[i] {
+			t.Fatalf("Register %d: got %d, expected %d", i, u.regs[i], s.regs[i])
+		}
+	}
+
+	data[headerSize] ^= 1
+	if err := u.UnmarshalBinary(data); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Damaged registers: got %v, expected ErrChecksum", err)
+	}
+	if err := u.UnmarshalBinary(data[:100]); !errors.Is(err, ErrFormat) {
+		t.Errorf("Truncated: got %v, expected ErrFormat", err)
+	}
+	data[4] = 9
+	if err := u.UnmarshalBinary(data); !errors.Is(err, ErrVersion) {
+		t.Errorf("Version 9: got %v, expected ErrVersion", err)
+	}
+}
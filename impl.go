@@ -0,0 +1,96 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "os"
+
+// implementation is a choice of functions for the inner loops of
+// NZAAT. The choice is recorded as flags rather than function values,
+// which the compiler has to assume retain their arguments: calling
+// through them would move every buffer passed to Write to the heap.
+type implementation struct {
+	name string
+
+	// archUpdate is set if update uses updateArch rather than
+	// updateGeneric.
+	archUpdate bool
+
+	// archUpdate4 is set if update4 uses update4Arch rather than
+	// update4Generic.
+	archUpdate4 bool
+
+	// supported is set if the CPU can run the implementation.
+	supported bool
+}
+
+var generic = implementation{
+	name:      "generic",
+	supported: true,
+}
+
+// update returns the state s after absorbing p.
+func (i implementation) update(s uint32, p []byte) uint32 {
+	if i.archUpdate {
+		return updateArch(s, p)
+	}
+	return updateGeneric(s, p)
+}
+
+// update4 absorbs len(p0) bytes of each of p0 to p3 into the four
+// independent states s. p1 to p3 must be at least as long as p0.
+func (i implementation) update4(s *[4]uint32, p0, p1, p2, p3 []byte) {
+	if i.archUpdate4 {
+		update4Arch(s, p0, p1, p2, p3)
+		return
+	}
+	update4Generic(s, p0, p1, p2, p3)
+}
+
+// impl is the implementation in use, chosen at startup. Setting the
+// environment variable NZAAT_IMPL to the name of a supported
+// implementation, such as "generic", overrides the choice.
+var impl implementation = selectImplementation(os.Getenv("NZAAT_IMPL"))
+
+// selectImplementation returns the implementation called name, or the
+// best one the CPU supports if there is no such implementation.
+func selectImplementation(name string) implementation {
+	for _, i := range implementations() {
+		if i.name == name {
+			return i
+		}
+	}
+	for _, i := range archImplementations {
+		if i.supported {
+			return i
+		}
+	}
+	return generic
+}
+
+// implementations returns the implementations the CPU supports.
+func implementations() []implementation {
+	var impls []implementation = []implementation{generic}
+	for _, i := range archImplementations {
+		if i.supported {
+			impls = append(impls, i)
+		}
+	}
+	return impls
+}
+
+// Implementation returns the name of the implementation of the inner
+// loops chosen for this CPU, for diagnostics.
+func Implementation() string {
+	return impl.name
+}
+
+func update(s uint32, p []byte) uint32 {
+	return impl.update(s, p)
+}
+
+func update4(s *[4]uint32, p0, p1, p2, p3 []byte) {
+	impl.update4(s, p0, p1, p2, p3)
+}
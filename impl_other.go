@@ -0,0 +1,22 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !(amd64 || arm64) || purego || tinygo
+
+package nzaat
+
+// archImplementations lists the optimized implementations for the
+// architecture, best first. There are none here.
+var archImplementations []implementation
+
+// updateArch is update for the implementations with archUpdate set.
+func updateArch(s uint32, p []byte) uint32 {
+	return updateGeneric(s, p)
+}
+
+// update4Arch is update4 for the implementations with archUpdate4 set.
+func update4Arch(s *[4]uint32, p0, p1, p2, p3 []byte) {
+	update4Generic(s, p0, p1, p2, p3)
+}
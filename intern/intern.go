@@ -0,0 +1,129 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package intern deduplicates strings, returning one canonical instance
+// for each distinct value.
+//
+// Strings are looked up by their NZAAT checksum. Since a 32-bit hash
+// will collide eventually, candidates with the same checksum are always
+// compared in full before being returned.
+//
+// An attacker who knows the hash can make every string land in the same
+// candidate list, turning each lookup into a linear scan. Interners fed
+// from untrusted input should be created with NewSeeded and a random
+// seed, and SetFloodAlarm reports unusually long candidate lists.
+package intern
+
+import "github.com/caoimhechaos/golang-nzaat"
+
+// Interner holds the canonical instances of the strings seen so far.
+// The zero value is an empty Interner ready to use. An Interner is not
+// safe for concurrent use.
+type Interner struct {
+	strings  map[uint32][]string
+	count    int
+	seed     nzaat.Digest
+	maxChain int
+	alarm    func(chain int)
+}
+
+// New returns a new, empty Interner.
+func New() *Interner {
+	return new(Interner)
+}
+
+// NewSeeded returns a new, empty Interner which hashes strings with the
+// seeded NZAAT hash selected by seed. Use nzaat.MakeSeed for a seed
+// which cannot be guessed by whoever supplies the strings.
+func NewSeeded(seed nzaat.Seed) *Interner {
+	return &Interner{seed: seed.Digest()}
+}
+
+// SetFloodAlarm arranges for alarm to be called with the length of the
+// candidate list whenever a lookup has to compare more than maxChain
+// strings with the same hash. With a seed unknown to an attacker this
+// hardly ever happens, so the alarm is a sign that strings are being
+// chosen to collide. A nil alarm turns the detection off again.
+func (in *Interner) SetFloodAlarm(maxChain int, alarm func(chain int)) {
+	in.maxChain = maxChain
+	in.alarm = alarm
+}
+
+func (in *Interner) hash(b []byte) uint32 {
+	return nzaat.Final(nzaat.Update(in.seed, b))
+}
+
+func (in *Interner) hashString(s string) uint32 {
+	return nzaat.Final(nzaat.UpdateString(in.seed, s))
+}
+
+// candidates returns the strings with the hash h, raising the flood
+// alarm if there are too many of them.
+func (in *Interner) candidates(h uint32) []string {
+	var chain []string = in.strings[h]
+
+	if in.alarm != nil && len(chain) > in.maxChain {
+		in.alarm(len(chain))
+	}
+	return chain
+}
+
+func (in *Interner) lookup(h uint32, b []byte) (string, bool) {
+	for _, s := range in.candidates(h) {
+		if s == string(b) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func (in *Interner) lookupString(h uint32, s string) (string, bool) {
+	for _, c := range in.candidates(h) {
+		if c == s {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+func (in *Interner) insert(h uint32, s string) {
+	if in.strings == nil {
+		in.strings = make(map[uint32][]string)
+	}
+	in.strings[h] = append(in.strings[h], s)
+	in.count++
+}
+
+// Intern returns the canonical instance of s, making s the canonical
+// instance if its value has not been seen before.
+func (in *Interner) Intern(s string) string {
+	var h uint32 = in.hashString(s)
+
+	if c, ok := in.lookupString(h, s); ok {
+		return c
+	}
+	in.insert(h, s)
+	return s
+}
+
+// InternBytes returns the canonical instance of the string value of b.
+// A new string is only allocated if the value has not been seen before,
+// so parsers can intern tokens straight out of their input buffers.
+func (in *Interner) InternBytes(b []byte) string {
+	var h uint32 = in.hash(b)
+
+	if c, ok := in.lookup(h, b); ok {
+		return c
+	}
+
+	var s string = string(b)
+	in.insert(h, s)
+	return s
+}
+
+// Len returns the number of distinct strings interned.
+func (in *Interner) Len() int {
+	return in.count
+}
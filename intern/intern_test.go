@@ -0,0 +1,103 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package intern
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test that equal strings are mapped to the same instance.
+func TestIntern(t *testing.T) {
+	var in *Interner = New()
+	var a string = in.Intern(string([]byte("message digest")))
+	var b string = in.InternBytes([]byte("message digest"))
+
+	if unsafe.StringData(a) != unsafe.StringData(b) {
+		t.Error("Interned strings do not share their data")
+	}
+	if in.Intern("abc") != "abc" {
+		t.Error("Interning changed the value")
+	}
+	if in.Len() != 2 {
+		t.Errorf("Expected 2 strings, got %d", in.Len())
+	}
+}
+
+// Test that strings with the same checksum are kept apart.
+func TestCollision(t *testing.T) {
+	var in Interner
+
+	in.insert(1, "a")
+	in.insert(1, "b")
+
+	if s, ok := in.lookup(1, []byte("b")); !ok || s != "b" {
+		t.Errorf("Lookup of colliding string returned %q", s)
+	}
+	if _, ok := in.lookup(1, []byte("c")); ok {
+		t.Error("Lookup of unknown string succeeded")
+	}
+}
+
+// Test that looking up a known value does not allocate.
+func TestInternBytesNoAlloc(t *testing.T) {
+	var in *Interner = New()
+	var b []byte = []byte("abc")
+
+	in.InternBytes(b)
+	if n := testing.AllocsPerRun(100, func() { in.InternBytes(b) }); n != 0 {
+		t.Errorf("InternBytes allocated %v times", n)
+	}
+}
+
+// Test that interning a known string does not allocate.
+func TestInternNoAlloc(t *testing.T) {
+	var in *Interner = New()
+	var s string = strings.Repeat("abc", 100)
+
+	in.Intern(s)
+	if n := testing.AllocsPerRun(100, func() { in.Intern(s) }); n != 0 {
+		t.Errorf("Intern allocated %v times", n)
+	}
+}
+
+// Test that a seeded Interner works and hashes differently.
+func TestSeeded(t *testing.T) {
+	var in *Interner = NewSeeded(nzaat.NewSeed(7))
+	var a string = in.Intern(string([]byte("abc")))
+
+	if unsafe.StringData(in.InternBytes([]byte("abc"))) != unsafe.StringData(a) {
+		t.Error("Seeded interner returned a new instance")
+	}
+	if in.hash([]byte("abc")) == New().hash([]byte("abc")) {
+		t.Error("Seed did not change the hash")
+	}
+}
+
+// Test that the flood alarm fires when a lookup scans a long chain.
+func TestFloodAlarm(t *testing.T) {
+	var in Interner
+	var h uint32 = in.hash([]byte("x"))
+	var longest int
+
+	in.SetFloodAlarm(4, func(chain int) {
+		longest = chain
+	})
+	for _, s := range []string{"a", "b", "c", "d"} {
+		in.insert(h, s)
+	}
+	in.Intern("x")
+	if longest != 0 {
+		t.Errorf("Alarm raised for a chain of %d", longest)
+	}
+	in.Intern("x")
+	if longest != 5 {
+		t.Errorf("Expected an alarm for a chain of 5, got %d", longest)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package cpu detects the CPU features the optimized implementations
+// of NZAAT depend on. The variables are set before any other package
+// initialization code runs and never change afterwards.
+package cpu
+
+// X86 holds the features of amd64 CPUs.
+var X86 struct {
+	// HasSSE41 is set if the SSE4.1 instructions are available.
+	HasSSE41 bool
+}
+
+// ARM64 holds the features of arm64 CPUs.
+var ARM64 struct {
+	// HasASIMD is set if the Advanced SIMD (NEON) instructions are
+	// available.
+	HasASIMD bool
+}
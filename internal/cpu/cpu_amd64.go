@@ -0,0 +1,21 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !purego && !tinygo
+
+package cpu
+
+// cpuid executes the CPUID instruction for the given leaf and subleaf.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func init() {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 1 {
+		return
+	}
+
+	_, _, ecx1, _ := cpuid(1, 0)
+	X86.HasSSE41 = ecx1&(1<<19) != 0
+}
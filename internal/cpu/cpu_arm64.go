@@ -0,0 +1,14 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !purego && !tinygo
+
+package cpu
+
+func init() {
+	// Go requires ARMv8-A with floating point on arm64, and Advanced
+	// SIMD is part of that architecture profile.
+	ARM64.HasASIMD = true
+}
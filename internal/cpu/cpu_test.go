@@ -0,0 +1,23 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package cpu
+
+import (
+	"runtime"
+	"testing"
+)
+
+// Test that only the features of the running architecture are set.
+func TestFeatures(t *testing.T) {
+	t.Logf("X86: %+v, ARM64: %+v", X86, ARM64)
+
+	if runtime.GOARCH != "amd64" && X86.HasSSE41 {
+		t.Error("X86 feature set on ", runtime.GOARCH)
+	}
+	if runtime.GOARCH != "arm64" && ARM64.HasASIMD {
+		t.Error("ARM64 feature set on ", runtime.GOARCH)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build cref && cgo
+
+package cref
+
+/*
+#include "nzat.h"
+
+static uint32_t
+cref_state(const void *p, size_t z)
+{
+	uint32_t h;
+
+	NZATInit(h);
+	NZATUpdateMem(h, p, z);
+	return (h);
+}
+
+static uint32_t
+cref_nzaat(const void *p, size_t z)
+{
+	uint32_t h = cref_state(p, z);
+
+	NZAATFinish(h);
+	return (h);
+}
+
+static uint32_t
+cref_nzat(const void *p, size_t z)
+{
+	uint32_t h = cref_state(p, z);
+
+	NZATFinish(h);
+	return (h);
+}
+*/
+import "C"
+
+import "unsafe"
+
+// NZAAT returns the NZAAT checksum of data computed by the C implementation.
+func NZAAT(data []byte) uint32 {
+	if len(data) == 0 {
+		return uint32(C.cref_nzaat(nil, 0))
+	}
+	return uint32(C.cref_nzaat(unsafe.Pointer(&data[0]), C.size_t(len(data))))
+}
+
+// NZAT returns the NZAT checksum of data computed by the C implementation.
+func NZAT(data []byte) uint32 {
+	if len(data) == 0 {
+		return uint32(C.cref_nzat(nil, 0))
+	}
+	return uint32(C.cref_nzat(unsafe.Pointer(&data[0]), C.size_t(len(data))))
+}
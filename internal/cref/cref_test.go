@@ -0,0 +1,65 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build cref && cgo
+
+package cref
+
+import (
+	"flag"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+var (
+	numInputs = flag.Int("cref.n", 1000000, "number of random inputs to cross-check")
+	seed      = flag.Uint64("cref.seed", 1, "seed for generating the random inputs")
+)
+
+// Test the published vectors against the C implementation.
+func TestVectors(t *testing.T) {
+	for s, want := range map[string]uint32{
+		"":               0,
+		"a":              0xc31517c4,
+		"abc":            0xC3E39E2D,
+		"message digest": 0x434B78B4,
+	} {
+		if res := NZAAT([]byte(s)); res != want {
+			t.Errorf("C NZAAT(%q) = %x, expected %x", s, res, want)
+		}
+	}
+	if res := NZAT(nil); res != 0x48009 {
+		t.Errorf("C NZAT(\"\") = %x, expected 48009", res)
+	}
+}
+
+// Cross-check random inputs of random lengths, biased towards short
+// inputs and runs of NUL octets.
+func TestRandomInputs(t *testing.T) {
+	var rnd *rand.Rand = rand.New(rand.NewPCG(*seed, 0))
+	var buf []byte = make([]byte, 4096)
+
+	for i := 0; i < *numInputs; i++ {
+		var data []byte = buf[:rnd.IntN(1<<rnd.IntN(13))]
+		var zero bool = rnd.IntN(8) == 0
+
+		for j := range data {
+			if zero {
+				data[j] = 0
+			} else {
+				data[j] = byte(rnd.Uint32())
+			}
+		}
+
+		if c, g := NZAAT(data), nzaat.Checksum(data); c != g {
+			t.Fatalf("NZAAT(%x): C %x, Go %x", data, c, g)
+		}
+		if c, g := NZAT(data), nzaat.ChecksumNZAT(data); c != g {
+			t.Fatalf("NZAT(%x): C %x, Go %x", data, c, g)
+		}
+	}
+}
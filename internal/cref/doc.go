@@ -0,0 +1,15 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package cref wraps a second C implementation of NZAAT and NZAT, written
+// alongside the Go one, for cross-checking it. This is not the upstream
+// MirBSD <mirhash.h>, so it does not show bit-compatibility with the
+// original implementation: agreement only shows that the Go and C
+// transcriptions of the algorithm match, and the published test vectors
+// are the only link to upstream. It needs cgo and is only built with
+// the cref build tag:
+//
+//	go test -tags cref ./internal/cref -cref.n 10000000
+package cref
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+ * All rights reserved.
+ * Use of this source code is governed by a BSD-style license that can
+ * be found in the LICENSE file.
+ *
+ * C implementation of the NZAAT and NZAT hashes, with macros named like
+ * those of MirBSD's <mirhash.h>, used to cross-check the Go code. It is
+ * not the upstream header and proves nothing about compatibility with
+ * it; when changing it, follow the published algorithm and test
+ * vectors, not the Go code.
+ */
+
+#ifndef CREF_NZAT_H
+#define CREF_NZAT_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#define NZATInit(h) do {					\
+	(h) = 0;						\
+} while (/* CONSTCOND */ 0)
+
+#define NZATUpdateByte(value,bv) do {				\
+	register uint32_t NZATUpdateByte_v = (value);		\
+								\
+	NZATUpdateByte_v += (uint8_t)(bv);			\
+	NZATUpdateByte_v += 1;					\
+	NZATUpdateByte_v += NZATUpdateByte_v << 10;		\
+	NZATUpdateByte_v ^= NZATUpdateByte_v >> 6;		\
+	(value) = NZATUpdateByte_v;				\
+} while (/* CONSTCOND */ 0)
+
+#define NZATUpdateMem(h,p,z) do {				\
+	register const uint8_t *NZATUpdateMem_p;		\
+	register size_t NZATUpdateMem_z = (z);			\
+								\
+	NZATUpdateMem_p = (const void *)(p);			\
+	while (NZATUpdateMem_z--)				\
+		NZATUpdateByte((h), *NZATUpdateMem_p++);	\
+} while (/* CONSTCOND */ 0)
+
+#define NZAATFinish(h) do {					\
+	register uint32_t NZAATFinish_v = (h);			\
+								\
+	NZAATFinish_v += NZAATFinish_v << 10;			\
+	NZAATFinish_v ^= NZAATFinish_v >> 6;			\
+	NZAATFinish_v += NZAATFinish_v << 3;			\
+	NZAATFinish_v ^= NZAATFinish_v >> 11;			\
+	NZAATFinish_v += NZAATFinish_v << 15;			\
+	(h) = NZAATFinish_v;					\
+} while (/* CONSTCOND */ 0)
+
+#define NZATFinish(h) do {					\
+	register uint32_t NZATFinish_v = (h);			\
+								\
+	NZATFinish_v += NZATFinish_v << 10;			\
+	NZATFinish_v ^= NZATFinish_v >> 6;			\
+	if (!NZATFinish_v)					\
+		++NZATFinish_v;					\
+	NZATFinish_v += NZATFinish_v << 3;			\
+	NZATFinish_v ^= NZATFinish_v >> 11;			\
+	NZATFinish_v += NZATFinish_v << 15;			\
+	(h) = NZATFinish_v;					\
+} while (/* CONSTCOND */ 0)
+
+#endif
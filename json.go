@@ -0,0 +1,121 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidJSON is returned, wrapped, by ChecksumCanonicalJSON for
+// documents which cannot be brought into canonical form.
+var ErrInvalidJSON = errors.New("nzaat: invalid JSON")
+
+// ChecksumJSON returns the NZAAT checksum of the canonical JSON
+// encoding of v, as produced by encoding/json and then canonicalized
+// like ChecksumCanonicalJSON does.
+func ChecksumJSON(v any) (uint32, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	return ChecksumCanonicalJSON(raw)
+}
+
+// ChecksumCanonicalJSON returns the NZAAT checksum of a canonical form
+// of the JSON document raw, so semantically equal documents have the
+// same checksum. In the canonical form, insignificant white space is
+// removed, object keys are sorted and numbers are written in a normal
+// form, so that e.g. 1, 1.0 and 1e0 are the same.
+func ChecksumCanonicalJSON(raw []byte) (uint32, error) {
+	var dec *json.Decoder = json.NewDecoder(bytes.NewReader(raw))
+	var v any
+
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return 0, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return 0, fmt.Errorf("%w: trailing data after JSON document at offset %d",
+			ErrInvalidJSON, dec.InputOffset())
+	}
+
+	var buf []byte
+	var err error
+	if buf, err = appendCanonicalJSON(buf, v); err != nil {
+		return 0, err
+	}
+	return Checksum(buf), nil
+}
+
+func appendCanonicalJSON(buf []byte, v any) ([]byte, error) {
+	var err error
+
+	switch x := v.(type) {
+	case map[string]any:
+		var keys []string = make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf = append(buf, '{')
+		for i, k := range keys {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			if buf, err = appendCanonicalJSON(buf, k); err != nil {
+				return nil, err
+			}
+			buf = append(buf, ':')
+			if buf, err = appendCanonicalJSON(buf, x[k]); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, '}'), nil
+	case []any:
+		buf = append(buf, '[')
+		for i, e := range x {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			if buf, err = appendCanonicalJSON(buf, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, ']'), nil
+	case json.Number:
+		return appendCanonicalNumber(buf, x)
+	default:
+		// Strings, booleans and null have a single encoding already
+		// once decoded.
+		var b []byte
+		if b, err = json.Marshal(x); err != nil {
+			return nil, err
+		}
+		return append(buf, b...), nil
+	}
+}
+
+// appendCanonicalNumber writes n as an exact decimal fraction in lowest
+// terms: an optional sign, the digits of the numerator and, unless the
+// number is an integer, a slash and the digits of the denominator. This
+// keeps numbers which do not fit a float64 distinct.
+func appendCanonicalNumber(buf []byte, n json.Number) ([]byte, error) {
+	var r *big.Rat
+	var ok bool
+
+	if r, ok = new(big.Rat).SetString(strings.ToLower(n.String())); !ok {
+		return nil, fmt.Errorf("%w: number %s", ErrInvalidJSON, n)
+	}
+	return append(buf, r.RatString()...), nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test that semantically equal documents have the same checksum.
+func TestChecksumCanonicalJSON(t *testing.T) {
+	a, err := ChecksumCanonicalJSON([]byte(`{"b": [1, 2.50, -0], "a": {"y": null, "x": true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ChecksumCanonicalJSON([]byte(`{"a":{"x":true,"y":null},"b":[1.0,25e-1,0]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("Equal documents have different checksums %x and %x", a, b)
+	}
+
+	c, err := ChecksumCanonicalJSON([]byte(`{"a":{"x":true,"y":null},"b":[1,2.5,1]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == c {
+		t.Error("Different documents have the same checksum")
+	}
+}
+
+// Test that large numbers are not rounded together.
+func TestChecksumCanonicalJSONPrecision(t *testing.T) {
+	a, _ := ChecksumCanonicalJSON([]byte(`9007199254740993`))
+	b, _ := ChecksumCanonicalJSON([]byte(`9007199254740992`))
+
+	if a == b {
+		t.Error("Numbers beyond float64 precision were rounded")
+	}
+}
+
+// Test hashing Go values and rejecting invalid documents.
+func TestChecksumJSON(t *testing.T) {
+	a, err := ChecksumJSON(map[string]int{"b": 2, "a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := ChecksumCanonicalJSON([]byte(`{"a": 1.0, "b": 2}`))
+	if a != b {
+		t.Error("Go value and document have different checksums")
+	}
+
+	if _, err = ChecksumCanonicalJSON([]byte(`{"a": 1} {}`)); err == nil {
+		t.Error("Trailing data was accepted")
+	}
+	if _, err = ChecksumCanonicalJSON([]byte(`{"a": 1} x`)); err == nil {
+		t.Error("Trailing garbage was accepted")
+	}
+	if _, err = ChecksumCanonicalJSON([]byte(`{"a":`)); err == nil {
+		t.Error("Truncated document was accepted")
+	}
+}
+
+// Test that numbers which cannot be represented exactly are rejected
+// with a usable error.
+func TestChecksumCanonicalJSONHugeExponent(t *testing.T) {
+	_, err := ChecksumCanonicalJSON([]byte(`[1e9999999]`))
+	if !errors.Is(err, ErrInvalidJSON) {
+		t.Fatalf("Expected ErrInvalidJSON, got %v", err)
+	}
+	if msg := err.Error(); msg != "nzaat: invalid JSON: number 1e9999999" {
+		t.Errorf("Unexpected error message %q", msg)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// largeVectors are pseudo-random inputs of several megabytes, recorded
+// by the seed and length to pass to splitMix. The expected sums were
+// computed with an independent byte-at-a-time implementation.
+var largeVectors = []struct {
+	seed  uint64
+	size  int
+	nzaat uint32
+	nzat  uint32
+}{
+	{0x1, 1 << 20, 0x614c4530, 0x614c4530},
+	{0x1332d23, 1<<20 + 3, 0x0b242b46, 0x0b242b46},
+	{0x6e7a6161, 4<<20 - 1, 0x8a9c664b, 0x8a9c664b},
+	{0xdeadbeefcafe, 8 << 20, 0xf6b21919, 0xf6b21919},
+}
+
+// splitMix returns size octets from the SplitMix64 generator started
+// at seed, each output in little-endian order. The generator is simple
+// enough to reproduce in any language that has 64-bit integers.
+func splitMix(seed uint64, size int) []byte {
+	var buf []byte = make([]byte, (size+7)&^7)
+
+	for i := 0; i < len(buf); i += 8 {
+		seed += 0x9e3779b97f4a7c15
+		var z uint64 = seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		binary.LittleEndian.PutUint64(buf[i:], z^(z>>31))
+	}
+	return buf[:size]
+}
+
+// Test the sums of the large vectors in one piece, with every
+// implementation and in writes of an odd size.
+func TestLargeVectors(t *testing.T) {
+	for _, v := range largeVectors {
+		var input []byte = splitMix(v.seed, v.size)
+
+		if res := Checksum(input); res != v.nzaat {
+			t.Errorf("Seed %#x: NZAAT returned %08x, expected %08x", v.seed, res, v.nzaat)
+		}
+		if res := ChecksumNZAT(input); res != v.nzat {
+			t.Errorf("Seed %#x: NZAT returned %08x, expected %08x", v.seed, res, v.nzat)
+		}
+		for _, i := range implementations() {
+			if res := naf(i.update(0, input)); res != v.nzaat {
+				t.Errorf("Seed %#x, %s: returned %08x, expected %08x", v.seed, i.name, res, v.nzaat)
+			}
+		}
+
+		var d Digest
+		for p := input; len(p) > 0; {
+			var n int = min(len(p), 4093)
+			d.Write(p[:n])
+			p = p[n:]
+		}
+		if res := d.Sum32(); res != v.nzaat {
+			t.Errorf("Seed %#x: chunked writes returned %08x, expected %08x", v.seed, res, v.nzaat)
+		}
+	}
+}
+
+// Test the generator against its first published output for seed 1.
+func TestSplitMix(t *testing.T) {
+	if res := binary.LittleEndian.Uint64(splitMix(1, 8)); res != 0x910a2dec89025cc1 {
+		t.Errorf("First output for seed 1 is %016x", res)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// lengthDigest is an NZAAT computation which also counts the octets
+// written and absorbs the count before finalization.
+type lengthDigest struct {
+	d Digest
+	n uint64
+}
+
+// NewWithLength returns a new hash.Hash32 computing the NZAAT checksum
+// of the data followed by its total length in octets, as eight octets
+// in little-endian order. Inputs of different lengths which happen to
+// reach the same internal state, e.g. a record and the same record
+// with trailing fields appended, then still hash differently. Field
+// boundaries within a record of the same total length are not
+// captured; write the fields with WriteLengthPrefixed for that.
+func NewWithLength() hash.Hash32 {
+	return new(lengthDigest)
+}
+
+func (d *lengthDigest) Reset() {
+	d.d = 0
+	d.n = 0
+}
+
+func (d *lengthDigest) Size() int {
+	return 4
+}
+
+func (d *lengthDigest) BlockSize() int {
+	return 1
+}
+
+func (d *lengthDigest) Write(p []byte) (int, error) {
+	d.n += uint64(len(p))
+	return d.d.Write(p)
+}
+
+// WriteString adds the bytes of s to the running hash without
+// converting s to a byte slice first.
+func (d *lengthDigest) WriteString(s string) (int, error) {
+	d.n += uint64(len(s))
+	return d.d.WriteString(s)
+}
+
+func (d *lengthDigest) Sum32() uint32 {
+	return FinalWithLength(d.d, d.n)
+}
+
+func (d *lengthDigest) Sum(in []byte) []byte {
+	var s uint32 = d.Sum32()
+	return append(in, byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
+
+// ChecksumWithLength returns the NZAAT checksum of data followed by its
+// length, like a hash returned by NewWithLength.
+func ChecksumWithLength(data []byte) uint32 {
+	return FinalWithLength(Update(0, data), uint64(len(data)))
+}
+
+// FinalWithLength returns the checksum of NewWithLength for the state
+// d, which must have absorbed exactly n octets.
+func FinalWithLength(d Digest, n uint64) uint32 {
+	d.WriteUint64(n, binary.LittleEndian)
+	return d.Sum32()
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"io"
+	"strconv"
+	"testing"
+)
+
+// Test that the length is absorbed after the data and that streaming
+// agrees with ChecksumWithLength.
+func TestWithLength(t *testing.T) {
+	var h hash.Hash32 = NewWithLength()
+	var want uint32 = Checksum([]byte("abc\x03\x00\x00\x00\x00\x00\x00\x00"))
+
+	h.Write([]byte("a"))
+	io.WriteString(h, "bc")
+	if res := h.Sum32(); res != want {
+		t.Errorf("Streaming returned %08x, expected %08x", res, want)
+	}
+	if res := ChecksumWithLength([]byte("abc")); res != want {
+		t.Errorf("ChecksumWithLength returned %08x, expected %08x", res, want)
+	}
+
+	h.Reset()
+	if res, want := h.Sum32(), ChecksumWithLength(nil); res != want {
+		t.Errorf("Reset hash returned %08x, expected %08x", res, want)
+	}
+
+}
+
+// Test that inputs of different lengths with the same internal state
+// are told apart by the length.
+func TestWithLengthStateCollision(t *testing.T) {
+	var seen map[Digest]string = make(map[Digest]string)
+
+	for i := 0; i < 1<<20; i++ {
+		var p string = strconv.Itoa(i * 7)
+		var d Digest = UpdateString(0, p)
+		q, ok := seen[d]
+		if !ok {
+			seen[d] = p
+			continue
+		}
+		if len(q) == len(p) {
+			continue
+		}
+
+		if ChecksumString(p) != ChecksumString(q) {
+			t.Fatalf("%q and %q reach the same state but differ", p, q)
+		}
+		if ChecksumWithLength([]byte(p)) == ChecksumWithLength([]byte(q)) {
+			t.Errorf("%q and %q collide despite their lengths", p, q)
+		}
+		return
+	}
+	t.Skip("No collision between inputs of different lengths found")
+}
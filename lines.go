@@ -0,0 +1,98 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"bufio"
+	"io"
+)
+
+// LineScanner reads input line by line and computes the NZAAT checksum
+// of every line, for comparing large text exports line by line where a
+// checksum of the whole file is too coarse. Lines can be of any length;
+// they are hashed as they are read and never held in memory as a whole.
+//
+// It is used like bufio.Scanner:
+//
+//	s := nzaat.NewLineScanner(r)
+//	for s.Scan() {
+//		fmt.Println(s.Line(), nzaat.FormatSum(s.Sum32()))
+//	}
+//	if err := s.Err(); err != nil {
+//		...
+//	}
+type LineScanner struct {
+	r     *bufio.Reader
+	delim byte
+	line  int64
+	sum   uint32
+	err   error
+}
+
+// NewLineScanner returns a LineScanner reading from r with the line
+// delimiter '\n'.
+func NewLineScanner(r io.Reader) *LineScanner {
+	return &LineScanner{r: bufio.NewReader(r), delim: '\n'}
+}
+
+// SetDelimiter sets the octet separating lines, such as 0 for the
+// output of find -print0. It must be called before the first Scan.
+func (s *LineScanner) SetDelimiter(delim byte) {
+	s.delim = delim
+}
+
+// Scan advances to the next line, which is then available through Line
+// and Sum32. The delimiter is not part of the checksum. A final line
+// without a delimiter is still returned, unless it is empty. Scan
+// returns false at the end of the input or after an error.
+func (s *LineScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	var d Digest
+	var n int
+	for {
+		chunk, err := s.r.ReadSlice(s.delim)
+		n += len(chunk)
+		switch err {
+		case nil:
+			d.Write(chunk[:len(chunk)-1])
+			s.line++
+			s.sum = d.Sum32()
+			return true
+		case bufio.ErrBufferFull:
+			d.Write(chunk)
+		default:
+			s.err = err
+			if n == 0 {
+				return false
+			}
+			d.Write(chunk)
+			s.line++
+			s.sum = d.Sum32()
+			return true
+		}
+	}
+}
+
+// Line returns the number of the current line, counting from 1.
+func (s *LineScanner) Line() int64 {
+	return s.line
+}
+
+// Sum32 returns the NZAAT checksum of the current line.
+func (s *LineScanner) Sum32() uint32 {
+	return s.sum
+}
+
+// Err returns the first error other than io.EOF encountered by Scan.
+func (s *LineScanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// scanLines returns the checksums of all lines read by s.
+func scanLines(t *testing.T, s *LineScanner) []uint32 {
+	t.Helper()
+
+	var sums []uint32
+	for s.Scan() {
+		if s.Line() != int64(len(sums)+1) {
+			t.Errorf("Got line number %d, expected %d", s.Line(), len(sums)+1)
+		}
+		sums = append(sums, s.Sum32())
+	}
+	if err := s.Err(); err != nil {
+		t.Error(err)
+	}
+	return sums
+}
+
+// Test checksumming lines with and without a final delimiter.
+func TestLineScanner(t *testing.T) {
+	var long string = strings.Repeat("0123456789", 1000)
+
+	for _, in := range []string{"abc\n\na\n", "abc\n\na", "abc\n\na\n" + long + "\n", ""} {
+		var want []uint32
+		var lines []string = strings.Split(strings.TrimSuffix(in, "\n"), "\n")
+		if in == "" {
+			lines = nil
+		}
+		for _, l := range lines {
+			want = append(want, ChecksumString(l))
+		}
+
+		var got []uint32 = scanLines(t, NewLineScanner(iotest.OneByteReader(strings.NewReader(in))))
+		if len(got) != len(want) {
+			t.Errorf("%.20q: got %d lines, expected %d", in, len(got), len(want))
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("%.20q, line %d: got %08x, expected %08x", in, i+1, got[i], want[i])
+			}
+		}
+	}
+}
+
+// Test a custom delimiter.
+func TestLineScannerDelimiter(t *testing.T) {
+	var s *LineScanner = NewLineScanner(strings.NewReader("abc\x00a\nb\x00"))
+	s.SetDelimiter(0)
+
+	var got []uint32 = scanLines(t, s)
+	if len(got) != 2 || got[0] != 0xc3e39e2d || got[1] != ChecksumString("a\nb") {
+		t.Errorf("Got %08x", got)
+	}
+}
+
+// Test that read errors are reported.
+func TestLineScannerError(t *testing.T) {
+	var errTest error = errors.New("test error")
+	var s *LineScanner = NewLineScanner(io.MultiReader(strings.NewReader("abc\n"), iotest.ErrReader(errTest)))
+
+	if !s.Scan() || s.Sum32() != 0xc3e39e2d {
+		t.Fatal("The first line was not returned")
+	}
+	if s.Scan() {
+		t.Error("Scan returned a line after the error")
+	}
+	if !errors.Is(s.Err(), errTest) {
+		t.Errorf("Got error %v, expected %v", s.Err(), errTest)
+	}
+}
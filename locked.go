@@ -0,0 +1,56 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"sync"
+)
+
+type lockedDigest struct {
+	mtx sync.Mutex
+	h   hash.Hash32
+}
+
+// NewLocked returns a hash.Hash32 which serializes all calls to h with
+// a mutex, so that a single running checksum can be shared by several
+// goroutines. Every Write is added as a whole, but the order of
+// concurrent writes, and hence the result, depends on scheduling.
+func NewLocked(h hash.Hash32) hash.Hash32 {
+	return &lockedDigest{h: h}
+}
+
+func (l *lockedDigest) Write(p []byte) (int, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.h.Write(p)
+}
+
+func (l *lockedDigest) Sum(b []byte) []byte {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.h.Sum(b)
+}
+
+func (l *lockedDigest) Sum32() uint32 {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.h.Sum32()
+}
+
+func (l *lockedDigest) Reset() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.h.Reset()
+}
+
+func (l *lockedDigest) Size() int {
+	return l.h.Size()
+}
+
+func (l *lockedDigest) BlockSize() int {
+	return l.h.BlockSize()
+}
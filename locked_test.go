@@ -0,0 +1,35 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"sync"
+	"testing"
+)
+
+// Test that concurrent writes of identical data give the same result
+// as sequential ones, and that the race detector stays quiet.
+func TestLocked(t *testing.T) {
+	var h hash.Hash32 = NewLocked(New())
+	var want hash.Hash32 = New()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		want.Write([]byte("record\n"))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Write([]byte("record\n"))
+			h.Sum32()
+		}()
+	}
+	wg.Wait()
+
+	if h.Sum32() != want.Sum32() {
+		t.Errorf("Got %08x, expected %08x", h.Sum32(), want.Sum32())
+	}
+}
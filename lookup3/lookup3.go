@@ -0,0 +1,145 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package lookup3 implements Bob Jenkins' lookup3 hash functions,
+// hashword, hashword2, hashlittle and hashlittle2, as published in
+// lookup3.c (May 2006). The results are the same as those of the C
+// implementation on a little-endian machine.
+package lookup3
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const golden = 0xdeadbeef
+
+func mix(a, b, c uint32) (uint32, uint32, uint32) {
+	a -= c
+	a ^= bits.RotateLeft32(c, 4)
+	c += b
+	b -= a
+	b ^= bits.RotateLeft32(a, 6)
+	a += c
+	c -= b
+	c ^= bits.RotateLeft32(b, 8)
+	b += a
+	a -= c
+	a ^= bits.RotateLeft32(c, 16)
+	c += b
+	b -= a
+	b ^= bits.RotateLeft32(a, 19)
+	a += c
+	c -= b
+	c ^= bits.RotateLeft32(b, 4)
+	b += a
+	return a, b, c
+}
+
+func final(a, b, c uint32) (uint32, uint32, uint32) {
+	c ^= b
+	c -= bits.RotateLeft32(b, 14)
+	a ^= c
+	a -= bits.RotateLeft32(c, 11)
+	b ^= a
+	b -= bits.RotateLeft32(a, 25)
+	c ^= b
+	c -= bits.RotateLeft32(b, 16)
+	a ^= c
+	a -= bits.RotateLeft32(c, 4)
+	b ^= a
+	b -= bits.RotateLeft32(a, 14)
+	c ^= b
+	c -= bits.RotateLeft32(b, 24)
+	return a, b, c
+}
+
+func hashword(k []uint32, a, b, c uint32) (uint32, uint32) {
+	for len(k) > 3 {
+		a += k[0]
+		b += k[1]
+		c += k[2]
+		a, b, c = mix(a, b, c)
+		k = k[3:]
+	}
+
+	switch len(k) {
+	case 3:
+		c += k[2]
+		fallthrough
+	case 2:
+		b += k[1]
+		fallthrough
+	case 1:
+		a += k[0]
+		a, b, c = final(a, b, c)
+	}
+
+	return c, b
+}
+
+// HashWord returns the hash of the 32-bit words k, like hashword.
+func HashWord(k []uint32, initval uint32) uint32 {
+	var a uint32 = golden + uint32(len(k))<<2 + initval
+	c, _ := hashword(k, a, a, a)
+	return c
+}
+
+// HashWord2 returns two 32-bit hashes of the 32-bit words k, like
+// hashword2. pc and pb are the primary and secondary initial values;
+// the primary result c is the same as the one of HashWord with the
+// initial value pc if pb is 0.
+func HashWord2(k []uint32, pc, pb uint32) (c, b uint32) {
+	var a uint32 = golden + uint32(len(k))<<2 + pc
+	return hashword(k, a, a, a+pb)
+}
+
+func hashlittle(key []byte, a, b, c uint32) (uint32, uint32) {
+	if len(key) == 0 {
+		return c, b
+	}
+
+	for len(key) > 12 {
+		a += binary.LittleEndian.Uint32(key[0:])
+		b += binary.LittleEndian.Uint32(key[4:])
+		c += binary.LittleEndian.Uint32(key[8:])
+		a, b, c = mix(a, b, c)
+		key = key[12:]
+	}
+
+	// The last block is zero padded.
+	var tail [12]byte
+	copy(tail[:], key)
+	a += binary.LittleEndian.Uint32(tail[0:])
+	b += binary.LittleEndian.Uint32(tail[4:])
+	c += binary.LittleEndian.Uint32(tail[8:])
+	a, b, c = final(a, b, c)
+
+	return c, b
+}
+
+// HashLittle returns the hash of key, like hashlittle.
+func HashLittle(key []byte, initval uint32) uint32 {
+	var a uint32 = golden + uint32(len(key)) + initval
+	c, _ := hashlittle(key, a, a, a)
+	return c
+}
+
+// HashLittle2 returns two 32-bit hashes of key, like hashlittle2. pc and
+// pb are the primary and secondary initial values; the primary result c
+// is the same as the one of HashLittle with the initial value pc if pb
+// is 0.
+func HashLittle2(key []byte, pc, pb uint32) (c, b uint32) {
+	var a uint32 = golden + uint32(len(key)) + pc
+	return hashlittle(key, a, a, a+pb)
+}
+
+// Hash64 returns a 64-bit hash of key computed with HashLittle2, using
+// the conventional combination of c in the low and b in the high 32
+// bits for both the seed and the result.
+func Hash64(key []byte, seed uint64) uint64 {
+	c, b := HashLittle2(key, uint32(seed), uint32(seed>>32))
+	return uint64(c) | uint64(b)<<32
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package lookup3
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// Test the vectors of driver5() in lookup3.c.
+func TestHashLittle2Vectors(t *testing.T) {
+	var four []byte = []byte("Four score and seven years ago")
+
+	for _, v := range []struct {
+		key          []byte
+		pc, pb       uint32
+		wantC, wantB uint32
+	}{
+		{nil, 0, 0, 0xdeadbeef, 0xdeadbeef},
+		{nil, 0, 0xdeadbeef, 0xbd5b7dde, 0xdeadbeef},
+		{nil, 0xdeadbeef, 0xdeadbeef, 0x9c093ccd, 0xbd5b7dde},
+		{four, 0, 0, 0x17770551, 0xce7226e6},
+		{four, 0, 1, 0xe3607cae, 0xbd371de4},
+		{four, 1, 0, 0xcd628161, 0x6cbea4b3},
+	} {
+		c, b := HashLittle2(v.key, v.pc, v.pb)
+		if c != v.wantC || b != v.wantB {
+			t.Errorf("hashlittle2(%q, %x, %x) = %x %x, expected %x %x",
+				v.key, v.pc, v.pb, c, b, v.wantC, v.wantB)
+		}
+	}
+
+	if res := HashLittle(four, 0); res != 0x17770551 {
+		t.Errorf("hashlittle = %x, expected 17770551", res)
+	}
+	if res := HashLittle(four, 1); res != 0xcd628161 {
+		t.Errorf("hashlittle = %x, expected cd628161", res)
+	}
+	if res := Hash64(four, 1<<32); res != 0xbd371de4e3607cae {
+		t.Errorf("Hash64 = %x", res)
+	}
+}
+
+// Test that hashword agrees with hashlittle on the little-endian
+// encoding of the words, as it does in C on little-endian machines.
+func TestHashWord(t *testing.T) {
+	var words []uint32
+
+	for n := 0; n < 10; n++ {
+		var key []byte = make([]byte, 4*len(words))
+		for i, w := range words {
+			binary.LittleEndian.PutUint32(key[4*i:], w)
+		}
+
+		if HashWord(words, 7) != HashLittle(key, 7) {
+			t.Errorf("hashword and hashlittle disagree for %d words", n)
+		}
+
+		c1, b1 := HashWord2(words, 3, 5)
+		c2, b2 := HashLittle2(key, 3, 5)
+		if c1 != c2 || b1 != b2 {
+			t.Errorf("hashword2 and hashlittle2 disagree for %d words", n)
+		}
+
+		words = append(words, uint32(n)*0x9e3779b9)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io"
+	"path"
+	"strings"
+)
+
+// cleanPath turns an archive entry name into a path as used by fs.FS.
+func cleanPath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// FromTar returns the manifest of the regular files in the tar archive
+// read from r. The archive is streamed, so r may be a pipe or a
+// decompressor; entries are never written to disk.
+func FromTar(r io.Reader) (*Manifest, error) {
+	var tr *tar.Reader = tar.NewReader(r)
+	var m *Manifest = new(Manifest)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if _, err = m.Add(cleanPath(hdr.Name), tr); err != nil {
+			return nil, err
+		}
+	}
+
+	m.sort()
+	return m, nil
+}
+
+// FromZip returns the manifest of the files in the zip archive read
+// from r, which has the given size.
+func FromZip(r io.ReaderAt, size int64) (*Manifest, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var m *Manifest = new(Manifest)
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		_, err = m.Add(cleanPath(f.Name), rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.sort()
+	return m, nil
+}
+
+// VerifyTar compares the files in the tar archive read from r to m.
+func VerifyTar(r io.Reader, m *Manifest) ([]Mismatch, error) {
+	got, err := FromTar(r)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(m, got), nil
+}
+
+// VerifyZip compares the files in the zip archive read from r to m.
+func VerifyZip(r io.ReaderAt, size int64, m *Manifest) ([]Mismatch, error) {
+	got, err := FromZip(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(m, got), nil
+}
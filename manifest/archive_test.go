@@ -0,0 +1,73 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var archiveFiles = []struct {
+	name string
+	data string
+}{
+	{"./abc", "abc"},
+	{"dir/empty", ""},
+	{"dir/a\\b\nc", "odd name"},
+}
+
+// Test that the manifest of a tar archive matches that of the tree.
+func TestTar(t *testing.T) {
+	var buf bytes.Buffer
+	var tw *tar.Writer = tar.NewWriter(&buf)
+
+	tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755})
+	for _, f := range archiveFiles {
+		tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(f.data))})
+		tw.Write([]byte(f.data))
+	}
+	tw.WriteHeader(&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "abc"})
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := Build(testFS())
+	got, err := FromTar(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %+v, expected %+v", got, want)
+	}
+
+	want.Entries[0].Sum++
+	if mm, err := VerifyTar(bytes.NewReader(buf.Bytes()), want); err != nil || len(mm) != 1 || mm[0].Path != "abc" {
+		t.Errorf("Unexpected mismatches %v, %v", mm, err)
+	}
+}
+
+// Test that the manifest of a zip archive matches that of the tree.
+func TestZip(t *testing.T) {
+	var buf bytes.Buffer
+	var zw *zip.Writer = zip.NewWriter(&buf)
+
+	zw.Create("dir/")
+	for _, f := range archiveFiles {
+		w, _ := zw.Create(f.name)
+		w.Write([]byte(f.data))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := Build(testFS())
+	if mm, err := VerifyZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), want); err != nil || len(mm) != 0 {
+		t.Errorf("Unexpected mismatches %v, %v", mm, err)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Range is a range of bytes of a file.
+type Range struct {
+	Off int64
+	Len int64
+}
+
+// Change describes how a file differs between an old and a new tree.
+// Old is nil for added files, and New is nil for removed files.
+type Change struct {
+	Path string
+	Old  *Entry
+	New  *Entry
+
+	// Ranges lists the ranges of the new file whose chunks differ from
+	// the old file, in order and with adjacent ranges merged. It is
+	// only set for modified files compared chunk by chunk. A file which
+	// was only truncated has no ranges; compare the sizes for that.
+	Ranges []Range
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Old == nil:
+		return "added " + c.Path
+	case c.New == nil:
+		return "removed " + c.Path
+	case c.Ranges == nil:
+		return "modified " + c.Path
+	default:
+		var s string = "modified " + c.Path
+		for _, r := range c.Ranges {
+			s += fmt.Sprintf(" %d+%d", r.Off, r.Len)
+		}
+		return s
+	}
+}
+
+// Diff returns the files which were added, removed or modified between
+// the manifests old and new, sorted by path. Both are sorted as a side
+// effect.
+func Diff(old, new *Manifest) []Change {
+	var changes []Change
+	for _, mm := range Compare(old, new) {
+		changes = append(changes, Change{Path: mm.Path, Old: mm.Want, New: mm.Got})
+	}
+	return changes
+}
+
+// DiffOptions configures DiffTrees.
+type DiffOptions struct {
+	// ChunkSize is the size of the chunks modified files are compared
+	// in to find the changed ranges. If it is 0, files are only
+	// compared as a whole.
+	ChunkSize int64
+}
+
+// DiffTrees returns the files which were added, removed or modified
+// between the trees old and new, sorted by path. Unmodified files are
+// only read once; modified files are read again to compare their
+// chunks if opts.ChunkSize is set.
+func DiffTrees(old, new fs.FS, opts DiffOptions) ([]Change, error) {
+	oldm, err := Build(old)
+	if err != nil {
+		return nil, err
+	}
+	newm, err := Build(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change = Diff(oldm, newm)
+	if opts.ChunkSize <= 0 {
+		return changes, nil
+	}
+
+	for i := range changes {
+		var c *Change = &changes[i]
+		if c.Old == nil || c.New == nil {
+			continue
+		}
+
+		oldSums, err := chunkFile(old, c.Path, opts.ChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		newSums, err := chunkFile(new, c.Path, opts.ChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		c.Ranges = changedRanges(oldSums, newSums, opts.ChunkSize, c.New.Size)
+	}
+
+	return changes, nil
+}
+
+// ChunkSums returns the NZAAT checksums of the consecutive chunks of
+// size bytes read from r, the last of which may be shorter. It panics
+// if size is not positive.
+func ChunkSums(r io.Reader, size int64) ([]uint32, error) {
+	if size <= 0 {
+		panic("manifest: chunk size must be positive")
+	}
+
+	var sums []uint32
+	for {
+		var d nzaat.Digest
+		n, err := io.CopyN(&d, r, size)
+		if n > 0 {
+			sums = append(sums, d.Sum32())
+		}
+		if err == io.EOF {
+			return sums, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func chunkFile(fsys fs.FS, name string, size int64) ([]uint32, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums, err := ChunkSums(f, size)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %s: %w", name, err)
+	}
+	return sums, nil
+}
+
+// changedRanges returns the merged ranges of the chunks of the new file
+// which differ from the old file or which the old file does not have.
+func changedRanges(old, new []uint32, chunkSize, size int64) []Range {
+	var ranges []Range = []Range{}
+
+	for i, sum := range new {
+		if i < len(old) && old[i] == sum {
+			continue
+		}
+
+		var off int64 = int64(i) * chunkSize
+		var n int64 = min(chunkSize, size-off)
+		if k := len(ranges) - 1; k >= 0 && ranges[k].Off+ranges[k].Len == off {
+			ranges[k].Len += n
+		} else {
+			ranges = append(ranges, Range{Off: off, Len: n})
+		}
+	}
+
+	return ranges
+}
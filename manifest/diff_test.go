@@ -0,0 +1,104 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// Test diffing two trees file by file and chunk by chunk.
+func TestDiffTrees(t *testing.T) {
+	var base []byte = bytes.Repeat([]byte("0123456789"), 10)
+	var modified []byte = bytes.Clone(base)
+	modified[15] = 'x'
+	modified[25] = 'x'
+	modified[75] = 'x'
+
+	var old fstest.MapFS = fstest.MapFS{
+		"same":      {Data: base},
+		"modified":  {Data: base},
+		"appended":  {Data: base},
+		"truncated": {Data: base},
+		"removed":   {Data: base},
+	}
+	var new fstest.MapFS = fstest.MapFS{
+		"same":      {Data: base},
+		"modified":  {Data: modified},
+		"appended":  {Data: append(bytes.Clone(base), "abc"...)},
+		"truncated": {Data: base[:50]},
+		"added":     {Data: base},
+	}
+
+	changes, err := DiffTrees(old, new, DiffOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	var want []string = []string{
+		"added added", "modified appended", "modified modified",
+		"removed removed", "modified truncated",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("File changes: got %q, expected %q", got, want)
+	}
+
+	changes, err = DiffTrees(old, new, DiffOptions{ChunkSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want = []string{
+		"added added", "modified appended 100+3", "modified modified 10+20 70+10",
+		"removed removed", "modified truncated",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chunk changes: got %q, expected %q", got, want)
+	}
+}
+
+// Test diffing manifests.
+func TestDiff(t *testing.T) {
+	var old, new Manifest
+	old.Add("a", strings.NewReader("a"))
+	old.Add("b", strings.NewReader("b"))
+	new.Add("b", strings.NewReader("B"))
+	new.Add("c", strings.NewReader("c"))
+
+	var changes []Change = Diff(&old, &new)
+	if len(changes) != 3 {
+		t.Fatalf("Got %d changes, expected 3: %v", len(changes), changes)
+	}
+	if changes[0].Path != "a" || changes[0].New != nil {
+		t.Errorf("Expected a to be removed, got %v", changes[0])
+	}
+	if changes[1].Path != "b" || changes[1].Old == nil || changes[1].New == nil {
+		t.Errorf("Expected b to be modified, got %v", changes[1])
+	}
+	if changes[2].Path != "c" || changes[2].Old != nil {
+		t.Errorf("Expected c to be added, got %v", changes[2])
+	}
+}
+
+// Test splitting input into chunk checksums.
+func TestChunkSums(t *testing.T) {
+	sums, err := ChunkSums(strings.NewReader("abcabcab"), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sums) != 3 || sums[0] != 0xc3e39e2d || sums[1] != sums[0] || sums[2] == sums[0] {
+		t.Errorf("Got %08x", sums)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+var (
+	// ErrUnlisted is returned, wrapped in an *fs.PathError, when
+	// opening a regular file which is not in the manifest of a
+	// VerifyingFS.
+	ErrUnlisted = errors.New("manifest: file not in manifest")
+
+	// ErrMismatch is returned, wrapped in an *fs.PathError, by reads
+	// from a file of a VerifyingFS whose contents don't match the
+	// manifest.
+	ErrMismatch = errors.New("manifest: file does not match manifest")
+)
+
+type verifyingFS struct {
+	fsys    fs.FS
+	entries map[string]Entry
+}
+
+// VerifyingFS returns a file system presenting the files of fsys which
+// verifies every regular file against m while it is read. The checksum
+// is compared as soon as the size listed in m has been read, so the
+// read reaching it returns ErrMismatch for a damaged file, and a file
+// growing beyond its size in m fails as soon as it does. Data returned
+// before the mismatch is detected must not be trusted, so callers
+// should read files completely before using them, as fs.ReadFile does.
+// Files can be seeked if those of fsys can, which lets http.FS serve
+// range requests; seeking hashes the file up to the new offset again,
+// so reads from there on are still verified. Regular files which are
+// not in m cannot be opened; directories are passed through as they
+// are.
+func VerifyingFS(fsys fs.FS, m *Manifest) fs.FS {
+	var v *verifyingFS = &verifyingFS{fsys: fsys, entries: make(map[string]Entry)}
+	for _, e := range m.Entries {
+		v.entries[e.Path] = e
+	}
+	return v
+}
+
+func (v *verifyingFS) Open(name string) (fs.File, error) {
+	f, err := v.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return f, nil
+	}
+
+	e, ok := v.entries[name]
+	if !ok {
+		f.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: ErrUnlisted}
+	}
+	if info.Size() != e.Size {
+		f.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: ErrMismatch}
+	}
+	return &verifyingFile{File: f, entry: e}, nil
+}
+
+// verifyingFile hashes a file while it is read.
+type verifyingFile struct {
+	fs.File
+	entry Entry
+	d     nzaat.Digest
+	n     int64
+}
+
+func (f *verifyingFile) Read(p []byte) (int, error) {
+	n, err := f.File.Read(p)
+	f.d.Write(p[:n])
+	f.n += int64(n)
+
+	// Check the checksum as soon as all of the file has been read, as
+	// readers which know the size may never see io.EOF.
+	if f.n > f.entry.Size || (f.n == f.entry.Size && f.d.Sum32() != f.entry.Sum) ||
+		(err == io.EOF && f.n < f.entry.Size) {
+		return n, &fs.PathError{Op: "read", Path: f.entry.Path, Err: ErrMismatch}
+	}
+	return n, err
+}
+
+// Seek seeks the underlying file, if it supports seeking, and restarts
+// the verification by hashing the file up to the new offset.
+func (f *verifyingFile) Seek(offset int64, whence int) (int64, error) {
+	s, ok := f.File.(io.Seeker)
+	if !ok {
+		return 0, &fs.PathError{Op: "seek", Path: f.entry.Path, Err: errors.ErrUnsupported}
+	}
+
+	pos, err := s.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	if _, err = s.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	f.d.Reset()
+	f.n = 0
+	if _, err = io.CopyN(io.Discard, f, min(pos, f.entry.Size)); err != nil {
+		return 0, err
+	}
+	return s.Seek(pos, io.SeekStart)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+// Test reading intact, damaged and unlisted files.
+func TestVerifyingFS(t *testing.T) {
+	var fsys fstest.MapFS = testFS()
+	m, err := Build(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys["abd"] = &fstest.MapFile{Data: []byte("abd")}
+	fsys["dir/empty"] = &fstest.MapFile{Data: []byte("x")}
+
+	var v fs.FS = VerifyingFS(fsys, m)
+	if data, err := fs.ReadFile(v, "abc"); err != nil || string(data) != "abc" {
+		t.Errorf("Reading intact file: %q, %v", data, err)
+	}
+	if _, err := fs.ReadFile(v, "abd"); !errors.Is(err, ErrUnlisted) {
+		t.Errorf("Reading unlisted file: %v", err)
+	}
+	if _, err := fs.ReadFile(v, "dir/empty"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Reading grown file: %v", err)
+	}
+	if entries, err := fs.ReadDir(v, "dir"); err != nil || len(entries) != 2 {
+		t.Errorf("Reading directory: %v, %v", entries, err)
+	}
+
+	// Same size, different contents.
+	fsys["abc"] = &fstest.MapFile{Data: []byte("abd")}
+	if _, err := fs.ReadFile(v, "abc"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Reading changed file: %v", err)
+	}
+}
+
+// Test that a damaged file is reported to readers which stop after the
+// listed size without waiting for io.EOF.
+func TestVerifyingFSReadFull(t *testing.T) {
+	var fsys fstest.MapFS = testFS()
+	m, err := Build(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys["abc"] = &fstest.MapFile{Data: []byte("abd")}
+
+	f, err := VerifyingFS(fsys, m).Open("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var buf [3]byte
+	if n, err := f.Read(buf[:]); n != 3 || !errors.Is(err, ErrMismatch) {
+		t.Errorf("Reading all of changed file: %d, %v", n, err)
+	}
+}
+
+// Test that reads after seeking are still verified.
+func TestVerifyingFSSeek(t *testing.T) {
+	var fsys fstest.MapFS = testFS()
+	m, err := Build(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for data, want := range map[string]error{"abc": nil, "abd": ErrMismatch} {
+		fsys["abc"] = &fstest.MapFile{Data: []byte(data)}
+
+		f, err := VerifyingFS(fsys, m).Open("abc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s io.ReadSeeker = f.(io.ReadSeeker)
+		if end, err := s.Seek(0, io.SeekEnd); err != nil || end != 3 {
+			t.Errorf("Seeking to the end of %q: %d, %v", data, end, err)
+		}
+		if _, err = s.Seek(1, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		rest, err := io.ReadAll(s)
+		if string(rest) != data[1:] || !errors.Is(err, want) {
+			t.Errorf("Reading %q after seeking: %q, %v", data, rest, err)
+		}
+		f.Close()
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package manifest builds and verifies manifests of file trees: lists
+// of the size and NZAAT checksum of every regular file.
+//
+// Manifests are stored in a stable text format. The first line is the
+// header "nzaat-manifest 1", followed by one line per file, sorted by
+// path, with the checksum, the size in bytes and the slash separated
+// path:
+//
+//	nzaat-manifest 1
+//	c3e39e2d 3 dir/abc
+//	digest 1f2e3d4c
+//
+// Backslashes and newlines in paths are escaped as \\ and \n. The last
+// line holds the NZAAT checksum of all preceding lines, including their
+// newlines, so that damage to the manifest itself is detected.
+//
+// Diff and DiffTrees report which files were added, removed or modified
+// between two manifests or trees, and DiffTrees can narrow modified
+// files down to the byte ranges of the chunks which changed.
+// VerifyingFS checks files against a manifest while they are read.
+package manifest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+const header = "nzaat-manifest 1"
+
+var (
+	// ErrFormat is returned, wrapped, by Read for malformed manifests.
+	ErrFormat = errors.New("manifest: malformed manifest")
+
+	// ErrDigest is returned by Read if the digest line doesn't match
+	// the contents of the manifest.
+	ErrDigest = errors.New("manifest: digest mismatch")
+)
+
+var (
+	escaper   = strings.NewReplacer("\\", "\\\\", "\n", "\\n")
+	unescaper = strings.NewReplacer("\\\\", "\\", "\\n", "\n")
+)
+
+// Entry describes a single file.
+type Entry struct {
+	Path string
+	Size int64
+	Sum  uint32
+}
+
+// Manifest is a list of file entries.
+type Manifest struct {
+	Entries []Entry
+}
+
+// Add appends an entry for the file at path with the contents read from
+// r, and returns it.
+func (m *Manifest) Add(path string, r io.Reader) (Entry, error) {
+	var h nzaat.Digest
+
+	size, err := io.Copy(&h, r)
+	if err != nil {
+		return Entry{}, fmt.Errorf("manifest: %s: %w", path, err)
+	}
+
+	var e Entry = Entry{Path: path, Size: size, Sum: h.Sum32()}
+	m.Entries = append(m.Entries, e)
+	return e, nil
+}
+
+// Build returns the manifest of all regular files in fsys.
+func Build(fsys fs.FS) (*Manifest, error) {
+	var m *Manifest = new(Manifest)
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		f, err := fsys.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = m.Add(path, f)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.sort()
+	return m, nil
+}
+
+func (m *Manifest) sort() {
+	sort.Slice(m.Entries, func(i, j int) bool { return m.Entries[i].Path < m.Entries[j].Path })
+}
+
+// body returns the manifest without the digest line.
+func (m *Manifest) body() string {
+	var sb strings.Builder
+
+	m.sort()
+	sb.WriteString(header + "\n")
+	for _, e := range m.Entries {
+		fmt.Fprintf(&sb, "%s %d %s\n", nzaat.FormatSum(e.Sum), e.Size, escaper.Replace(e.Path))
+	}
+	return sb.String()
+}
+
+// Digest returns the checksum of the manifest as stored on its last
+// line. It identifies the whole tree.
+func (m *Manifest) Digest() uint32 {
+	return nzaat.Checksum([]byte(m.body()))
+}
+
+// WriteTo writes m to w in the text format. The entries are sorted by
+// path first.
+func (m *Manifest) WriteTo(w io.Writer) (int64, error) {
+	var body string = m.body()
+	n, err := fmt.Fprintf(w, "%sdigest %s\n", body, nzaat.FormatSum(nzaat.Checksum([]byte(body))))
+	return int64(n), err
+}
+
+// Read parses a manifest written by WriteTo, verifying its digest.
+func Read(r io.Reader) (*Manifest, error) {
+	var br *bufio.Reader = bufio.NewReader(r)
+	var m *Manifest = new(Manifest)
+	var h nzaat.Digest
+	var lineno int
+
+	for {
+		line, err := br.ReadString('\n')
+		lineno++
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: missing digest", ErrFormat)
+		} else if err != nil {
+			return nil, err
+		}
+
+		var text string = strings.TrimSuffix(line, "\n")
+		if lineno == 1 {
+			if text != header {
+				return nil, fmt.Errorf("%w: bad header %q", ErrFormat, text)
+			}
+			h.WriteString(line)
+			continue
+		}
+
+		if digest, ok := strings.CutPrefix(text, "digest "); ok {
+			sum, err := nzaat.ParseSum(digest)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrFormat, lineno, err)
+			}
+			if sum != h.Sum32() {
+				return nil, ErrDigest
+			}
+			if _, err = br.ReadByte(); err != io.EOF {
+				return nil, fmt.Errorf("%w: data after the digest", ErrFormat)
+			}
+			return m, nil
+		}
+
+		e, err := parseEntry(text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrFormat, lineno, err)
+		}
+		m.Entries = append(m.Entries, e)
+		h.WriteString(line)
+	}
+}
+
+func parseEntry(text string) (Entry, error) {
+	var e Entry
+
+	fields := strings.SplitN(text, " ", 3)
+	if len(fields) != 3 || fields[2] == "" {
+		return e, errors.New("expected checksum, size and path")
+	}
+
+	sum, err := nzaat.ParseSum(fields[0])
+	if err != nil {
+		return e, err
+	}
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || size < 0 {
+		return e, fmt.Errorf("bad size %q", fields[1])
+	}
+
+	e = Entry{Path: unescaper.Replace(fields[2]), Size: size, Sum: sum}
+	return e, nil
+}
+
+// Mismatch describes a difference between two manifests. Want is nil
+// for files which are not expected, and Got is nil for missing files.
+type Mismatch struct {
+	Path string
+	Want *Entry
+	Got  *Entry
+}
+
+func (mm Mismatch) String() string {
+	switch {
+	case mm.Want == nil:
+		return mm.Path + ": unexpected file"
+	case mm.Got == nil:
+		return mm.Path + ": missing"
+	case mm.Want.Size != mm.Got.Size:
+		return fmt.Sprintf("%s: size %d, expected %d", mm.Path, mm.Got.Size, mm.Want.Size)
+	default:
+		return fmt.Sprintf("%s: checksum %s, expected %s", mm.Path, nzaat.FormatSum(mm.Got.Sum), nzaat.FormatSum(mm.Want.Sum))
+	}
+}
+
+// Compare returns the differences between the expected manifest want
+// and got, sorted by path. Both are sorted as a side effect.
+func Compare(want, got *Manifest) []Mismatch {
+	var mismatches []Mismatch
+	var i, j int
+
+	want.sort()
+	got.sort()
+
+	for i < len(want.Entries) || j < len(got.Entries) {
+		switch {
+		case j == len(got.Entries) || (i < len(want.Entries) && want.Entries[i].Path < got.Entries[j].Path):
+			mismatches = append(mismatches, Mismatch{Path: want.Entries[i].Path, Want: &want.Entries[i]})
+			i++
+		case i == len(want.Entries) || got.Entries[j].Path < want.Entries[i].Path:
+			mismatches = append(mismatches, Mismatch{Path: got.Entries[j].Path, Got: &got.Entries[j]})
+			j++
+		default:
+			if want.Entries[i] != got.Entries[j] {
+				mismatches = append(mismatches, Mismatch{Path: want.Entries[i].Path, Want: &want.Entries[i], Got: &got.Entries[j]})
+			}
+			i++
+			j++
+		}
+	}
+
+	return mismatches
+}
+
+// Verify builds the manifest of fsys and compares it to m. An error is
+// only returned if fsys could not be read; differences are returned as
+// mismatches.
+func Verify(fsys fs.FS, m *Manifest) ([]Mismatch, error) {
+	got, err := Build(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(m, got), nil
+}
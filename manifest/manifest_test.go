@@ -0,0 +1,115 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"abc":         {Data: []byte("abc")},
+		"dir/empty":   {Data: nil},
+		"dir/a\\b\nc": {Data: []byte("odd name")},
+	}
+}
+
+// Test building a manifest and writing it in the text format.
+func TestBuildWrite(t *testing.T) {
+	m, err := Build(testFS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err = m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string = strings.Split(buf.String(), "\n")
+	if len(lines) != 6 || lines[0] != "nzaat-manifest 1" || lines[1] != "c3e39e2d 3 abc" || lines[3] != "00000000 0 dir/empty" {
+		t.Errorf("Unexpected manifest %q", buf.String())
+	}
+	if lines[2][8:] != " 8 dir/a\\\\b\\nc" {
+		t.Errorf("Unexpected escaping in %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[4], "digest ") || lines[5] != "" {
+		t.Errorf("Unexpected digest line %q", lines[4])
+	}
+
+	r, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, m) {
+		t.Errorf("Read %+v, expected %+v", r, m)
+	}
+	if r.Digest() != m.Digest() {
+		t.Error("Digest changed after reading")
+	}
+}
+
+// Test that damaged manifests are rejected.
+func TestReadErrors(t *testing.T) {
+	m, _ := Build(testFS())
+	var buf bytes.Buffer
+	m.WriteTo(&buf)
+	var good string = buf.String()
+
+	for _, c := range []struct {
+		text string
+		err  error
+	}{
+		{strings.Replace(good, "c3e39e2d 3 abc", "c3e39e2d 4 abc", 1), ErrDigest},
+		{strings.Replace(good, "nzaat-manifest 1", "nzaat-manifest 2", 1), ErrFormat},
+		{strings.Replace(good, "c3e39e2d 3 abc", "c3e39e2d abc", 1), ErrFormat},
+		{good[:strings.Index(good, "digest")], ErrFormat},
+		{good + "more\n", ErrFormat},
+	} {
+		if _, err := Read(strings.NewReader(c.text)); !errors.Is(err, c.err) {
+			t.Errorf("Reading %q: got %v, expected %v", c.text, err, c.err)
+		}
+	}
+}
+
+// Test verifying a modified tree.
+func TestVerify(t *testing.T) {
+	var fsys fstest.MapFS = testFS()
+	m, _ := Build(fsys)
+
+	if mm, err := Verify(fsys, m); err != nil || len(mm) != 0 {
+		t.Errorf("Unmodified tree gave %v, %v", mm, err)
+	}
+
+	fsys["abc"] = &fstest.MapFile{Data: []byte("abd")}
+	fsys["new"] = &fstest.MapFile{Data: []byte("new")}
+	delete(fsys, "dir/empty")
+
+	mm, err := Verify(fsys, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, x := range mm {
+		got = append(got, x.String())
+	}
+	var want []string = []string{
+		"abc: checksum " + nzaat.FormatSum(nzaat.Checksum([]byte("abd"))) + ", expected c3e39e2d",
+		"dir/empty: missing",
+		"new: unexpected file",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got mismatches %q, expected %q", got, want)
+	}
+}
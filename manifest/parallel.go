@@ -0,0 +1,103 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"errors"
+	"io/fs"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Options configure BuildParallel.
+type Options struct {
+	// Workers is the number of files hashed at the same time.
+	// Defaults to GOMAXPROCS.
+	Workers int
+}
+
+type job struct {
+	path string
+	size int64
+}
+
+// BuildParallel returns the same manifest as Build, hashing several
+// files at once to keep fast storage busy. The largest files are
+// started first, so that a big file found late doesn't leave one
+// worker running long after the others are done. Rather than stopping
+// at the first error, all files are tried and the errors are joined.
+func BuildParallel(fsys fs.FS, opts Options) (*Manifest, error) {
+	var jobs []job
+	var errs []error
+
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		jobs = append(jobs, job{path, fi.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].size > jobs[j].size })
+
+	var entries []Entry = make([]Entry, len(jobs))
+	var failed []error = make([]error, len(jobs))
+	var queue chan int = make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				entries[i], failed[i] = hashFile(fsys, jobs[i].path)
+			}
+		}()
+	}
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	if err = errors.Join(append(errs, failed...)...); err != nil {
+		return nil, err
+	}
+
+	var m *Manifest = &Manifest{Entries: entries}
+	m.sort()
+	return m, nil
+}
+
+func hashFile(fsys fs.FS, path string) (Entry, error) {
+	var m Manifest
+
+	f, err := fsys.Open(path)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer f.Close()
+
+	return m.Add(path, f)
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package manifest
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// Test that the parallel manifest equals the sequential one.
+func TestBuildParallel(t *testing.T) {
+	var fsys fstest.MapFS = testFS()
+	for i := 0; i < 100; i++ {
+		fsys["many/"+strconv.Itoa(i)] = &fstest.MapFile{Data: []byte(strings.Repeat("x", i*37))}
+	}
+
+	want, err := Build(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, workers := range []int{0, 1, 7} {
+		got, err := BuildParallel(fsys, Options{Workers: workers})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d workers gave a different manifest", workers)
+		}
+	}
+}
+
+// failingFS fails to open the files with "bad" in their name.
+type failingFS struct {
+	fstest.MapFS
+}
+
+func (f failingFS) Open(name string) (fs.File, error) {
+	if strings.Contains(name, "bad") {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return f.MapFS.Open(name)
+}
+
+// Test that the errors of all files are reported.
+func TestBuildParallelErrors(t *testing.T) {
+	var fsys fstest.MapFS = testFS()
+	fsys["bad1"] = &fstest.MapFile{}
+	fsys["dir/bad2"] = &fstest.MapFile{}
+
+	_, err := BuildParallel(failingFS{fsys}, Options{Workers: 2})
+	if !errors.Is(err, fs.ErrPermission) || !strings.Contains(err.Error(), "bad1") || !strings.Contains(err.Error(), "bad2") {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
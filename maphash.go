@@ -0,0 +1,118 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// Seed selects one of many NZAAT-based hash functions for Hash. Unlike
+// the seeds of hash/maphash, a Seed can be recreated from its value, so
+// hashes computed with a fixed seed are stable across processes.
+type Seed struct {
+	s uint32
+}
+
+// MakeSeed returns a new random seed.
+func MakeSeed() Seed {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("nzaat: unable to read random seed: " + err.Error())
+	}
+	return Seed{s: binary.BigEndian.Uint32(b[:])}
+}
+
+// NewSeed returns the seed with the value v.
+func NewSeed(v uint32) Seed {
+	return Seed{s: v}
+}
+
+// Uint32 returns the value of the seed, for passing to NewSeed.
+func (s Seed) Uint32() uint32 {
+	return s.s
+}
+
+// Digest returns the state of a Hash using s before any data has been
+// written to it. Passing it to Update or UpdateString followed by Final
+// computes the same hash as the seeded Hash without any allocations.
+func (s Seed) Digest() Digest {
+	var d Digest
+	d.WriteUint32(s.s, binary.BigEndian)
+	return d
+}
+
+// Hash computes a seeded NZAAT hash of a byte sequence, with an API
+// modelled after hash/maphash.Hash. The seed is mixed in as four octets
+// of input ahead of the data.
+//
+// The zero Hash is unseeded and computes the plain NZAAT checksum. A
+// Hash is not safe for concurrent use.
+type Hash struct {
+	seed   Seed
+	seeded bool
+	state  Digest
+}
+
+// SetSeed sets h to use seed and resets it.
+func (h *Hash) SetSeed(seed Seed) {
+	h.seed = seed
+	h.seeded = true
+	h.Reset()
+}
+
+// Seed returns the seed of h, or the zero Seed if h is unseeded.
+func (h *Hash) Seed() Seed {
+	return h.seed
+}
+
+// Reset discards all data added to h so far, keeping its seed.
+func (h *Hash) Reset() {
+	h.state.Reset()
+	if h.seeded {
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], h.seed.s)
+		h.state.Write(b[:])
+	}
+}
+
+// Write adds b to the sequence hashed by h. It always returns len(b)
+// and a nil error.
+func (h *Hash) Write(b []byte) (int, error) {
+	return h.state.Write(b)
+}
+
+// WriteString adds the bytes of s to the sequence hashed by h. It
+// always returns len(s) and a nil error.
+func (h *Hash) WriteString(s string) (int, error) {
+	return h.state.WriteString(s)
+}
+
+// WriteByte adds b to the sequence hashed by h. It never fails.
+func (h *Hash) WriteByte(b byte) error {
+	h.state.Write([]byte{b})
+	return nil
+}
+
+// Sum32 returns the hash of the sequence written to h so far.
+func (h *Hash) Sum32() uint32 {
+	return h.state.Sum32()
+}
+
+// Sum appends the big-endian hash value to b.
+func (h *Hash) Sum(b []byte) []byte {
+	return h.state.Sum(b)
+}
+
+// Size returns the size of the hash value in bytes.
+func (h *Hash) Size() int {
+	return 4
+}
+
+// BlockSize returns the block size of the hash.
+func (h *Hash) BlockSize() int {
+	return 1
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"testing"
+)
+
+var _ hash.Hash32 = new(Hash)
+
+// Test that an unseeded Hash computes the plain checksum.
+func TestHashUnseeded(t *testing.T) {
+	var h Hash
+
+	h.WriteString("message ")
+	h.Write([]byte("diges"))
+	h.WriteByte('t')
+
+	if res := h.Sum32(); res != 0x434B78B4 {
+		t.Errorf("Unseeded hash returned %x", res)
+	}
+}
+
+// Test that fixed seeds give reproducible, distinct results.
+func TestHashSeeded(t *testing.T) {
+	var a, b, c Hash
+
+	a.SetSeed(NewSeed(42))
+	b.SetSeed(NewSeed(42))
+	c.SetSeed(NewSeed(43))
+
+	for _, h := range []*Hash{&a, &b, &c} {
+		h.WriteString("abc")
+	}
+
+	if a.Sum32() != b.Sum32() {
+		t.Error("Equal seeds gave different results")
+	}
+	if a.Sum32() == c.Sum32() {
+		t.Error("Different seeds gave equal results")
+	}
+	if res := a.Sum32(); res != 0x2f0f15ca {
+		t.Errorf("Seeded hash returned %x", res)
+	}
+
+	a.Reset()
+	a.WriteString("abc")
+	if a.Sum32() != b.Sum32() {
+		t.Error("Reset lost the seed")
+	}
+	if a.Seed().Uint32() != 42 {
+		t.Errorf("Seed returned %d", a.Seed().Uint32())
+	}
+}
+
+// Test that Seed.Digest continues like a seeded Hash.
+func TestSeedDigest(t *testing.T) {
+	var h Hash
+
+	h.SetSeed(NewSeed(42))
+	h.WriteString("abc")
+	if res := Final(UpdateString(NewSeed(42).Digest(), "abc")); res != h.Sum32() {
+		t.Errorf("Seed digest gave %08x, expected %08x", res, h.Sum32())
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package merkle computes NZAAT tree hashes over the chunks of an
+// object and proves that a chunk belongs to an object with a known
+// root, without the rest of the object.
+//
+// The tree has the shape of the Merkle trees of RFC 9162: for n > 1
+// leaves, the left subtree holds the largest power of two smaller than
+// n leaves and the right subtree the rest. Leaves and inner nodes are
+// hashed with distinct prefixes, so a leaf can never be passed off as
+// an inner node:
+//
+//	leaf = NZAAT(0x00 || chunk)
+//	node = NZAAT(0x01 || left || right)
+//
+// with the child hashes as big endian 32 bit integers. The root of an
+// empty tree is the NZAAT checksum of nothing, 0.
+//
+// A 32 bit hash protects against accidental damage and mix-ups, not
+// against an adversary forging chunks that fit a proof.
+package merkle
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// ErrIndex is returned by Prove for a leaf index outside of the tree.
+var ErrIndex = errors.New("merkle: leaf index out of range")
+
+// LeafSum returns the hash of the leaf for chunk.
+func LeafSum(chunk []byte) uint32 {
+	var d nzaat.Digest
+	d.Write([]byte{0})
+	d.Write(chunk)
+	return d.Sum32()
+}
+
+// NodeSum returns the hash of the inner node with the children left
+// and right.
+func NodeSum(left, right uint32) uint32 {
+	var b [9]byte
+	b[0] = 1
+	binary.BigEndian.PutUint32(b[1:5], left)
+	binary.BigEndian.PutUint32(b[5:], right)
+	return nzaat.Checksum(b[:])
+}
+
+// split returns the number of leaves in the left subtree of a tree of
+// n > 1 leaves.
+func split(n int) int {
+	return 1 << (bits.Len(uint(n-1)) - 1)
+}
+
+// Tree is a Merkle tree over a sequence of leaf hashes.
+type Tree struct {
+	leaves []uint32
+	root   uint32
+}
+
+// New returns the tree over the given leaf hashes, as computed by
+// LeafSum.
+func New(leaves []uint32) *Tree {
+	var t *Tree = &Tree{leaves: leaves}
+	if len(leaves) > 0 {
+		t.root = subtree(leaves)
+	}
+	return t
+}
+
+// FromReader returns the tree over the contents of r split into chunks
+// of chunkSize bytes; only the last chunk may be shorter.
+func FromReader(r io.Reader, chunkSize int) (*Tree, error) {
+	var buf []byte = make([]byte, chunkSize)
+	var leaves []uint32
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			leaves = append(leaves, LeafSum(buf[:n]))
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return New(leaves), nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func subtree(leaves []uint32) uint32 {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	var k int = split(len(leaves))
+	return NodeSum(subtree(leaves[:k]), subtree(leaves[k:]))
+}
+
+// Root returns the root hash of the tree.
+func (t *Tree) Root() uint32 {
+	return t.root
+}
+
+// Len returns the number of leaves in the tree.
+func (t *Tree) Len() int {
+	return len(t.leaves)
+}
+
+// Proof shows that a leaf is part of a tree with a given root.
+type Proof struct {
+	// Index is the position of the leaf in the tree.
+	Index int
+
+	// Size is the number of leaves in the tree.
+	Size int
+
+	// Path holds the hashes of the siblings of the nodes on the way
+	// from the leaf up to the root, starting at the bottom.
+	Path []uint32
+}
+
+// Prove returns the inclusion proof for the leaf at index i.
+func (t *Tree) Prove(i int) (Proof, error) {
+	if i < 0 || i >= len(t.leaves) {
+		return Proof{}, ErrIndex
+	}
+
+	var p Proof = Proof{Index: i, Size: len(t.leaves)}
+	var leaves []uint32 = t.leaves
+	var path []uint32
+
+	// Collect the siblings from the top down, then reverse them.
+	for len(leaves) > 1 {
+		var k int = split(len(leaves))
+		if i < k {
+			path = append(path, subtree(leaves[k:]))
+			leaves = leaves[:k]
+		} else {
+			path = append(path, subtree(leaves[:k]))
+			leaves = leaves[k:]
+			i -= k
+		}
+	}
+	for j := len(path) - 1; j >= 0; j-- {
+		p.Path = append(p.Path, path[j])
+	}
+	return p, nil
+}
+
+// VerifyProof reports whether p proves that the leaf with the hash
+// leaf is part of the tree with the root hash root. This follows the
+// verification algorithm of RFC 9162, section 2.1.3.2.
+func VerifyProof(root, leaf uint32, p Proof) bool {
+	if p.Index < 0 || p.Index >= p.Size {
+		return false
+	}
+
+	var fn, sn uint64 = uint64(p.Index), uint64(p.Size - 1)
+	var r uint32 = leaf
+
+	for _, s := range p.Path {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			r = NodeSum(s, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = NodeSum(r, s)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && r == root
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package merkle
+
+import (
+	"bytes"
+	"testing"
+)
+
+func leafSums(n int) []uint32 {
+	var leaves []uint32
+	for i := 0; i < n; i++ {
+		leaves = append(leaves, LeafSum([]byte{byte(i), byte(i >> 8)}))
+	}
+	return leaves
+}
+
+// Test the shape of small trees.
+func TestRoot(t *testing.T) {
+	var l []uint32 = leafSums(5)
+
+	if res := New(nil).Root(); res != 0 {
+		t.Errorf("Empty tree has root %08x", res)
+	}
+	if res := New(l[:1]).Root(); res != l[0] {
+		t.Errorf("Single leaf tree has root %08x, expected %08x", res, l[0])
+	}
+	var want uint32 = NodeSum(NodeSum(NodeSum(l[0], l[1]), NodeSum(l[2], l[3])), l[4])
+	if res := New(l).Root(); res != want {
+		t.Errorf("Five leaf tree has root %08x, expected %08x", res, want)
+	}
+}
+
+// Test that proofs for every leaf of trees of many sizes verify, and
+// fail for the wrong leaf, root or an index outside the tree.
+func TestProve(t *testing.T) {
+	for n := 1; n <= 33; n++ {
+		var leaves []uint32 = leafSums(n)
+		var tree *Tree = New(leaves)
+
+		for i := 0; i < n; i++ {
+			p, err := tree.Prove(i)
+			if err != nil {
+				t.Fatalf("Proving leaf %d of %d: %v", i, n, err)
+			}
+			if !VerifyProof(tree.Root(), leaves[i], p) {
+				t.Errorf("Proof for leaf %d of %d does not verify", i, n)
+			}
+			if n > 1 && VerifyProof(tree.Root(), leaves[(i+1)%n], p) {
+				t.Errorf("Proof for leaf %d of %d verifies another leaf", i, n)
+			}
+			if VerifyProof(tree.Root()+1, leaves[i], p) {
+				t.Errorf("Proof for leaf %d of %d verifies another root", i, n)
+			}
+			p.Index = n
+			if VerifyProof(tree.Root(), leaves[i], p) {
+				t.Errorf("Proof for leaf %d of %d verifies outside the tree", i, n)
+			}
+		}
+		if _, err := tree.Prove(n); err != ErrIndex {
+			t.Errorf("Proving leaf %d of %d: got %v", n, n, err)
+		}
+	}
+}
+
+// Test that a chunk read from an object can be verified against the
+// root of the whole object.
+func TestFromReader(t *testing.T) {
+	var data []byte = bytes.Repeat([]byte("0123456789"), 100)
+
+	tree, err := FromReader(bytes.NewReader(data), 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Len() != 16 {
+		t.Fatalf("Expected 16 chunks, got %d", tree.Len())
+	}
+
+	p, _ := tree.Prove(15)
+	if !VerifyProof(tree.Root(), LeafSum(data[960:]), p) {
+		t.Error("Proof for the last chunk does not verify")
+	}
+	if VerifyProof(tree.Root(), LeafSum(data[896:960]), p) {
+		t.Error("Proof for the last chunk verifies the one before")
+	}
+}
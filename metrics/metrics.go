@@ -0,0 +1,98 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package metrics counts the work done by NZAAT digests, so services
+// can monitor how much time and volume goes through checksumming.
+//
+// The counters can be published with expvar. Other monitoring systems
+// can read them through the accessors; with Prometheus, for example:
+//
+//	prometheus.MustRegister(prometheus.NewCounterFunc(
+//		prometheus.CounterOpts{Name: "nzaat_hashed_bytes_total"},
+//		func() float64 { return float64(stats.Bytes()) }))
+package metrics
+
+import (
+	"expvar"
+	"hash"
+	"sync/atomic"
+	"time"
+)
+
+// Stats accumulates the counters of all digests wrapped by it. It is
+// safe for concurrent use.
+type Stats struct {
+	bytes atomic.Uint64
+	sums  atomic.Uint64
+	nanos atomic.Int64
+}
+
+// Bytes returns the number of bytes written to the wrapped digests.
+func (s *Stats) Bytes() uint64 {
+	return s.bytes.Load()
+}
+
+// Sums returns the number of checksums computed by the wrapped digests.
+func (s *Stats) Sums() uint64 {
+	return s.sums.Load()
+}
+
+// Duration returns the total time spent writing to the wrapped digests.
+func (s *Stats) Duration() time.Duration {
+	return time.Duration(s.nanos.Load())
+}
+
+// Throughput returns the average number of bytes hashed per second of
+// Duration, or 0 if nothing has been hashed.
+func (s *Stats) Throughput() float64 {
+	var d time.Duration = s.Duration()
+	if d <= 0 {
+		return 0
+	}
+	return float64(s.Bytes()) / d.Seconds()
+}
+
+// Publish exports the counters with expvar as a map under name, with
+// the keys bytes, sums, seconds and bytes_per_second. Like
+// expvar.Publish, it panics if name is already in use.
+func (s *Stats) Publish(name string) {
+	var m *expvar.Map = new(expvar.Map)
+
+	m.Set("bytes", expvar.Func(func() any { return s.Bytes() }))
+	m.Set("sums", expvar.Func(func() any { return s.Sums() }))
+	m.Set("seconds", expvar.Func(func() any { return s.Duration().Seconds() }))
+	m.Set("bytes_per_second", expvar.Func(func() any { return s.Throughput() }))
+	expvar.Publish(name, m)
+}
+
+// Wrap returns a hash.Hash32 behaving like h which counts its work in
+// s. Like h, it is not safe for concurrent use.
+func (s *Stats) Wrap(h hash.Hash32) hash.Hash32 {
+	return &digest{Hash32: h, stats: s}
+}
+
+type digest struct {
+	hash.Hash32
+	stats *Stats
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	var start time.Time = time.Now()
+	n, err := d.Hash32.Write(p)
+
+	d.stats.nanos.Add(int64(time.Since(start)))
+	d.stats.bytes.Add(uint64(n))
+	return n, err
+}
+
+func (d *digest) Sum32() uint32 {
+	d.stats.sums.Add(1)
+	return d.Hash32.Sum32()
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	d.stats.sums.Add(1)
+	return d.Hash32.Sum(b)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"hash"
+	"sync/atomic"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test that the wrapper counts bytes and sums without changing the
+// result.
+func TestWrap(t *testing.T) {
+	var s Stats
+	var h hash.Hash32 = s.Wrap(nzaat.New())
+
+	h.Write([]byte("ab"))
+	h.Write([]byte("c"))
+	if sum := h.Sum32(); sum != 0xc3e39e2d {
+		t.Errorf("Wrapped digest gave %08x", sum)
+	}
+	s.Wrap(nzaat.NewNZAT()).Sum(nil)
+
+	if s.Bytes() != 3 || s.Sums() != 2 {
+		t.Errorf("Counted %d bytes and %d sums", s.Bytes(), s.Sums())
+	}
+}
+
+// publishSeq keeps expvar names unique when the tests are run repeatedly.
+var publishSeq atomic.Int64
+
+// Test publishing the counters with expvar.
+func TestPublish(t *testing.T) {
+	var name string = fmt.Sprintf("nzaat_test_%d", publishSeq.Add(1))
+	var s Stats
+	s.Wrap(nzaat.New()).Write(make([]byte, 1000))
+	s.Publish(name)
+
+	var v map[string]float64
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v["bytes"] != 1000 || v["sums"] != 0 {
+		t.Errorf("Unexpected published values %v", v)
+	}
+	if _, ok := v["bytes_per_second"]; !ok {
+		t.Error("Throughput not published")
+	}
+}
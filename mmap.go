@@ -0,0 +1,41 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"io"
+	"os"
+)
+
+// ChecksumFileMmap returns the NZAAT checksum of the file at path. On
+// platforms supporting it, the file is mapped into memory and hashed in
+// place, avoiding read system calls and copies, which matters for very
+// large files. Elsewhere, and for files which can't be mapped, such as
+// pipes, it falls back to reading the file.
+func ChecksumFileMmap(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	if fi.Mode().IsRegular() && fi.Size() > 0 && int64(int(fi.Size())) == fi.Size() {
+		if sum, ok := checksumMmap(f, int(fi.Size())); ok {
+			return sum, nil
+		}
+	}
+
+	var d Digest
+	if _, err = io.Copy(&d, f); err != nil {
+		return 0, err
+	}
+	return d.Sum32(), nil
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !unix
+
+package nzaat
+
+import "os"
+
+// checksumMmap is not supported on this platform.
+func checksumMmap(f *os.File, size int) (uint32, bool) {
+	return 0, false
+}
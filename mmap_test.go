@@ -0,0 +1,33 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test hashing files of various sizes, including an empty one.
+func TestChecksumFileMmap(t *testing.T) {
+	var dir string = t.TempDir()
+
+	for _, data := range []string{"", "abc", string(make([]byte, 100000))} {
+		var name string = filepath.Join(dir, "f")
+		if err := os.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		sum, err := ChecksumFileMmap(name)
+		if err != nil || sum != Checksum([]byte(data)) {
+			t.Errorf("File of %d bytes: got %08x, %v", len(data), sum, err)
+		}
+	}
+
+	if _, err := ChecksumFileMmap(filepath.Join(dir, "missing")); err == nil {
+		t.Error("No error for a missing file")
+	}
+}
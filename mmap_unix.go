@@ -0,0 +1,27 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build unix
+
+package nzaat
+
+import (
+	"os"
+	"syscall"
+)
+
+// checksumMmap hashes the size bytes of f through a read-only mapping.
+// It reports false if f couldn't be mapped.
+func checksumMmap(f *os.File, size int) (uint32, bool) {
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return 0, false
+	}
+	defer syscall.Munmap(data)
+
+	var d Digest
+	d.Write(data)
+	return d.Sum32(), true
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// NZAAT-MP is a multi-lane variant of NZAAT for large inputs. Since the
+// update of NZAAT depends on the previous state, a single NZAAT can't
+// go faster than one byte after the other; NZAAT-MP spreads the input
+// over L independent lanes, which the CPU, or SIMD units, can update
+// side by side. Its result is defined as follows, independently of how
+// it is computed:
+//
+//	s[j] ← 0 for each lane j < L
+//	for each input octet b at index i: NUP(s[i mod L], b)
+//	h ← 0
+//	NUP(h, b) for each octet b of the input length as 8 octets,
+//	    big endian, then of each s[j] in lane order as 4 octets,
+//	    big endian
+//	result ← NAF(h)
+//
+// NZAAT-MP is not compatible with NZAAT; NZAAT-MP-4 and NZAAT-MP-8 are
+// different functions as well. The lane count must be stored alongside
+// any checksum meant to be verified later.
+
+// Lane counts supported by NZAAT-MP.
+const (
+	MP4 = 4
+	MP8 = 8
+)
+
+type mpDigest struct {
+	lanes []uint32
+	n     uint64
+}
+
+// NewMP returns a new hash.Hash32 computing NZAAT-MP with the given
+// number of lanes, which must be MP4 or MP8.
+func NewMP(lanes int) hash.Hash32 {
+	if lanes != MP4 && lanes != MP8 {
+		panic("nzaat: NZAAT-MP supports 4 or 8 lanes")
+	}
+	return &mpDigest{lanes: make([]uint32, lanes)}
+}
+
+// ChecksumMP returns the NZAAT-MP checksum of data with the given
+// number of lanes, which must be MP4 or MP8.
+func ChecksumMP(data []byte, lanes int) uint32 {
+	var h hash.Hash32 = NewMP(lanes)
+	h.Write(data)
+	return h.Sum32()
+}
+
+func (d *mpDigest) Reset() {
+	clear(d.lanes)
+	d.n = 0
+}
+
+func (d *mpDigest) Size() int {
+	return 4
+}
+
+// BlockSize returns the number of lanes, since writes of multiples of
+// it keep the lanes aligned.
+func (d *mpDigest) BlockSize() int {
+	return len(d.lanes)
+}
+
+func (d *mpDigest) Write(p []byte) (int, error) {
+	var total int = len(p)
+	var l int = len(d.lanes)
+
+	// Finish the block started by an earlier write.
+	for ; len(p) > 0 && d.n%uint64(l) != 0; p = p[1:] {
+		d.lanes[d.n%uint64(l)] = update(d.lanes[d.n%uint64(l)], p[:1])
+		d.n++
+	}
+
+	var blocks int = len(p) / l * l
+	if l == MP4 {
+		writeLanes4((*[4]uint32)(d.lanes), p[:blocks])
+	} else {
+		writeLanes8((*[8]uint32)(d.lanes), p[:blocks])
+	}
+	d.n += uint64(blocks)
+
+	for _, x := range p[blocks:] {
+		d.lanes[d.n%uint64(l)] = update(d.lanes[d.n%uint64(l)], []byte{x})
+		d.n++
+	}
+
+	return total, nil
+}
+
+// writeLanes4 absorbs p, whose length is a multiple of 4, into four
+// lanes starting with lane 0.
+func writeLanes4(s *[4]uint32, p []byte) {
+	var a, b, c, d uint32 = s[0], s[1], s[2], s[3]
+
+	for ; len(p) >= 4; p = p[4:] {
+		a += uint32(p[0]) + 1
+		b += uint32(p[1]) + 1
+		c += uint32(p[2]) + 1
+		d += uint32(p[3]) + 1
+		a += a << 10
+		b += b << 10
+		c += c << 10
+		d += d << 10
+		a ^= a >> 6
+		b ^= b >> 6
+		c ^= c >> 6
+		d ^= d >> 6
+	}
+
+	s[0], s[1], s[2], s[3] = a, b, c, d
+}
+
+// writeLanes8 absorbs p, whose length is a multiple of 8, into eight
+// lanes starting with lane 0.
+func writeLanes8(s *[8]uint32, p []byte) {
+	var a, b, c, d, e, f, g, h uint32 = s[0], s[1], s[2], s[3], s[4], s[5], s[6], s[7]
+
+	for ; len(p) >= 8; p = p[8:] {
+		a += uint32(p[0]) + 1
+		b += uint32(p[1]) + 1
+		c += uint32(p[2]) + 1
+		d += uint32(p[3]) + 1
+		e += uint32(p[4]) + 1
+		f += uint32(p[5]) + 1
+		g += uint32(p[6]) + 1
+		h += uint32(p[7]) + 1
+		a += a << 10
+		b += b << 10
+		c += c << 10
+		d += d << 10
+		e += e << 10
+		f += f << 10
+		g += g << 10
+		h += h << 10
+		a ^= a >> 6
+		b ^= b >> 6
+		c ^= c >> 6
+		d ^= d >> 6
+		e ^= e >> 6
+		f ^= f >> 6
+		g ^= g >> 6
+		h ^= h >> 6
+	}
+
+	s[0], s[1], s[2], s[3], s[4], s[5], s[6], s[7] = a, b, c, d, e, f, g, h
+}
+
+func (d *mpDigest) Sum32() uint32 {
+	var h Digest
+
+	h.WriteUint64(d.n, binary.BigEndian)
+	for _, s := range d.lanes {
+		h.WriteUint32(s, binary.BigEndian)
+	}
+	return h.Sum32()
+}
+
+func (d *mpDigest) Sum(in []byte) []byte {
+	var s uint32 = d.Sum32()
+	return append(in, byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
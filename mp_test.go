@@ -0,0 +1,97 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+)
+
+// mpReference computes NZAAT-MP literally as specified.
+func mpReference(data []byte, lanes int) uint32 {
+	var s []uint32 = make([]uint32, lanes)
+	var h Digest
+	var b [8]byte
+
+	for i, x := range data {
+		s[i%lanes] = updateReference(s[i%lanes], []byte{x})
+	}
+
+	binary.BigEndian.PutUint64(b[:], uint64(len(data)))
+	h.Write(b[:])
+	for _, v := range s {
+		binary.BigEndian.PutUint32(b[:4], v)
+		h.Write(b[:4])
+	}
+	return h.Sum32()
+}
+
+// Test against the specification with streaming writes of odd sizes.
+func TestMP(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(5, 6))
+	var data []byte = make([]byte, 200)
+
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+
+	for _, lanes := range []int{MP4, MP8} {
+		for n := 0; n <= len(data); n += 7 {
+			var want uint32 = mpReference(data[:n], lanes)
+			if got := ChecksumMP(data[:n], lanes); got != want {
+				t.Errorf("MP%d of %d bytes: got %08x, expected %08x", lanes, n, got, want)
+			}
+
+			var h = NewMP(lanes)
+			for p := data[:n]; len(p) > 0; {
+				var k int = min(len(p), 1+rng.IntN(11))
+				h.Write(p[:k])
+				p = p[k:]
+			}
+			if got := h.Sum32(); got != want {
+				t.Errorf("MP%d of %d bytes in pieces: got %08x, expected %08x", lanes, n, got, want)
+			}
+		}
+	}
+}
+
+// Test fixed values, so the definition can't change unnoticed.
+func TestMPGolden(t *testing.T) {
+	for _, c := range []struct {
+		in    string
+		lanes int
+		want  uint32
+	}{
+		{"", MP4, 0x14a5b88b},
+		{"abc", MP4, 0x515ee547},
+		{"message digest", MP8, 0x9ce9e591},
+	} {
+		if got := ChecksumMP([]byte(c.in), c.lanes); got != c.want {
+			t.Errorf("MP%d(%q) = %08x, expected %08x", c.lanes, c.in, got, c.want)
+		}
+	}
+}
+
+// Test that unsupported lane counts panic.
+func TestMPPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewMP(3) did not panic")
+		}
+	}()
+	NewMP(3)
+}
+
+func BenchmarkMP8(b *testing.B) {
+	var buf []byte = make([]byte, 64<<10)
+	var h = NewMP(MP8)
+
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		h.Write(buf)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package multihash encodes NZAAT and NZAT checksums as multihashes,
+// the self-describing digests of the multiformats project used by IPFS
+// and other content addressed systems.
+//
+// A multihash is the varint code of the hash function, the varint
+// length of the digest, and the digest, here the 4 byte big endian
+// checksum. The functions use codes from the range the multicodec table
+// reserves for private use, so they never clash with registered codes.
+//
+// The package implements the format itself and doesn't depend on the
+// multiformats libraries. To make them recognize the codes, register
+// the hashes with them, for example:
+//
+//	multihash.Register(nzmh.NZAAT, func() hash.Hash { return nzaat.New() })
+//	multihash.Register(nzmh.NZAT, func() hash.Hash { return nzaat.NewNZAT() })
+package multihash
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Multihash codes of the checksums, from the private use range
+// 0x300000 to 0x3fffff of the multicodec table.
+const (
+	NZAAT uint64 = 0x300a7a
+	NZAT  uint64 = 0x300a7b
+)
+
+// Size is the length of the digests.
+const Size = 4
+
+var (
+	// ErrUnknownCode is returned for codes other than NZAAT and NZAT.
+	ErrUnknownCode = errors.New("multihash: unknown hash code")
+
+	// ErrInvalid is returned, wrapped, for malformed multihashes.
+	ErrInvalid = errors.New("multihash: invalid multihash")
+)
+
+// Names maps the codes to the names of the hashes, as used in the
+// multicodec table.
+var Names = map[uint64]string{
+	NZAAT: "nzaat",
+	NZAT:  "nzat",
+}
+
+// Encode returns the multihash of digest, computed by the hash with
+// the given code.
+func Encode(code uint64, digest []byte) []byte {
+	var mh []byte = make([]byte, 0, 2*binary.MaxVarintLen64+len(digest))
+	mh = binary.AppendUvarint(mh, code)
+	mh = binary.AppendUvarint(mh, uint64(len(digest)))
+	return append(mh, digest...)
+}
+
+// Sum returns the multihash of data, computed by the hash with the
+// given code.
+func Sum(data []byte, code uint64) ([]byte, error) {
+	var sum uint32
+
+	switch code {
+	case NZAAT:
+		sum = nzaat.Checksum(data)
+	case NZAT:
+		sum = nzaat.ChecksumNZAT(data)
+	default:
+		return nil, fmt.Errorf("%w: %#x", ErrUnknownCode, code)
+	}
+
+	var digest [Size]byte
+	binary.BigEndian.PutUint32(digest[:], sum)
+	return Encode(code, digest[:]), nil
+}
+
+// Decode splits the multihash mh into its code and digest. The digest
+// shares the memory of mh. Codes of other hashes are accepted.
+func Decode(mh []byte) (uint64, []byte, error) {
+	code, n := binary.Uvarint(mh)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("%w: bad code", ErrInvalid)
+	}
+	mh = mh[n:]
+
+	length, n := binary.Uvarint(mh)
+	if n <= 0 || length != uint64(len(mh)-n) {
+		return 0, nil, fmt.Errorf("%w: bad length", ErrInvalid)
+	}
+	return code, mh[n:], nil
+}
+
+// Verify reports whether mh, an NZAAT or NZAT multihash, is the
+// multihash of data.
+func Verify(mh, data []byte) (bool, error) {
+	code, digest, err := Decode(mh)
+	if err != nil {
+		return false, err
+	}
+	if len(digest) != Size {
+		return false, fmt.Errorf("%w: digest of %d bytes", ErrInvalid, len(digest))
+	}
+
+	want, err := Sum(data, code)
+	if err != nil {
+		return false, err
+	}
+	_, wantDigest, _ := Decode(want)
+	return string(digest) == string(wantDigest), nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package multihash
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// Test the encoding of a known checksum.
+func TestSum(t *testing.T) {
+	mh, err := Sum([]byte("abc"), NZAAT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 0x300a7a as a varint, then the length 4 and the checksum.
+	var want []byte = []byte{0xfa, 0x94, 0xc0, 0x01, 0x04, 0xc3, 0xe3, 0x9e, 0x2d}
+	if !bytes.Equal(mh, want) {
+		t.Errorf("Got %x, expected %x", mh, want)
+	}
+
+	code, digest, err := Decode(mh)
+	if err != nil || code != NZAAT || !bytes.Equal(digest, want[5:]) {
+		t.Errorf("Decoded %#x, %x, %v", code, digest, err)
+	}
+
+	if _, err = Sum(nil, 0x12); !errors.Is(err, ErrUnknownCode) {
+		t.Errorf("Expected ErrUnknownCode, got %v", err)
+	}
+}
+
+// Test verification against data and malformed multihashes.
+func TestVerify(t *testing.T) {
+	mh, _ := Sum([]byte("abc"), NZAT)
+
+	if ok, err := Verify(mh, []byte("abc")); !ok || err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+	if ok, err := Verify(mh, []byte("abd")); ok || err != nil {
+		t.Errorf("Verify of other data gave %v, %v", ok, err)
+	}
+	for _, bad := range [][]byte{nil, {0xfa}, mh[:len(mh)-1], append(mh, 0)} {
+		if _, err := Verify(bad, []byte("abc")); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Verify(%x) gave %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Part is the NZAAT checksum and length of one part of an object which
+// was transferred or stored in independently hashed parts.
+type Part struct {
+	Sum  uint32
+	Size int64
+}
+
+// Composer computes the composite checksum of an object from the
+// checksums of its parts, in the manner of S3 multipart ETags. The
+// composite is the NZAAT checksum of, for each part in order, its size
+// as 8 big-endian octets followed by its checksum as 4 big-endian
+// octets. It therefore depends on how the object was split: it is not
+// the checksum of the whole object, and the same object uploaded with
+// a different part size has a different composite. The zero value is
+// ready to use.
+type Composer struct {
+	d     Digest
+	parts int
+	size  int64
+}
+
+// Add appends the next part of the object.
+func (c *Composer) Add(p Part) {
+	var b [12]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(p.Size))
+	binary.BigEndian.PutUint32(b[8:], p.Sum)
+	c.d.Write(b[:])
+	c.parts++
+	c.size += p.Size
+}
+
+// Sum32 returns the composite checksum of the parts added so far.
+func (c *Composer) Sum32() uint32 {
+	return c.d.Sum32()
+}
+
+// Parts returns the number of parts added so far.
+func (c *Composer) Parts() int {
+	return c.parts
+}
+
+// Size returns the total size of the parts added so far.
+func (c *Composer) Size() int64 {
+	return c.size
+}
+
+// String returns the composite checksum formatted by FormatComposite.
+func (c *Composer) String() string {
+	return FormatComposite(c.Sum32(), c.parts)
+}
+
+// Compose returns the composite checksum of an object consisting of
+// parts, in order, as computed by Composer.
+func Compose(parts []Part) uint32 {
+	var c Composer
+	for _, p := range parts {
+		c.Add(p)
+	}
+	return c.Sum32()
+}
+
+// ComposeReader reads an object from r, splits it into parts of
+// partSize bytes, the last of which may be shorter, and returns its
+// composite checksum and number of parts. This lets the receiver of a
+// multipart upload verify the assembled object. It panics if partSize
+// is not positive.
+func ComposeReader(r io.Reader, partSize int64) (uint32, int, error) {
+	if partSize <= 0 {
+		panic("nzaat: part size must be positive")
+	}
+
+	var c Composer
+	for {
+		var d Digest
+		n, err := io.CopyN(&d, r, partSize)
+		if n > 0 {
+			c.Add(Part{Sum: d.Sum32(), Size: n})
+		}
+		if err == io.EOF {
+			return c.Sum32(), c.parts, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+}
+
+// FormatComposite returns a composite checksum and its number of parts
+// in the form "xxxxxxxx-N" used by multipart ETags.
+func FormatComposite(sum uint32, parts int) string {
+	return FormatSum(sum) + "-" + strconv.Itoa(parts)
+}
+
+// ParseComposite parses a composite checksum written by
+// FormatComposite. Errors wrap ErrInvalidSum.
+func ParseComposite(s string) (uint32, int, error) {
+	hex, count, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidSum, s)
+	}
+	sum, err := ParseSum(hex)
+	if err != nil {
+		return 0, 0, err
+	}
+	parts, err := strconv.Atoi(count)
+	if err != nil || parts < 0 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidSum, s)
+	}
+	return sum, parts, nil
+}
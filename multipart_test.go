@@ -0,0 +1,87 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// Test that Compose hashes the sizes and checksums of the parts.
+func TestCompose(t *testing.T) {
+	var parts []Part = []Part{
+		{Sum: Checksum([]byte("hello, ")), Size: 7},
+		{Sum: Checksum([]byte("world")), Size: 5},
+	}
+	var want []byte
+	for _, p := range parts {
+		want = binary.BigEndian.AppendUint64(want, uint64(p.Size))
+		want = binary.BigEndian.AppendUint32(want, p.Sum)
+	}
+
+	if got := Compose(parts); got != Checksum(want) {
+		t.Errorf("Compose: got %08x, expected %08x", got, Checksum(want))
+	}
+	if got := Compose(parts[:1]); got == Compose(parts) {
+		t.Error("Dropping a part does not change the composite")
+	}
+	if got := Compose([]Part{parts[1], parts[0]}); got == Compose(parts) {
+		t.Error("Reordering the parts does not change the composite")
+	}
+
+	var c Composer
+	c.Add(parts[0])
+	c.Add(parts[1])
+	if c.Parts() != 2 || c.Size() != 12 {
+		t.Errorf("Got %d parts of %d bytes, expected 2 parts of 12", c.Parts(), c.Size())
+	}
+	if got, want := c.String(), FormatComposite(Compose(parts), 2); got != want {
+		t.Errorf("String: got %q, expected %q", got, want)
+	}
+}
+
+// Test that ComposeReader splits an object like an uploader would.
+func TestComposeReader(t *testing.T) {
+	var data []byte = bytes.Repeat([]byte("0123456789"), 25)
+
+	for _, size := range []int64{1, 64, 100, 250, 1000} {
+		var parts []Part
+		for off := int64(0); off < int64(len(data)); off += size {
+			var part []byte = data[off:min(off+size, int64(len(data)))]
+			parts = append(parts, Part{Sum: Checksum(part), Size: int64(len(part))})
+		}
+
+		sum, n, err := ComposeReader(bytes.NewReader(data), size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sum != Compose(parts) || n != len(parts) {
+			t.Errorf("Part size %d: got %08x-%d, expected %08x-%d",
+				size, sum, n, Compose(parts), len(parts))
+		}
+	}
+
+	sum, n, err := ComposeReader(bytes.NewReader(nil), 10)
+	if err != nil || sum != Compose(nil) || n != 0 {
+		t.Errorf("Empty object: got %08x-%d, %v", sum, n, err)
+	}
+}
+
+// Test parsing formatted composites.
+func TestParseComposite(t *testing.T) {
+	sum, n, err := ParseComposite(FormatComposite(0xc3e39e2d, 12))
+	if err != nil || sum != 0xc3e39e2d || n != 12 {
+		t.Errorf("Got %08x, %d, %v, expected c3e39e2d, 12", sum, n, err)
+	}
+
+	for _, s := range []string{"", "c3e39e2d", "c3e39e2d-", "c3e39e2d-x", "xyz-1", "c3e39e2d--1"} {
+		if _, _, err := ParseComposite(s); !errors.Is(err, ErrInvalidSum) {
+			t.Errorf("ParseComposite(%q): got %v, expected ErrInvalidSum", s, err)
+		}
+	}
+}
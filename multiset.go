@@ -0,0 +1,69 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "encoding/binary"
+
+// Multiset computes a hash over a multiset of byte strings which does
+// not depend on the order in which the elements are added, so sets and
+// maps can be hashed without sorting them first.
+//
+// Each element is hashed with NZAAT on its own and the results are
+// summed up. The sum and the number of elements are then hashed again
+// to produce the result, so e.g. an empty element is still counted.
+// Since addition can be undone, elements can also be removed again, so
+// the hash of a long-lived set can be maintained as the set changes.
+//
+// The zero Multiset is empty and ready to use.
+type Multiset struct {
+	sum   uint64
+	count uint64
+}
+
+// Add adds elem to the multiset.
+func (m *Multiset) Add(elem []byte) {
+	m.sum += uint64(Checksum(elem))
+	m.count++
+}
+
+// AddString adds the bytes of elem to the multiset.
+func (m *Multiset) AddString(elem string) {
+	var d Digest
+	d.WriteString(elem)
+	m.sum += uint64(d.Sum32())
+	m.count++
+}
+
+// Remove removes elem from the multiset. elem must have been added
+// before; removing an element which is not in the multiset leaves the
+// hash in a state no sequence of additions can produce.
+func (m *Multiset) Remove(elem []byte) {
+	m.sum -= uint64(Checksum(elem))
+	m.count--
+}
+
+// RemoveString removes the bytes of elem from the multiset, like
+// Remove.
+func (m *Multiset) RemoveString(elem string) {
+	var d Digest
+	d.WriteString(elem)
+	m.sum -= uint64(d.Sum32())
+	m.count--
+}
+
+// Len returns the number of elements added to the multiset.
+func (m *Multiset) Len() uint64 {
+	return m.count
+}
+
+// Sum32 returns the hash of the elements added so far.
+func (m *Multiset) Sum32() uint32 {
+	var b [16]byte
+
+	binary.BigEndian.PutUint64(b[:8], m.sum)
+	binary.BigEndian.PutUint64(b[8:], m.count)
+	return Checksum(b[:])
+}
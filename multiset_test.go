@@ -0,0 +1,68 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "testing"
+
+// Test that the order of elements does not matter.
+func TestMultisetOrder(t *testing.T) {
+	var a, b Multiset
+
+	a.Add([]byte("a"))
+	a.Add([]byte("abc"))
+	a.AddString("message digest")
+
+	b.AddString("message digest")
+	b.Add([]byte("a"))
+	b.AddString("abc")
+
+	if a.Sum32() != b.Sum32() {
+		t.Errorf("Order changed the result: %x != %x", a.Sum32(), b.Sum32())
+	}
+}
+
+// Test that duplicates and empty elements are counted.
+func TestMultisetCount(t *testing.T) {
+	var a, b, c Multiset
+
+	a.AddString("a")
+	b.AddString("a")
+	b.AddString("a")
+	c.AddString("a")
+	c.AddString("")
+
+	if a.Sum32() == b.Sum32() {
+		t.Error("Duplicate element was not counted")
+	}
+	if a.Sum32() == c.Sum32() {
+		t.Error("Empty element was not counted")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 elements, got %d", c.Len())
+	}
+}
+
+// Test that removing an element undoes adding it.
+func TestMultisetRemove(t *testing.T) {
+	var a, b Multiset
+
+	a.AddString("a")
+	a.AddString("abc")
+	b.AddString("abc")
+	b.AddString("message digest")
+	b.AddString("a")
+
+	b.Remove([]byte("message digest"))
+	if a.Sum32() != b.Sum32() {
+		t.Error("Removal did not restore the previous hash")
+	}
+
+	b.RemoveString("a")
+	b.RemoveString("abc")
+	if b.Sum32() != new(Multiset).Sum32() || b.Len() != 0 {
+		t.Error("Removing all elements did not give the empty hash")
+	}
+}
@@ -68,58 +68,101 @@ package nzaat
 
 import "hash"
 
-type digest uint32
+// Digest is the running state of an NZAAT computation. The zero value
+// is ready to use, and New returns a *Digest behind hash.Hash32. Like
+// all digests of this package, it is not safe for concurrent use; see
+// NewLocked for sharing one between goroutines.
+type Digest uint32
 
 // New returns a new hash.Hash32 computing the NZAAT checksum.
 func New() hash.Hash32 {
-	d := new(digest)
+	d := new(Digest)
 	d.Reset()
 	return d
 }
 
-func (d *digest) Reset() {
+func (d *Digest) Reset() {
 	*d = 0
 }
 
-func (d *digest) Size() int {
+func (d *Digest) Size() int {
 	return 4
 }
 
-func (d *digest) BlockSize() int {
+func (d *Digest) BlockSize() int {
 	return 1
 }
 
-func (d *digest) Write(p []byte) (nn int, err error) {
-	for _, x := range p {
-		*d += digest(x) + 1
-		*d += *d << 10
-		*d ^= *d >> 6
-	}
-
+func (d *Digest) Write(p []byte) (nn int, err error) {
+	*d = Digest(update(uint32(*d), p))
 	return len(p), nil
 }
 
+// WriteString adds the bytes of s to the running hash without
+// converting s to a byte slice first.
+func (d *Digest) WriteString(s string) (nn int, err error) {
+	*d = Digest(updateString(uint32(*d), s))
+	return len(s), nil
+}
+
 // Count NILs in all parts
-func (d *digest) Sum32() uint32 {
-	var sum uint32 = uint32(*d)
+func (d *Digest) Sum32() uint32 {
+	return naf(uint32(*d))
+}
+
+func (d *Digest) Sum(in []byte) []byte {
+	var s uint32 = d.Sum32()
+	return append(in, byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
 
-	sum += sum << 10
-	sum ^= sum >> 6
-	sum += sum << 3
-	sum ^= sum >> 11
-	sum += sum << 15
+// State returns the raw state of the running hash, before finalization.
+// Passing it to SetState on another Digest continues the computation
+// exactly where d stands, e.g. from a checkpoint.
+func (d *Digest) State() uint32 {
+	return uint32(*d)
+}
 
-	return sum
+// SetState replaces the state of the running hash with s, as returned
+// by State.
+func (d *Digest) SetState(s uint32) {
+	*d = Digest(s)
 }
 
-func (d *digest) Sum(in []byte) []byte {
+// Checksum returns the NZAAT checksum of data. Short inputs, which
+// dominate hash table workloads, are hashed without a loop.
+func Checksum(data []byte) uint32 {
+	if len(data) <= smallSize {
+		return naf(sumSmall(data))
+	}
+	return naf(update(0, data))
+}
+
+// nzatDigest shares the update function with Digest but uses the NZF
+// postprocess function, so its result is never 0.
+type nzatDigest struct {
+	Digest
+}
+
+// NewNZAT returns a new hash.Hash32 computing the NZAT checksum, which
+// never yields 0.
+func NewNZAT() hash.Hash32 {
+	d := new(nzatDigest)
+	d.Reset()
+	return d
+}
+
+// Mix in one more zero octet, then make the one state which would
+// yield 0 collide with the state 1 instead.
+func (d *nzatDigest) Sum32() uint32 {
+	return nzf(uint32(d.Digest))
+}
+
+func (d *nzatDigest) Sum(in []byte) []byte {
 	var s uint32 = d.Sum32()
 	return append(in, byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
 }
 
-// Checksum returns the NZAAT checksum of data.
-func Checksum(data []byte) uint32 {
-	var h hash.Hash32 = New()
-	h.Write(data)
-	return h.Sum32()
+// ChecksumNZAT returns the NZAT checksum of data.
+func ChecksumNZAT(data []byte) uint32 {
+	return nzf(update(0, data))
 }
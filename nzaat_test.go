@@ -66,3 +66,42 @@ func TestStringMessageDigest(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// Test that the NZAT hash of an empty string is not 0.
+func TestNZATEmpty(t *testing.T) {
+	var h hash.Hash32 = NewNZAT()
+	var res uint32 = h.Sum32()
+
+	t.Logf("NZAT(\"\") = %x\n", res)
+
+	if res != 0x48009 {
+		t.Fail()
+	}
+}
+
+// Test that NZAT agrees with NZAAT for a non-zero state.
+func TestNZATStringABC(t *testing.T) {
+	var res uint32 = ChecksumNZAT([]byte("abc"))
+
+	t.Logf("NZAT(\"abc\") = %x\n", res)
+
+	if res != 0xC3E39E2D {
+		t.Fail()
+	}
+}
+
+// Test that a computation can be resumed from its state.
+func TestState(t *testing.T) {
+	var a, b Digest
+
+	a.Write([]byte("message "))
+	b.SetState(a.State())
+	b.Write([]byte("digest"))
+
+	if res, want := b.Sum32(), Checksum([]byte("message digest")); res != want {
+		t.Errorf("Resumed hash returned %08x, expected %08x", res, want)
+	}
+	if res := a.State(); res != update(0, []byte("message ")) {
+		t.Errorf("State returned %08x", res)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package oaat implements Bob Jenkins' classic one-at-a-time hash, the
+// OAAT hash NZAAT is derived from, so data hashed with OAAT can still
+// be verified and compared against NZAAT.
+//
+// In terms of the primitives in the documentation of the nzaat package,
+// OAAT updates with OUP(s,b) for each input octet and finishes with
+// FIN(s), starting from an IV of 0. Importing this package registers
+// it with the nzaat registry under the name "oaat".
+package oaat
+
+import (
+	"hash"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+func init() {
+	nzaat.Register("oaat", New)
+}
+
+type digest uint32
+
+// New returns a new hash.Hash32 computing the OAAT checksum.
+func New() hash.Hash32 {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	*d = 0
+}
+
+func (d *digest) Size() int {
+	return 4
+}
+
+func (d *digest) BlockSize() int {
+	return 1
+}
+
+func (d *digest) Write(p []byte) (nn int, err error) {
+	for _, x := range p {
+		*d += digest(x)
+		*d += *d << 10
+		*d ^= *d >> 6
+	}
+
+	return len(p), nil
+}
+
+func (d *digest) Sum32() uint32 {
+	var sum uint32 = uint32(*d)
+
+	sum += sum << 3
+	sum ^= sum >> 11
+	sum += sum << 15
+
+	return sum
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	var s uint32 = d.Sum32()
+	return append(in, byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
+
+// Checksum returns the OAAT checksum of data.
+func Checksum(data []byte) uint32 {
+	var h hash.Hash32 = New()
+	h.Write(data)
+	return h.Sum32()
+}
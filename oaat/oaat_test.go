@@ -0,0 +1,57 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package oaat
+
+import (
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test the well-known OAAT vectors.
+func TestVectors(t *testing.T) {
+	for s, want := range map[string]uint32{
+		"":  0,
+		"a": 0xca2e9442,
+		"The quick brown fox jumps over the lazy dog": 0x519e91f5,
+	} {
+		if res := Checksum([]byte(s)); res != want {
+			t.Errorf("OAAT(%q) = %x, expected %x", s, res, want)
+		}
+	}
+}
+
+// Test that OAAT differs from NZAAT only in the documented ways.
+func TestParams(t *testing.T) {
+	var p nzaat.Params = nzaat.NZAATParams
+	p.C = 0
+	p.ExtraMix = false
+
+	for _, s := range []string{"", "a", "abc", "\x00\x00"} {
+		if p.Checksum([]byte(s)) != Checksum([]byte(s)) {
+			t.Errorf("Params disagree with OAAT for %q", s)
+		}
+	}
+
+	// Unlike NZAAT, OAAT does not change with leading NUL octets.
+	if Checksum([]byte("\x00\x00a")) != Checksum([]byte("a")) {
+		t.Error("OAAT changed for leading NUL octets")
+	}
+}
+
+// Test the registration with the nzaat registry.
+func TestRegistered(t *testing.T) {
+	fn, ok := nzaat.Get("oaat")
+	if !ok {
+		t.Fatal("oaat is not registered")
+	}
+
+	var h = fn()
+	h.Write([]byte("a"))
+	if h.Sum32() != 0xca2e9442 {
+		t.Errorf("Registered hash returned %x", h.Sum32())
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "hash"
+
+// Params describe a member of the one-at-a-time hash family in terms of
+// the primitives in the package documentation, for protocols which
+// standardized on slightly different constants than NZAAT.
+//
+// For each input octet b, the state s is updated as
+//
+//	s += b + C; s += s << MixShifts[0]; s ^= s >> MixShifts[1]
+//
+// and the result is computed as
+//
+//	MIX(s) if ExtraMix; if NonZero && s == 0 { s = 1 }
+//	s += s << FinShifts[0]; s ^= s >> FinShifts[1]; s += s << FinShifts[2]
+type Params struct {
+	// C is the constant added to the state along with every octet.
+	C uint32
+
+	// IV is the initial state.
+	IV uint32
+
+	// MixShifts are the left and right shift of the MIX primitive.
+	MixShifts [2]uint
+
+	// FinShifts are the shifts of the FIN primitive.
+	FinShifts [3]uint
+
+	// ExtraMix mixes in another zero data octet before finalizing.
+	ExtraMix bool
+
+	// NonZero prevents the result from being 0 by making the state
+	// which would produce it collide with the state 1, like NZAT.
+	NonZero bool
+}
+
+// NZAATParams are the parameters of NZAAT, as computed by New.
+var NZAATParams = Params{
+	C:         1,
+	MixShifts: [2]uint{10, 6},
+	FinShifts: [3]uint{3, 11, 15},
+	ExtraMix:  true,
+}
+
+// NZATParams are the parameters of NZAT, as computed by NewNZAT.
+var NZATParams = Params{
+	C:         1,
+	MixShifts: [2]uint{10, 6},
+	FinShifts: [3]uint{3, 11, 15},
+	ExtraMix:  true,
+	NonZero:   true,
+}
+
+type paramDigest struct {
+	p Params
+	s uint32
+}
+
+// New returns a new hash.Hash32 computing the hash described by p.
+func (p Params) New() hash.Hash32 {
+	var d *paramDigest = &paramDigest{p: p}
+	d.Reset()
+	return d
+}
+
+// Checksum returns the hash of data described by p.
+func (p Params) Checksum(data []byte) uint32 {
+	var h hash.Hash32 = p.New()
+	h.Write(data)
+	return h.Sum32()
+}
+
+func (d *paramDigest) Reset() {
+	d.s = d.p.IV
+}
+
+func (d *paramDigest) Size() int {
+	return 4
+}
+
+func (d *paramDigest) BlockSize() int {
+	return 1
+}
+
+func (d *paramDigest) Write(p []byte) (nn int, err error) {
+	var s uint32 = d.s
+
+	for _, x := range p {
+		s += uint32(x) + d.p.C
+		s += s << d.p.MixShifts[0]
+		s ^= s >> d.p.MixShifts[1]
+	}
+
+	d.s = s
+	return len(p), nil
+}
+
+func (d *paramDigest) Sum32() uint32 {
+	var sum uint32 = d.s
+
+	if d.p.ExtraMix {
+		sum += sum << d.p.MixShifts[0]
+		sum ^= sum >> d.p.MixShifts[1]
+	}
+	if d.p.NonZero && sum == 0 {
+		sum++
+	}
+	sum += sum << d.p.FinShifts[0]
+	sum ^= sum >> d.p.FinShifts[1]
+	sum += sum << d.p.FinShifts[2]
+
+	return sum
+}
+
+func (d *paramDigest) Sum(in []byte) []byte {
+	var s uint32 = d.Sum32()
+	return append(in, byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "testing"
+
+// Test that the predefined parameters match the fixed implementations.
+func TestParamsPredefined(t *testing.T) {
+	for _, s := range []string{"", "a", "abc", "message digest", "\x00\x00\x00"} {
+		if NZAATParams.Checksum([]byte(s)) != Checksum([]byte(s)) {
+			t.Errorf("NZAATParams disagree with NZAAT for %q", s)
+		}
+		if NZATParams.Checksum([]byte(s)) != ChecksumNZAT([]byte(s)) {
+			t.Errorf("NZATParams disagree with NZAT for %q", s)
+		}
+	}
+}
+
+// Test that changing the parameters changes the result.
+func TestParamsVariants(t *testing.T) {
+	var p Params = NZAATParams
+	var base uint32 = p.Checksum([]byte("abc"))
+
+	p.IV = 0x12345678
+	if p.Checksum([]byte("abc")) == base {
+		t.Error("IV did not change the result")
+	}
+
+	p = NZAATParams
+	p.MixShifts = [2]uint{11, 7}
+	if p.Checksum([]byte("abc")) == base {
+		t.Error("MixShifts did not change the result")
+	}
+
+	p = NZAATParams
+	p.FinShifts = [3]uint{4, 12, 16}
+	if p.Checksum([]byte("abc")) == base {
+		t.Error("FinShifts did not change the result")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package partition assigns message keys to partitions, for producers
+// of Kafka and similar partitioned logs.
+//
+// Partition uses NZAAT: a key goes to partition Checksum(key) modulo
+// the number of partitions, with the checksum taken as an unsigned 32
+// bit integer. This definition is fixed, so that producers written in
+// any language, and all future releases of this package, place keys
+// the same way.
+//
+// Murmur2Partition places keys the same way as the default partitioner
+// of the Java Kafka client does for messages with a key, for producers
+// migrating from it.
+package partition
+
+import (
+	"encoding/binary"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+func checkPartitions(numPartitions int) {
+	if numPartitions <= 0 {
+		panic("partition: number of partitions must be positive")
+	}
+}
+
+// Partition returns the partition of key among numPartitions, which
+// must be positive.
+func Partition(key []byte, numPartitions int) int {
+	checkPartitions(numPartitions)
+	return int(nzaat.Checksum(key) % uint32(numPartitions))
+}
+
+// Murmur2 returns the 32 bit MurmurHash2 of data with the seed used by
+// Kafka.
+func Murmur2(data []byte) int32 {
+	const seed = 0x9747b28c
+	const m = 0x5bd1e995
+	const r = 24
+
+	var h uint32 = seed ^ uint32(len(data))
+	var n int = len(data) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		var k uint32 = binary.LittleEndian.Uint32(data[i:])
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	switch len(data) & 3 {
+	case 3:
+		h ^= uint32(data[n+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[n+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[n])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+
+	return int32(h)
+}
+
+// Murmur2Partition returns the partition of key among numPartitions as
+// chosen by the Java Kafka client: the Murmur2 hash with its sign bit
+// cleared, modulo the number of partitions.
+func Murmur2Partition(key []byte, numPartitions int) int {
+	checkPartitions(numPartitions)
+	return int(uint32(Murmur2(key))&0x7fffffff) % numPartitions
+}
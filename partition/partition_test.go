@@ -0,0 +1,52 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package partition
+
+import "testing"
+
+// Test that the NZAAT partition is the checksum modulo the number of
+// partitions.
+func TestPartition(t *testing.T) {
+	// NZAAT("abc") = 0xc3e39e2d = 3286474285.
+	for n, want := range map[int]int{1: 0, 7: 3286474285 % 7, 12: 3286474285 % 12} {
+		if p := Partition([]byte("abc"), n); p != want {
+			t.Errorf("Partition of abc among %d is %d, expected %d", n, p, want)
+		}
+	}
+}
+
+// Test Murmur2 against the values from the tests of the Kafka client.
+func TestMurmur2(t *testing.T) {
+	for in, want := range map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+	} {
+		if h := Murmur2([]byte(in)); h != want {
+			t.Errorf("Murmur2(%q) = %d, expected %d", in, h, want)
+		}
+	}
+}
+
+// Test the Kafka compatible partition.
+func TestMurmur2Partition(t *testing.T) {
+	// -790332482 & 0x7fffffff = 1357151166.
+	if p := Murmur2Partition([]byte("foobar"), 10); p != 1357151166%10 {
+		t.Errorf("Partition of foobar is %d", p)
+	}
+}
+
+// Test that a non-positive number of partitions panics.
+func TestPartitionPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Partition among 0 partitions did not panic")
+		}
+	}()
+	Partition([]byte("abc"), 0)
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+// pickTwoSeeds select the independent hashes of the two choices of
+// PickTwo.
+var pickTwoSeeds = [2]Digest{
+	NewSeed(0x70637431).Digest(),
+	NewSeed(0x70637432).Digest(),
+}
+
+// PickTwo returns two distinct buckets out of n for key, for the power
+// of two choices: a load balancer or scheduler sends the key to the
+// less loaded of the two, which keeps the maximum load far lower than
+// a single hashed choice does. The buckets are derived from two
+// checksums of key with independent fixed seeds, so the same key always
+// yields the same pair. It panics if n is less than 2.
+func PickTwo(key []byte, n int) (int, int) {
+	if n < 2 {
+		panic("nzaat: PickTwo needs at least two buckets")
+	}
+
+	var a int = int(Final(Update(pickTwoSeeds[0], key)) % uint32(n))
+	var b int = int(Final(Update(pickTwoSeeds[1], key)) % uint32(n-1))
+
+	// Choose the second bucket among the remaining n-1 ones.
+	if b >= a {
+		b++
+	}
+	return a, b
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test that the choices are distinct, in range, stable and spread out,
+// and that choosing the less loaded one balances the load.
+func TestPickTwo(t *testing.T) {
+	const n = 10
+	var pairs [n][n]int
+	var load [n]int
+
+	for i := 0; i < 100000; i++ {
+		var key []byte = []byte("key-" + strconv.Itoa(i))
+		a, b := PickTwo(key, n)
+		if a == b || a < 0 || a >= n || b < 0 || b >= n {
+			t.Fatalf("PickTwo(%q) returned %d, %d", key, a, b)
+		}
+		if c, d := PickTwo(key, n); c != a || d != b {
+			t.Fatalf("PickTwo(%q) is not stable", key)
+		}
+		pairs[a][b]++
+		if load[b] < load[a] {
+			a = b
+		}
+		load[a]++
+	}
+
+	for a := range pairs {
+		for b := range pairs[a] {
+			if a != b && (pairs[a][b] < 800 || pairs[a][b] > 1450) {
+				t.Errorf("Pair (%d, %d) chosen %d times", a, b, pairs[a][b])
+			}
+		}
+	}
+	for i, l := range load {
+		if l < 9990 || l > 10010 {
+			t.Errorf("Bucket %d has a load of %d", i, l)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "sync"
+
+var digestPool = sync.Pool{
+	New: func() any { return new(Digest) },
+}
+
+// GetDigest returns a reset Digest from a pool shared by the package.
+// Return it with PutDigest when done, so servers hashing on every
+// request don't allocate a new one each time.
+func GetDigest() *Digest {
+	return digestPool.Get().(*Digest)
+}
+
+// PutDigest resets d and returns it to the pool. d must not be used
+// afterwards.
+func PutDigest(d *Digest) {
+	d.Reset()
+	digestPool.Put(d)
+}
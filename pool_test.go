@@ -0,0 +1,37 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "testing"
+
+// Test that pooled digests come back reset.
+func TestPool(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var d *Digest = GetDigest()
+		if *d != 0 {
+			t.Fatalf("Got a digest in state %08x", uint32(*d))
+		}
+		d.WriteString("abc")
+		if sum := d.Sum32(); sum != 0xc3e39e2d {
+			t.Errorf("Pooled digest gave %08x", sum)
+		}
+		PutDigest(d)
+	}
+}
+
+// Test that reusing a pooled digest doesn't allocate.
+func TestPoolNoAlloc(t *testing.T) {
+	PutDigest(GetDigest())
+
+	if n := testing.AllocsPerRun(100, func() {
+		var d *Digest = GetDigest()
+		d.WriteString("key")
+		d.Sum32()
+		PutDigest(d)
+	}); n != 0 {
+		t.Errorf("Pooled hashing allocated %v times", n)
+	}
+}
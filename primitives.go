@@ -0,0 +1,31 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+// UpdateByte returns the state s after absorbing the octet b. This is
+// the NUP primitive described at the top of nzaat.go, the body of the
+// loop in Write.
+func UpdateByte(s uint32, b byte) uint32 {
+	return nup(s, b)
+}
+
+// Mix applies the MIX primitive, which is shared between the update
+// functions of NZAAT and the one-at-a-time hash, to s.
+func Mix(s uint32) uint32 {
+	s += s << 10
+	s ^= s >> 6
+	return s
+}
+
+// Fin applies the FIN primitive, the postprocess function of the
+// one-at-a-time hash, to s. Fin(Mix(s)) is the NZAAT checksum of the
+// input which led to the state s.
+func Fin(s uint32) uint32 {
+	s += s << 3
+	s ^= s >> 11
+	s += s << 15
+	return s
+}
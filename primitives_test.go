@@ -0,0 +1,26 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "testing"
+
+// Test that the primitives compose to the checksums.
+func TestPrimitives(t *testing.T) {
+	var s uint32
+
+	for _, b := range []byte("abc") {
+		s = UpdateByte(s, b)
+	}
+	if res := Fin(Mix(s)); res != 0xc3e39e2d {
+		t.Errorf("Fin(Mix(s)) returned %08x, expected c3e39e2d", res)
+	}
+	if res := Fin(Mix(0)); res != 0 {
+		t.Errorf("Fin(Mix(0)) returned %08x", res)
+	}
+	if res := Fin(1); res != ChecksumNZAT(nil) {
+		t.Errorf("Fin(1) returned %08x, expected the NZAT checksum of nothing", res)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+// Update returns the running state d after absorbing p. Unlike Write it
+// takes and returns the state by value, so together with Final a whole
+// checksum is computed without heap allocations or interfaces, as on
+// TinyGo microcontroller targets:
+//
+//	var d nzaat.Digest
+//	d = nzaat.Update(d, header[:])
+//	d = nzaat.Update(d, payload)
+//	sum := nzaat.Final(d)
+//
+// Builds with the tinygo or purego tag use the portable Go
+// implementation only.
+func Update(d Digest, p []byte) Digest {
+	return Digest(update(uint32(d), p))
+}
+
+// UpdateString is like Update for a string.
+func UpdateString(d Digest, s string) Digest {
+	return Digest(updateString(uint32(d), s))
+}
+
+// Final returns the NZAAT checksum of the input absorbed into d. It is
+// the same as d.Sum32().
+func Final(d Digest) uint32 {
+	return naf(uint32(d))
+}
+
+// FinalNZAT returns the NZAT checksum, which is never 0, of the input
+// absorbed into d.
+func FinalNZAT(d Digest) uint32 {
+	return nzf(uint32(d))
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "testing"
+
+// Test that the value functions agree with the hash.Hash32 interface.
+func TestUpdateFinal(t *testing.T) {
+	var d Digest = Update(0, []byte("message "))
+	d = UpdateString(d, "digest")
+
+	if got, want := Final(d), ChecksumString("message digest"); got != want {
+		t.Errorf("Final: got %08x, expected %08x", got, want)
+	}
+	if got, want := FinalNZAT(d), ChecksumNZAT([]byte("message digest")); got != want {
+		t.Errorf("FinalNZAT: got %08x, expected %08x", got, want)
+	}
+	if got := FinalNZAT(0); got != 0x48009 {
+		t.Errorf("FinalNZAT(0): got %08x, expected 00048009", got)
+	}
+}
+
+// Test that a complete computation over buffers on the stack, as a
+// microcontroller would checksum a frame, does not allocate.
+func TestNoAllocs(t *testing.T) {
+	var tests = map[string]func(){
+		"Update": func() {
+			var frame [64]byte
+			updateSink = Final(Update(0, frame[:]))
+		},
+		"Write": func() {
+			var frame [64]byte
+			var d Digest
+			d.Write(frame[:])
+			updateSink = d.Sum32()
+		},
+		"Checksum": func() {
+			var frame [64]byte
+			updateSink = Checksum(frame[:])
+		},
+		"ChecksumNZAT": func() {
+			var frame [64]byte
+			updateSink = ChecksumNZAT(frame[:])
+		},
+		"ChecksumBatch4": func() {
+			var frames [4][64]byte
+			var sums [4]uint32
+			checksum4([][]byte{frames[0][:], frames[1][:], frames[2][:], frames[3][:]}, sums[:])
+			updateSink = sums[0]
+		},
+	}
+
+	for name, f := range tests {
+		if n := testing.AllocsPerRun(100, f); n != 0 {
+			t.Errorf("%s allocates %v times", name, n)
+		}
+	}
+}
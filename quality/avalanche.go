@@ -0,0 +1,143 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Band classifies a flip probability like the colours of Bob Jenkins'
+// avalanche charts.
+type Band int
+
+const (
+	// Green means the output bit flips between 1/3 and 2/3 of the time.
+	Green Band = iota
+
+	// Yellow means the output bit flips between 1/6 and 5/6 of the
+	// time, but is not Green.
+	Yellow
+
+	// Red means the output bit flips less than 1/6 or more than 5/6
+	// of the time.
+	Red
+)
+
+func (b Band) String() string {
+	switch b {
+	case Green:
+		return "green"
+	case Yellow:
+		return "yellow"
+	default:
+		return "red"
+	}
+}
+
+// Matrix holds the results of an avalanche analysis.
+type Matrix struct {
+	// P[i][o] is the probability that output bit o changes when input
+	// bit i is flipped. Input bits are numbered from the least
+	// significant bit of the first octet, output bits from the least
+	// significant bit of the hash.
+	P [][]float64 `json:"p"`
+
+	// Samples is the number of random keys the probabilities were
+	// measured over.
+	Samples int `json:"samples"`
+
+	// WorstBias is the largest deviation of any probability from 1/2,
+	// scaled to the range 0 to 1.
+	WorstBias float64 `json:"worst_bias"`
+
+	// RMSBias is the root mean square of the scaled deviations of all
+	// probabilities from 1/2. It summarizes the whole matrix in a
+	// single score for comparing variants; lower is better, and an
+	// ideal hash approaches 0 as the number of samples grows.
+	RMSBias float64 `json:"rms_bias"`
+}
+
+// Band returns the band of the flip probability of output bit out when
+// input bit in is flipped.
+func (m *Matrix) Band(in, out int) Band {
+	var bias float64 = math.Abs(2*m.P[in][out] - 1)
+
+	switch {
+	case bias <= 1.0/3:
+		return Green
+	case bias <= 2.0/3:
+		return Yellow
+	default:
+		return Red
+	}
+}
+
+// Worst returns the worst band of all entries of the matrix.
+func (m *Matrix) Worst() Band {
+	var worst Band = Green
+
+	for in := range m.P {
+		for out := range m.P[in] {
+			if b := m.Band(in, out); b > worst {
+				worst = b
+			}
+		}
+	}
+	return worst
+}
+
+// AvalancheMatrix measures, for each bit of random keys of inputLen
+// octets, how often each output bit of h changes when it is flipped.
+// It uses the default Options; see Avalanche for a pass/fail test.
+func AvalancheMatrix(h func([]byte) uint32, inputLen int) *Matrix {
+	return avalancheMatrix(h, inputLen, Options{})
+}
+
+func avalancheMatrix(h HashFunc, inputLen int, opts Options) *Matrix {
+	var rnd *rand.Rand = opts.rand()
+	var samples int = opts.samples()
+	var flips []int = make([]int, inputLen*8*32)
+	var key []byte = make([]byte, inputLen)
+
+	for s := 0; s < samples; s++ {
+		for i := range key {
+			key[i] = byte(rnd.Uint32())
+		}
+		var base uint32 = h(key)
+
+		for bit := 0; bit < inputLen*8; bit++ {
+			key[bit/8] ^= 1 << (bit % 8)
+			var diff uint32 = base ^ h(key)
+			key[bit/8] ^= 1 << (bit % 8)
+
+			for out := 0; out < 32; out++ {
+				flips[bit*32+out] += int(diff>>out) & 1
+			}
+		}
+	}
+
+	var m *Matrix = &Matrix{
+		P:       make([][]float64, inputLen*8),
+		Samples: samples,
+	}
+	var sumSq float64
+
+	for in := range m.P {
+		m.P[in] = make([]float64, 32)
+		for out := range m.P[in] {
+			var p float64 = float64(flips[in*32+out]) / float64(samples)
+			var bias float64 = math.Abs(2*p - 1)
+
+			m.P[in][out] = p
+			m.WorstBias = math.Max(m.WorstBias, bias)
+			sumSq += bias * bias
+		}
+	}
+	m.RMSBias = math.Sqrt(sumSq / float64(len(flips)))
+
+	return m
+}
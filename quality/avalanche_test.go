@@ -0,0 +1,44 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+	"github.com/caoimhechaos/golang-nzaat/oaat"
+)
+
+// Test the claim of the package documentation of nzaat: OAAT is all
+// green except for its last input octet, while NZAAT is all green.
+func TestAvalancheMatrixClaim(t *testing.T) {
+	var nz *Matrix = AvalancheMatrix(nzaat.Checksum, 4)
+	var oa *Matrix = AvalancheMatrix(oaat.Checksum, 4)
+
+	if nz.Worst() != Green {
+		t.Errorf("NZAAT is not all green, worst bias %v", nz.WorstBias)
+	}
+
+	for in := range oa.P {
+		for out := range oa.P[in] {
+			if oa.Band(in, out) != Green && in < 24 {
+				t.Errorf("OAAT input bit %d is %v for output bit %d",
+					in, oa.Band(in, out), out)
+			}
+		}
+	}
+	if oa.Worst() == Green {
+		t.Error("OAAT is all green")
+	}
+
+	if nz.RMSBias >= oa.RMSBias {
+		t.Errorf("NZAAT RMS bias %v is not better than OAAT's %v",
+			nz.RMSBias, oa.RMSBias)
+	}
+	if len(nz.P) != 32 || len(nz.P[0]) != 32 || nz.Samples != 10000 {
+		t.Errorf("Unexpected matrix shape")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+)
+
+// BIC holds the results of a bit independence criterion analysis:
+// whether pairs of output bits change independently of each other when
+// a single input bit is flipped.
+type BIC struct {
+	// Correlation[j][k] is the largest absolute correlation between
+	// changes of output bits j and k found for any flipped input bit.
+	// The matrix is symmetric, and its diagonal is 0.
+	Correlation [][]float64 `json:"correlation"`
+
+	// Samples is the number of random keys the correlations were
+	// measured over.
+	Samples int `json:"samples"`
+
+	// WorstCorrelation is the largest entry of Correlation.
+	WorstCorrelation float64 `json:"worst_correlation"`
+
+	// WorstInput and WorstOutputs are the input bit and the pair of
+	// output bits for which WorstCorrelation was found.
+	WorstInput   int    `json:"worst_input"`
+	WorstOutputs [2]int `json:"worst_outputs"`
+}
+
+// BitIndependence flips each bit of random keys of inputLen octets and
+// measures the correlation (phi coefficient) between the changes of all
+// pairs of output bits of h. An ideal hash has no correlation, apart
+// from sampling noise.
+func BitIndependence(h HashFunc, inputLen int, opts Options) *BIC {
+	var rnd *rand.Rand = opts.rand()
+	var samples int = opts.samples()
+	var key []byte = make([]byte, inputLen)
+	var keys []byte = make([]byte, samples*inputLen)
+	var b *BIC = &BIC{
+		Correlation: make([][]float64, 32),
+		Samples:     samples,
+	}
+
+	for j := range b.Correlation {
+		b.Correlation[j] = make([]float64, 32)
+	}
+	for i := range keys {
+		keys[i] = byte(rnd.Uint32())
+	}
+
+	// Process one input bit at a time, so only the counts for that
+	// bit need to be kept.
+	for bit := 0; bit < inputLen*8; bit++ {
+		var ones [32]int
+		var both [32][32]int
+
+		for s := 0; s < samples; s++ {
+			copy(key, keys[s*inputLen:])
+			var base uint32 = h(key)
+			key[bit/8] ^= 1 << (bit % 8)
+			var diff uint32 = base ^ h(key)
+
+			for j := 0; j < 32; j++ {
+				if diff>>j&1 == 0 {
+					continue
+				}
+				ones[j]++
+				for k := j + 1; k < 32; k++ {
+					both[j][k] += int(diff>>k) & 1
+				}
+			}
+		}
+
+		var n float64 = float64(samples)
+		for j := 0; j < 32; j++ {
+			for k := j + 1; k < 32; k++ {
+				var nj, nk float64 = float64(ones[j]), float64(ones[k])
+				var den float64 = math.Sqrt(nj * (n - nj) * nk * (n - nk))
+				var phi float64 = 1
+
+				if den > 0 {
+					phi = math.Abs(float64(both[j][k])*n-nj*nk) / den
+				}
+				if phi > b.Correlation[j][k] {
+					b.Correlation[j][k] = phi
+					b.Correlation[k][j] = phi
+				}
+				if phi > b.WorstCorrelation {
+					b.WorstCorrelation = phi
+					b.WorstInput = bit
+					b.WorstOutputs = [2]int{j, k}
+				}
+			}
+		}
+	}
+
+	return b
+}
+
+// BitIndependenceTest runs BitIndependence and checks that no pair of
+// output bits is correlated by more than opts.MaxBias allows.
+func BitIndependenceTest(h HashFunc, inputLen int, opts Options) Result {
+	var b *BIC = BitIndependence(h, inputLen, opts)
+
+	// Allow for sampling noise of up to six standard deviations.
+	var limit float64 = math.Max(opts.maxBias(), 6/math.Sqrt(float64(b.Samples)))
+	return Result{
+		Name:     "bic/" + strconv.Itoa(inputLen),
+		Passed:   b.WorstCorrelation <= limit,
+		Score:    b.WorstCorrelation,
+		Expected: 0,
+		Keys:     b.Samples,
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Test that NZAAT output bits change mostly independently.
+func TestBitIndependenceNZAAT(t *testing.T) {
+	var b *BIC = BitIndependence(nzaat.Checksum, 4, Options{Samples: 2000})
+
+	t.Logf("Worst correlation %v for input bit %d, output bits %v",
+		b.WorstCorrelation, b.WorstInput, b.WorstOutputs)
+	if res := BitIndependenceTest(nzaat.Checksum, 4, Options{Samples: 2000}); !res.Passed {
+		t.Errorf("NZAAT failed the BIC test: %+v", res)
+	}
+	if b.Correlation[3][3] != 0 || b.Correlation[3][5] != b.Correlation[5][3] {
+		t.Error("Correlation matrix is malformed")
+	}
+}
+
+// Test that a hash with dependent output bits is caught.
+func TestBitIndependenceBad(t *testing.T) {
+	var dup HashFunc = func(b []byte) uint32 {
+		var s uint32 = nzaat.Checksum(b) & 0xffff
+		return s | s<<16
+	}
+	var b *BIC = BitIndependence(dup, 4, Options{Samples: 500})
+
+	if b.WorstCorrelation < 0.99 || b.WorstOutputs[1]-b.WorstOutputs[0] != 16 {
+		t.Errorf("Duplicated bits were not found: %v for %v",
+			b.WorstCorrelation, b.WorstOutputs)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"fmt"
+	"math"
+)
+
+// BucketStats describes how evenly a set of keys was distributed over
+// a number of buckets.
+type BucketStats struct {
+	// Buckets is the number of buckets the keys were distributed over
+	// by taking the hash modulo Buckets.
+	Buckets int `json:"buckets"`
+
+	// Keys is the number of keys distributed.
+	Keys int `json:"keys"`
+
+	// ChiSquared is Pearson's chi-squared statistic of the bucket
+	// loads against a uniform distribution.
+	ChiSquared float64 `json:"chi_squared"`
+
+	// Z is ChiSquared transformed into a standard normal variable by
+	// the Wilson-Hilferty approximation. Values far above 0 indicate
+	// clustering, values far below 0 suspiciously even loads.
+	Z float64 `json:"z"`
+
+	// MaxLoad is the number of keys in the fullest bucket.
+	MaxLoad int `json:"max_load"`
+
+	// Skew is MaxLoad divided by the expected load of a bucket.
+	Skew float64 `json:"skew"`
+
+	// Passed is true if Z is within ±4, i.e. the distribution is what
+	// a random function would produce.
+	Passed bool `json:"passed"`
+}
+
+// Distribution hashes keys with h, distributes them over each of the
+// given numbers of buckets and reports how evenly they were spread, so
+// operators can check the hash against their actual key sets. Duplicate
+// keys should be removed from keys first. It returns an error if any
+// of the bucket counts is not positive.
+func Distribution(h HashFunc, keys [][]byte, buckets []int) ([]BucketStats, error) {
+	var hashes []uint32 = make([]uint32, len(keys))
+	var stats []BucketStats = make([]BucketStats, 0, len(buckets))
+
+	for _, n := range buckets {
+		if n <= 0 {
+			return nil, fmt.Errorf("quality: invalid number of buckets %d", n)
+		}
+	}
+
+	for i, k := range keys {
+		hashes[i] = h(k)
+	}
+
+	for _, n := range buckets {
+		stats = append(stats, bucketStats(hashes, n))
+	}
+	return stats, nil
+}
+
+func bucketStats(hashes []uint32, n int) BucketStats {
+	var loads []int = make([]int, n)
+	var s BucketStats = BucketStats{Buckets: n, Keys: len(hashes)}
+
+	// Without keys there is nothing to measure, and the statistics
+	// would all divide by zero.
+	if len(hashes) == 0 {
+		return s
+	}
+
+	for _, h := range hashes {
+		loads[h%uint32(n)]++
+	}
+
+	var expected float64 = float64(len(hashes)) / float64(n)
+	for _, l := range loads {
+		var d float64 = float64(l) - expected
+		s.ChiSquared += d * d / expected
+		if l > s.MaxLoad {
+			s.MaxLoad = l
+		}
+	}
+	s.Skew = float64(s.MaxLoad) / expected
+
+	// Wilson-Hilferty: (X/k)^(1/3) is approximately normal with mean
+	// 1 - 2/(9k) and variance 2/(9k) for k degrees of freedom.
+	var k float64 = float64(n - 1)
+	if k > 0 {
+		var v float64 = 2 / (9 * k)
+		s.Z = (math.Cbrt(s.ChiSquared/k) - (1 - v)) / math.Sqrt(v)
+	}
+	s.Passed = math.Abs(s.Z) <= 4
+
+	return s
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+func hostnames(n int) [][]byte {
+	var keys [][]byte = make([][]byte, n)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("web%d.example.com", i))
+	}
+	return keys
+}
+
+// Test that NZAAT spreads similar keys evenly.
+func TestDistributionNZAAT(t *testing.T) {
+	stats, err := Distribution(nzaat.Checksum, hostnames(50000), []int{7, 64, 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range stats {
+		t.Logf("%+v", s)
+		if !s.Passed || s.Keys != 50000 {
+			t.Errorf("NZAAT failed for %d buckets: %+v", s.Buckets, s)
+		}
+	}
+}
+
+// Test that a bad hash is detected.
+func TestDistributionBad(t *testing.T) {
+	var length HashFunc = func(b []byte) uint32 { return uint32(len(b)) }
+
+	s, err := Distribution(length, hostnames(1000), []int{64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s[0].Passed || s[0].Skew < 10 {
+		t.Errorf("Hashing by length passed: %+v", s[0])
+	}
+}
+
+// Test that bucket counts which are not positive are rejected.
+func TestDistributionInvalidBuckets(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if _, err := Distribution(nzaat.Checksum, hostnames(10), []int{64, n}); err == nil {
+			t.Errorf("%d buckets were accepted", n)
+		}
+	}
+}
+
+// Test that an empty key set yields zeroed statistics which can be
+// marshalled.
+func TestDistributionNoKeys(t *testing.T) {
+	s, err := Distribution(nzaat.Checksum, nil, []int{64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s[0] != (BucketStats{Buckets: 64}) {
+		t.Errorf("Statistics without keys: %+v", s[0])
+	}
+	if _, err = json.Marshal(s); err != nil {
+		t.Errorf("Marshalling statistics without keys: %v", err)
+	}
+}
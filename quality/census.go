@@ -0,0 +1,102 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"bufio"
+	"io"
+	"sort"
+)
+
+// Collision lists distinct keys which share a hash value.
+type Collision struct {
+	Hash uint32   `json:"hash"`
+	Keys []string `json:"keys"`
+}
+
+// Census describes the collisions found in a corpus of keys.
+type Census struct {
+	// Keys is the number of distinct keys in the corpus.
+	Keys int `json:"keys"`
+
+	// Duplicates is the number of keys which were seen more than once
+	// and thus ignored.
+	Duplicates int `json:"duplicates"`
+
+	// CollidingPairs is the number of pairs of distinct keys with the
+	// same hash value.
+	CollidingPairs int `json:"colliding_pairs"`
+
+	// Expected is the number of colliding pairs a random 32-bit
+	// function would produce for Keys keys, n(n-1)/2³³.
+	Expected float64 `json:"expected"`
+
+	// Passed is true if CollidingPairs is not significantly above
+	// Expected.
+	Passed bool `json:"passed"`
+
+	// Collisions lists the colliding keys, ordered by hash value.
+	Collisions []Collision `json:"collisions"`
+}
+
+// CollisionCensus hashes all keys produced by the iterator keys with h
+// and reports the collisions among them. Identical keys are only
+// counted once. All distinct keys are kept in memory.
+func CollisionCensus(h HashFunc, keys func(yield func([]byte) bool)) *Census {
+	var byHash map[uint32][]string = make(map[uint32][]string)
+	var c *Census = new(Census)
+
+	keys(func(k []byte) bool {
+		var sum uint32 = h(k)
+		for _, other := range byHash[sum] {
+			if other == string(k) {
+				c.Duplicates++
+				return true
+			}
+		}
+		byHash[sum] = append(byHash[sum], string(k))
+		c.Keys++
+		return true
+	})
+
+	for sum, ks := range byHash {
+		if len(ks) > 1 {
+			c.CollidingPairs += len(ks) * (len(ks) - 1) / 2
+			c.Collisions = append(c.Collisions, Collision{Hash: sum, Keys: ks})
+		}
+	}
+	sort.Slice(c.Collisions, func(i, j int) bool {
+		return c.Collisions[i].Hash < c.Collisions[j].Hash
+	})
+
+	c.evaluate()
+	return c
+}
+
+// evaluate sets Expected and Passed from the number of keys and
+// colliding pairs.
+func (c *Census) evaluate() {
+	var n float64 = float64(c.Keys)
+	c.Expected = n * (n - 1) / 2 / (1 << 32)
+	c.Passed = c.CollidingPairs <= poissonLimit(c.Expected)
+}
+
+// CensusLines runs CollisionCensus over the lines of r, such as a word
+// list or a file with one key per line. Line endings are not part of
+// the keys.
+func CensusLines(h HashFunc, r io.Reader) (*Census, error) {
+	var s *bufio.Scanner = bufio.NewScanner(r)
+
+	s.Buffer(nil, 1<<20)
+	var c *Census = CollisionCensus(h, func(yield func([]byte) bool) {
+		for s.Scan() && yield(s.Bytes()) {
+		}
+	})
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
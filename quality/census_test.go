@@ -0,0 +1,69 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+	"github.com/caoimhechaos/golang-nzaat/oaat"
+)
+
+// Test a census over a large corpus of random keys.
+func TestCollisionCensusNZAAT(t *testing.T) {
+	var rnd *rand.Rand = Options{Seed: 1}.rand()
+	var c *Census = CollisionCensus(nzaat.Checksum, func(yield func([]byte) bool) {
+		var key []byte = make([]byte, 16)
+		for i := 0; i < 200000; i++ {
+			for j := range key {
+				key[j] = byte(rnd.Uint32())
+			}
+			if !yield(key) {
+				return
+			}
+		}
+	})
+
+	t.Logf("%d keys, %d colliding pairs, %v expected", c.Keys, c.CollidingPairs, c.Expected)
+	if !c.Passed || c.Keys != 200000 {
+		t.Errorf("NZAAT failed the census: %+v", c)
+	}
+}
+
+// Test that collisions and duplicates are reported.
+func TestCensusLines(t *testing.T) {
+	c, err := CensusLines(oaat.Checksum, strings.NewReader("a\n\x00a\nb\na\n\x00\x00a\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Keys != 4 || c.Duplicates != 1 || c.CollidingPairs != 3 || c.Passed {
+		t.Errorf("Unexpected census %+v", c)
+	}
+	if len(c.Collisions) != 1 || len(c.Collisions[0].Keys) != 3 ||
+		c.Collisions[0].Hash != oaat.Checksum([]byte("a")) {
+		t.Errorf("Unexpected collisions %+v", c.Collisions)
+	}
+}
+
+// Test evaluating a corpus of tens of millions of keys, where the
+// expected number of collisions is far beyond the exact Poisson limit.
+func TestCensusLargeCorpus(t *testing.T) {
+	var c *Census = &Census{Keys: 30000000, CollidingPairs: 104900}
+	c.evaluate()
+
+	if c.Expected < 104700 || c.Expected > 104800 || !c.Passed {
+		t.Errorf("Unexpected evaluation %+v", c)
+	}
+
+	c.CollidingPairs = 106000
+	c.evaluate()
+	if c.Passed {
+		t.Errorf("%d colliding pairs passed with %v expected", c.CollidingPairs, c.Expected)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package quality runs statistical quality tests in the style of
+// SMHasher against 32-bit hash functions, so that claims about the
+// behaviour of NZAAT and its relatives can be checked mechanically.
+//
+// All tests are deterministic for a given Options.Seed, and their
+// results are collected in a Report which can be serialized as JSON.
+package quality
+
+import (
+	"hash"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+)
+
+// HashFunc is a 32-bit hash function under test.
+type HashFunc func([]byte) uint32
+
+// FromHash32 returns a HashFunc computing the hash of a fresh instance
+// of the hash.Hash32 returned by newHash.
+func FromHash32(newHash func() hash.Hash32) HashFunc {
+	return func(b []byte) uint32 {
+		var h hash.Hash32 = newHash()
+		h.Write(b)
+		return h.Sum32()
+	}
+}
+
+// Options control the amount of work done by the tests.
+type Options struct {
+	// Seed seeds the pseudo-random generation of test keys.
+	Seed uint64
+
+	// Samples is the number of random keys used by the avalanche and
+	// differential tests. Defaults to 10000.
+	Samples int
+
+	// MaxBias is the worst avalanche bias allowed to pass. Defaults to
+	// 1/3, which corresponds to the "green" band of Bob Jenkins'
+	// avalanche charts: every output bit flips between 1/3 and 2/3 of
+	// the time. SMHasher uses the much stricter 0.01.
+	MaxBias float64
+}
+
+func (o Options) samples() int {
+	if o.Samples <= 0 {
+		return 10000
+	}
+	return o.Samples
+}
+
+func (o Options) maxBias() float64 {
+	if o.MaxBias <= 0 {
+		return 1.0 / 3
+	}
+	return o.MaxBias
+}
+
+func (o Options) rand() *rand.Rand {
+	return rand.New(rand.NewPCG(o.Seed, 0x6e7a616174))
+}
+
+// Result is the outcome of a single test.
+type Result struct {
+	// Name identifies the test.
+	Name string `json:"name"`
+
+	// Passed is true if the hash behaved as expected from a random
+	// function.
+	Passed bool `json:"passed"`
+
+	// Score is the measured value, whose meaning depends on the test:
+	// the worst bias for avalanche tests, and the number of colliding
+	// pairs for collision tests.
+	Score float64 `json:"score"`
+
+	// Expected is the value of Score expected from a random function.
+	Expected float64 `json:"expected"`
+
+	// Keys is the number of keys hashed.
+	Keys int `json:"keys"`
+}
+
+// Report collects the results of all tests run against one hash.
+type Report struct {
+	Hash    string   `json:"hash"`
+	Results []Result `json:"results"`
+}
+
+// Passed reports whether all tests of the report were passed.
+func (r Report) Passed() bool {
+	for _, res := range r.Results {
+		if !res.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Run runs all tests of this package against h, which is called name
+// in the report.
+func Run(name string, h HashFunc, opts Options) Report {
+	var r Report = Report{Hash: name}
+
+	for _, n := range []int{4, 8, 16} {
+		r.Results = append(r.Results, Avalanche(h, n, opts))
+	}
+	r.Results = append(r.Results, BitIndependenceTest(h, 4, opts))
+	r.Results = append(r.Results,
+		Differential(h, opts),
+		SparseKeys(h, 4, 3),
+		SparseKeys(h, 8, 3),
+		CyclicKeys(h, 4, 8, opts),
+		ZeroRuns(h, 4096),
+	)
+
+	return r
+}
+
+// Avalanche flips every bit of random keys of inputLen octets and
+// checks that each output bit changes with a probability close to 1/2.
+// The score is the worst bias of the AvalancheMatrix; it must not
+// exceed opts.MaxBias.
+func Avalanche(h HashFunc, inputLen int, opts Options) Result {
+	var m *Matrix = avalancheMatrix(h, inputLen, opts)
+
+	// Allow for sampling noise of up to six standard deviations of
+	// the binomial distribution.
+	var limit float64 = math.Max(opts.maxBias(), 6/math.Sqrt(float64(m.Samples)))
+	return Result{
+		Name:     "avalanche/" + strconv.Itoa(inputLen),
+		Passed:   m.WorstBias <= limit,
+		Score:    m.WorstBias,
+		Expected: 0,
+		Keys:     m.Samples,
+	}
+}
+
+// Differential checks that flipping one or two bits of random 8 octet
+// keys practically never yields the same hash.
+func Differential(h HashFunc, opts Options) Result {
+	var rnd *rand.Rand = opts.rand()
+	var samples int = opts.samples() / 100
+	var key []byte = make([]byte, 8)
+	var collisions, tries int
+
+	if samples < 1 {
+		samples = 1
+	}
+
+	for s := 0; s < samples; s++ {
+		for i := range key {
+			key[i] = byte(rnd.Uint32())
+		}
+		var base uint32 = h(key)
+
+		for b1 := 0; b1 < 64; b1++ {
+			for b2 := b1; b2 < 64; b2++ {
+				key[b1/8] ^= 1 << (b1 % 8)
+				if b2 != b1 {
+					key[b2/8] ^= 1 << (b2 % 8)
+				}
+				if h(key) == base {
+					collisions++
+				}
+				tries++
+				key[b1/8] ^= 1 << (b1 % 8)
+				if b2 != b1 {
+					key[b2/8] ^= 1 << (b2 % 8)
+				}
+			}
+		}
+	}
+
+	var expected float64 = float64(tries) / (1 << 32)
+	return Result{
+		Name:     "differential",
+		Passed:   collisions <= poissonLimit(expected),
+		Score:    float64(collisions),
+		Expected: expected,
+		Keys:     tries,
+	}
+}
+
+// SparseKeys hashes all keys of keyLen octets with at most maxBits bits
+// set and counts the collisions.
+func SparseKeys(h HashFunc, keyLen, maxBits int) Result {
+	var hashes []uint32
+	var key []byte = make([]byte, keyLen)
+
+	var gen func(start, left int)
+	gen = func(start, left int) {
+		hashes = append(hashes, h(key))
+		if left == 0 {
+			return
+		}
+		for bit := start; bit < keyLen*8; bit++ {
+			key[bit/8] ^= 1 << (bit % 8)
+			gen(bit+1, left-1)
+			key[bit/8] ^= 1 << (bit % 8)
+		}
+	}
+	gen(0, maxBits)
+
+	return collisionResult("sparse/"+strconv.Itoa(keyLen)+"x"+strconv.Itoa(maxBits), hashes)
+}
+
+// CyclicKeys hashes random keys made of a block of cycleLen octets
+// repeated reps times and counts the collisions.
+func CyclicKeys(h HashFunc, cycleLen, reps int, opts Options) Result {
+	var rnd *rand.Rand = opts.rand()
+	var n int = opts.samples() * 10
+	var hashes []uint32 = make([]uint32, 0, n)
+	var seen map[string]bool = make(map[string]bool, n)
+	var key []byte = make([]byte, cycleLen*reps)
+
+	for len(hashes) < n {
+		for i := 0; i < cycleLen; i++ {
+			key[i] = byte(rnd.Uint32())
+		}
+		if seen[string(key[:cycleLen])] {
+			continue
+		}
+		seen[string(key[:cycleLen])] = true
+
+		for i := cycleLen; i < len(key); i++ {
+			key[i] = key[i-cycleLen]
+		}
+		hashes = append(hashes, h(key))
+	}
+
+	return collisionResult("cyclic/"+strconv.Itoa(cycleLen)+"x"+strconv.Itoa(reps), hashes)
+}
+
+// ZeroRuns hashes runs of 0 to maxLen NUL octets and counts the
+// collisions. Hashes which ignore NUL octets in some state, such as
+// OAAT starting from its zero IV, fail this test.
+func ZeroRuns(h HashFunc, maxLen int) Result {
+	var hashes []uint32 = make([]uint32, 0, maxLen+1)
+	var key []byte = make([]byte, maxLen)
+
+	for n := 0; n <= maxLen; n++ {
+		hashes = append(hashes, h(key[:n]))
+	}
+
+	return collisionResult("zeroes/"+strconv.Itoa(maxLen), hashes)
+}
+
+// collisionResult counts the colliding pairs among hashes of distinct
+// keys and compares them with the birthday bound.
+func collisionResult(name string, hashes []uint32) Result {
+	var collisions int = countCollisions(hashes)
+	var n float64 = float64(len(hashes))
+	var expected float64 = n * (n - 1) / 2 / (1 << 32)
+
+	return Result{
+		Name:     name,
+		Passed:   collisions <= poissonLimit(expected),
+		Score:    float64(collisions),
+		Expected: expected,
+		Keys:     len(hashes),
+	}
+}
+
+// countCollisions returns the number of pairs of equal values.
+func countCollisions(hashes []uint32) int {
+	var sorted []uint32 = append([]uint32(nil), hashes...)
+	var pairs, run int
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			run++
+			pairs += run
+		} else {
+			run = 0
+		}
+	}
+	return pairs
+}
+
+const (
+	// maxExactLambda is the largest mean for which poissonLimit sums
+	// up the Poisson distribution.
+	maxExactLambda = 700
+
+	// z999 is the 99.9% quantile of the standard normal distribution.
+	z999 = 3.090232
+)
+
+// poissonLimit returns the smallest k for which a Poisson distributed
+// variable with mean lambda exceeds k with a probability below 0.1%.
+// Beyond maxExactLambda, where e^-lambda underflows, the normal
+// approximation lambda + z·√lambda is used instead.
+func poissonLimit(lambda float64) int {
+	if lambda > maxExactLambda {
+		return int(math.Ceil(lambda + z999*math.Sqrt(lambda)))
+	}
+
+	var p float64 = math.Exp(-lambda)
+	var cdf float64 = p
+	var k int
+
+	for 1-cdf >= 0.001 {
+		k++
+		p *= lambda / float64(k)
+		cdf += p
+	}
+	return k
+}
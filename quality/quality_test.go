@@ -0,0 +1,85 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package quality
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+	"github.com/caoimhechaos/golang-nzaat/oaat"
+)
+
+// Test that NZAAT passes all tests.
+func TestNZAAT(t *testing.T) {
+	var r Report = Run("nzaat", nzaat.Checksum, Options{Seed: 1, Samples: 2000})
+
+	for _, res := range r.Results {
+		t.Logf("%+v", res)
+		if !res.Passed {
+			t.Errorf("NZAAT failed %s", res.Name)
+		}
+	}
+
+	if _, err := json.Marshal(r); err != nil {
+		t.Error(err)
+	}
+}
+
+// Test that the zero run test catches OAAT's weakness, which NZAAT was
+// designed to avoid.
+func TestOAATZeroRuns(t *testing.T) {
+	var res Result = ZeroRuns(oaat.Checksum, 16)
+
+	if res.Passed || res.Score != 16*17/2 {
+		t.Errorf("OAAT zero runs gave %+v", res)
+	}
+}
+
+// Test that OAAT is not all green for its last input octet, unlike
+// NZAAT.
+func TestOAATAvalanche(t *testing.T) {
+	if res := Avalanche(oaat.Checksum, 4, Options{Samples: 2000}); res.Passed {
+		t.Errorf("OAAT passed the avalanche test: %+v", res)
+	}
+}
+
+// Test that a bad hash fails the avalanche test.
+func TestBadAvalanche(t *testing.T) {
+	var sum HashFunc = func(b []byte) uint32 {
+		var s uint32
+		for _, c := range b {
+			s = s<<1 + uint32(c)
+		}
+		return s
+	}
+
+	if res := Avalanche(sum, 4, Options{Samples: 1000}); res.Passed {
+		t.Errorf("Shift-and-add passed the avalanche test: %+v", res)
+	}
+}
+
+// Test the collision counting and Poisson limits.
+func TestCollisionHelpers(t *testing.T) {
+	if n := countCollisions([]uint32{1, 2, 1, 3, 1, 2}); n != 4 {
+		t.Errorf("Expected 4 colliding pairs, got %d", n)
+	}
+	if k := poissonLimit(0); k != 0 {
+		t.Errorf("poissonLimit(0) = %d", k)
+	}
+	if k := poissonLimit(1); k != 5 {
+		t.Errorf("poissonLimit(1) = %d", k)
+	}
+
+	// Both sides of the switch to the normal approximation, whose
+	// exact limits are 783 and 889.
+	if k := poissonLimit(700); k < 780 || k > 800 {
+		t.Errorf("poissonLimit(700) = %d", k)
+	}
+	if k := poissonLimit(800); k < 880 || k > 900 {
+		t.Errorf("poissonLimit(800) = %d", k)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package recordlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// LinkSize is the size of the link at the start of every record of a
+// hash-chained log.
+const LinkSize = 4
+
+// ErrChainBroken is returned by ChainReader.Next for a record whose
+// link doesn't follow from the records before it.
+var ErrChainBroken = errors.New("recordlog: hash chain broken")
+
+// ChainSum returns the link of a record with the payload data following
+// a record with the link prev: the NZAAT checksum of prev as a big
+// endian 32 bit integer followed by data.
+func ChainSum(prev uint32, data []byte) uint32 {
+	var d nzaat.Digest
+	d.WriteUint32(prev, binary.BigEndian)
+	d.Write(data)
+	return d.Sum32()
+}
+
+// ChainWriter writes a hash-chained log: every record starts with a
+// link covering the link of the previous record and its own payload.
+// Accidental changes, removals or reorderings of records break the
+// chain at that point. The links are unkeyed 32 bit NZAAT checksums,
+// not a MAC, though: anyone editing a record can recompute all later
+// links, so deliberate tampering, like appending records or cutting
+// off the end of the log, can only be detected by comparing the head
+// of the chain against a copy kept elsewhere.
+type ChainWriter struct {
+	w    *Writer
+	head uint32
+	buf  []byte
+}
+
+// NewChainWriter returns a ChainWriter appending records to w after
+// the record with the link head, which is 0 for a new log.
+func NewChainWriter(w io.Writer, head uint32) *ChainWriter {
+	return &ChainWriter{w: NewWriter(w), head: head}
+}
+
+// Write appends data as a single chained record. It returns the number
+// of bytes of data written, as io.Writer requires.
+func (c *ChainWriter) Write(data []byte) (int, error) {
+	var link uint32 = ChainSum(c.head, data)
+
+	c.buf = binary.BigEndian.AppendUint32(c.buf[:0], link)
+	c.buf = append(c.buf, data...)
+
+	n, err := c.w.Write(c.buf)
+	if err == nil {
+		c.head = link
+	}
+	return max(n-LinkSize, 0), err
+}
+
+// Head returns the link of the last record written.
+func (c *ChainWriter) Head() uint32 {
+	return c.head
+}
+
+// ChainReader reads and verifies a hash-chained log.
+type ChainReader struct {
+	r      *Reader
+	head   uint32
+	offset int64
+}
+
+// NewChainReader returns a ChainReader reading records from r, which
+// follow the record with the link head, or 0 at the start of a log.
+func NewChainReader(r io.Reader, head uint32) *ChainReader {
+	return &ChainReader{r: NewReader(r), head: head}
+}
+
+// Next returns the payload of the next record. The returned slice is
+// only valid until the next call. It returns the same errors as
+// Reader.Next, and ErrChainBroken for a record which is intact but
+// does not belong after the previous one.
+func (c *ChainReader) Next() ([]byte, error) {
+	rec, err := c.r.Next()
+	if err != nil {
+		return nil, err
+	}
+	if len(rec) < LinkSize {
+		return nil, ErrChainBroken
+	}
+
+	var link uint32 = binary.BigEndian.Uint32(rec)
+	if ChainSum(c.head, rec[LinkSize:]) != link {
+		return nil, ErrChainBroken
+	}
+
+	c.head = link
+	c.offset = c.r.Offset()
+	return rec[LinkSize:], nil
+}
+
+// Head returns the link of the last record returned by Next.
+func (c *ChainReader) Head() uint32 {
+	return c.head
+}
+
+// Offset returns the offset just after the last record returned by
+// Next, relative to the start of the reader.
+func (c *ChainReader) Offset() int64 {
+	return c.offset
+}
+
+// ChainReport describes the result of verifying a hash-chained log.
+type ChainReport struct {
+	// Records is the number of records verified, which is also the
+	// index of the first bad record, if any.
+	Records int
+
+	// Valid is the size of the verified part of the log in bytes,
+	// which is also the offset of the first bad record, if any.
+	Valid int64
+
+	// Head is the link of the last verified record.
+	Head uint32
+
+	// Problem is nil if the whole log was verified. Otherwise it is
+	// the reason the first bad record was rejected: ErrChainBroken,
+	// or any of the problems reported by Scan.
+	Problem error
+}
+
+// VerifyChain verifies the hash-chained log read from r from its start
+// up to the first break. An error is only returned if r fails; damage
+// is described in the report.
+func VerifyChain(r io.Reader) (ChainReport, error) {
+	var report ChainReport
+	var cr *ChainReader = NewChainReader(bufio.NewReader(r), 0)
+	var err error
+
+	for {
+		if _, err = cr.Next(); err != nil {
+			break
+		}
+		report.Records++
+	}
+	report.Valid = cr.Offset()
+	report.Head = cr.Head()
+
+	switch err {
+	case io.EOF:
+	case io.ErrUnexpectedEOF, ErrCorrupt, ErrTooLarge, ErrChainBroken:
+		report.Problem = err
+	default:
+		return report, err
+	}
+	return report, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package recordlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// writeChain returns a chained log of n records and its head.
+func writeChain(n int) ([]byte, uint32) {
+	var buf bytes.Buffer
+	var w *ChainWriter = NewChainWriter(&buf, 0)
+
+	for i := 0; i < n; i++ {
+		w.Write([]byte("entry " + strconv.Itoa(i)))
+	}
+	return buf.Bytes(), w.Head()
+}
+
+// Test verifying an intact chain and resuming it.
+func TestChain(t *testing.T) {
+	log, head := writeChain(5)
+
+	report, err := VerifyChain(bytes.NewReader(log))
+	if err != nil || report.Problem != nil || report.Records != 5 || report.Head != head ||
+		report.Valid != int64(len(log)) {
+		t.Fatalf("Unexpected report %+v, %v", report, err)
+	}
+
+	var buf *bytes.Buffer = bytes.NewBuffer(log)
+	NewChainWriter(buf, report.Head).Write([]byte("entry 5"))
+	if report, _ = VerifyChain(buf); report.Problem != nil || report.Records != 6 {
+		t.Errorf("Resumed chain gave %+v", report)
+	}
+}
+
+// Test that tampering with properly framed records is detected at the
+// first changed link.
+func TestChainTampering(t *testing.T) {
+	log, _ := writeChain(5)
+	var recs [][]byte
+	var r *Reader = NewReader(bytes.NewReader(log))
+
+	for i := 0; i < 5; i++ {
+		rec, _ := r.Next()
+		recs = append(recs, bytes.Clone(rec))
+	}
+
+	var rewrite = func(recs [][]byte) []byte {
+		var buf bytes.Buffer
+		var w *Writer = NewWriter(&buf)
+		for _, rec := range recs {
+			w.Write(rec)
+		}
+		return buf.Bytes()
+	}
+
+	// Change the payload of record 2 and fix its link to match: the
+	// link of record 3 no longer follows.
+	var changed [][]byte = append([][]byte(nil), recs...)
+	var prev uint32 = binary.BigEndian.Uint32(recs[1])
+	changed[2] = binary.BigEndian.AppendUint32(nil, ChainSum(prev, []byte("forged")))
+	changed[2] = append(changed[2], "forged"...)
+	if report, _ := VerifyChain(bytes.NewReader(rewrite(changed))); report.Problem != ErrChainBroken || report.Records != 3 {
+		t.Errorf("Forged record gave %+v", report)
+	}
+
+	// Drop record 1.
+	var dropped [][]byte = append([][]byte{recs[0]}, recs[2:]...)
+	if report, _ := VerifyChain(bytes.NewReader(rewrite(dropped))); report.Problem != ErrChainBroken || report.Records != 1 {
+		t.Errorf("Dropped record gave %+v", report)
+	}
+
+	// Frame damage is still reported as such.
+	var flipped []byte = bytes.Clone(log)
+	flipped[len(flipped)-1] ^= 1
+	if report, _ := VerifyChain(bytes.NewReader(flipped)); report.Problem != ErrCorrupt || report.Records != 4 {
+		t.Errorf("Flipped bit gave %+v", report)
+	}
+}
+
+// Test that ChainWriter obeys the io.Writer contract, so io.Copy works.
+func TestChainWriterCopy(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := io.Copy(NewChainWriter(&buf, 0), strings.NewReader("hello"))
+	if err != nil || n != 5 {
+		t.Fatalf("io.Copy returned %d, %v", n, err)
+	}
+	if rec, err := NewChainReader(&buf, 0).Next(); err != nil || string(rec) != "hello" {
+		t.Errorf("Got %q, %v", rec, err)
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package recordlog implements an append-only log of records, each
+// framed with its length and NZAAT checksum.
+//
+// Every record is preceded by an 8 byte header: the length of the
+// payload and the checksum of the length and payload, both as big
+// endian 32 bit integers. A crash in the middle of an append leaves a
+// torn record at the end of the log, which Open detects and truncates,
+// so that the log always ends after the last complete record. Damaged
+// records before the end make Open fail rather than lose data.
+//
+// ChainWriter and ChainReader add a hash chain on top of the framing,
+// linking each record to the one before it, which makes accidental
+// changes to the records evident. It is not a MAC, so deliberate
+// tampering is only evident against a chain head stored elsewhere.
+// VerifyChain finds the first break in such a chain.
+package recordlog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// HeaderSize is the size of the header in front of every record.
+const HeaderSize = 8
+
+// MaxRecordSize is the largest payload a record may have. It bounds the
+// memory used when reading a damaged length.
+const MaxRecordSize = 64 << 20
+
+var (
+	// ErrCorrupt is returned by Reader.Next for a record whose
+	// checksum doesn't match.
+	ErrCorrupt = errors.New("recordlog: corrupt record")
+
+	// ErrTooLarge is returned when appending a record larger than
+	// MaxRecordSize, and when reading a header claiming one.
+	ErrTooLarge = errors.New("recordlog: record too large")
+)
+
+func recordSum(hdr []byte, payload []byte) uint32 {
+	var d nzaat.Digest
+	d.Write(hdr[:4])
+	d.Write(payload)
+	return d.Sum32()
+}
+
+// Writer writes framed records to an io.Writer.
+type Writer struct {
+	w   io.Writer
+	hdr [HeaderSize]byte
+}
+
+// NewWriter returns a Writer framing records onto w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes rec as a single record. It returns the number of bytes
+// of rec written, as io.Writer requires, so every call to Write on a
+// Writer becomes one record.
+func (w *Writer) Write(rec []byte) (int, error) {
+	n, err := w.writeRecord(rec)
+	return max(n-HeaderSize, 0), err
+}
+
+// writeRecord writes rec as a single record and returns the number of
+// bytes written, including the header.
+func (w *Writer) writeRecord(rec []byte) (int, error) {
+	if len(rec) > MaxRecordSize {
+		return 0, ErrTooLarge
+	}
+
+	binary.BigEndian.PutUint32(w.hdr[:4], uint32(len(rec)))
+	binary.BigEndian.PutUint32(w.hdr[4:], recordSum(w.hdr[:], rec))
+
+	n, err := w.w.Write(w.hdr[:])
+	if err != nil {
+		return n, err
+	}
+	m, err := w.w.Write(rec)
+	return n + m, err
+}
+
+// Reader reads framed records from an io.Reader.
+type Reader struct {
+	r      io.Reader
+	hdr    [HeaderSize]byte
+	buf    []byte
+	offset int64
+}
+
+// NewReader returns a Reader reading records from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Offset returns the offset just after the last record returned by
+// Next, relative to the start of the reader.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// Next returns the next record. The returned slice is only valid until
+// the next call. At the clean end of the input it returns io.EOF; a
+// partial record yields io.ErrUnexpectedEOF, and a damaged one
+// ErrCorrupt or ErrTooLarge.
+func (r *Reader) Next() ([]byte, error) {
+	if _, err := io.ReadFull(r.r, r.hdr[:]); err != nil {
+		return nil, err
+	}
+
+	var size uint32 = binary.BigEndian.Uint32(r.hdr[:4])
+	if size > MaxRecordSize {
+		return nil, ErrTooLarge
+	}
+	if cap(r.buf) < int(size) {
+		r.buf = make([]byte, size)
+	}
+	r.buf = r.buf[:size]
+
+	if _, err := io.ReadFull(r.r, r.buf); err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+	if recordSum(r.hdr[:], r.buf) != binary.BigEndian.Uint32(r.hdr[4:]) {
+		return nil, ErrCorrupt
+	}
+
+	r.offset += HeaderSize + int64(size)
+	return r.buf, nil
+}
+
+// Log is an append-only record log stored in a file. It is not safe
+// for concurrent use.
+type Log struct {
+	f     *os.File
+	w     *Writer
+	size  int64
+	count int
+}
+
+// Open opens the log in the file name, creating it if necessary. The
+// existing records are scanned, and a torn record at the end, left by
+// a crash in the middle of an append, is truncated away. A record is
+// considered torn if it is incomplete, or if it is damaged and runs up
+// to or past the end of the file. Damage before the end is not
+// repaired, since that would drop the valid records after it; Open
+// fails with an error wrapping ErrCorrupt or ErrTooLarge instead, and
+// ScanFile can repair the log deliberately.
+func Open(name string) (*Log, error) {
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := Scan(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("recordlog: scanning %s: %w", name, err)
+	}
+
+	if report.Problem != nil && report.Problem != io.ErrUnexpectedEOF {
+		var torn bool
+		if torn, err = tornTail(f, report.Valid); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if !torn {
+			f.Close()
+			return nil, fmt.Errorf("recordlog: %s: record %d at offset %d: %w",
+				name, report.Records, report.Valid, report.Problem)
+		}
+	}
+
+	if err = f.Truncate(report.Valid); err == nil {
+		_, err = f.Seek(report.Valid, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &Log{f: f, w: NewWriter(f), size: report.Valid, count: report.Records}, nil
+}
+
+// tornTail reports whether the damaged record at offset in f runs up to
+// or past the end of the file, as one torn by a crash would.
+func tornTail(f *os.File, offset int64) (bool, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	var hdr [HeaderSize]byte
+	if _, err = f.ReadAt(hdr[:], offset); err != nil {
+		return false, err
+	}
+	var end int64 = offset + HeaderSize + int64(binary.BigEndian.Uint32(hdr[:4]))
+	return end >= fi.Size(), nil
+}
+
+// Append adds rec to the end of the log and returns its offset. The
+// record is not durable before the next call to Sync. If writing the
+// record fails, the part of it which was written is truncated away
+// again, so later records don't end up behind a torn one.
+func (l *Log) Append(rec []byte) (int64, error) {
+	var offset int64 = l.size
+
+	n, err := l.w.writeRecord(rec)
+	if err != nil {
+		if n > 0 {
+			if terr := l.truncate(offset); terr != nil {
+				l.size += int64(n)
+				return offset, errors.Join(err, terr)
+			}
+		}
+		return offset, err
+	}
+
+	l.size += int64(n)
+	l.count++
+	return offset, nil
+}
+
+// truncate cuts the log file off at size and continues writing there.
+func (l *Log) truncate(size int64) error {
+	if err := l.f.Truncate(size); err != nil {
+		return err
+	}
+	_, err := l.f.Seek(size, io.SeekStart)
+	return err
+}
+
+// Len returns the number of records in the log.
+func (l *Log) Len() int {
+	return l.count
+}
+
+// Size returns the size of the log in bytes.
+func (l *Log) Size() int64 {
+	return l.size
+}
+
+// Sync commits the appended records to stable storage.
+func (l *Log) Sync() error {
+	return l.f.Sync()
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	return l.f.Close()
+}
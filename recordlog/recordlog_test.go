@@ -0,0 +1,226 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package recordlog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test reading back the records written to a buffer.
+func TestWriterReader(t *testing.T) {
+	var buf bytes.Buffer
+	var w *Writer = NewWriter(&buf)
+
+	for i := 0; i < 10; i++ {
+		w.Write([]byte(strconv.Itoa(i)))
+	}
+	w.Write(nil)
+
+	var r *Reader = NewReader(&buf)
+	for i := 0; i < 10; i++ {
+		rec, err := r.Next()
+		if err != nil || string(rec) != strconv.Itoa(i) {
+			t.Fatalf("Record %d: got %q, %v", i, rec, err)
+		}
+	}
+	if rec, err := r.Next(); err != nil || len(rec) != 0 {
+		t.Errorf("Expected an empty record, got %q, %v", rec, err)
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
+
+// Test that damaged records are detected.
+func TestReaderDamage(t *testing.T) {
+	var buf bytes.Buffer
+	NewWriter(&buf).Write([]byte("hello"))
+	var good []byte = buf.Bytes()
+
+	var flipped []byte = bytes.Clone(good)
+	flipped[HeaderSize] ^= 1
+	if _, err := NewReader(bytes.NewReader(flipped)).Next(); err != ErrCorrupt {
+		t.Errorf("Flipped payload bit: got %v", err)
+	}
+
+	var long []byte = bytes.Clone(good)
+	long[3] = 4
+	if _, err := NewReader(bytes.NewReader(long)).Next(); err != ErrCorrupt {
+		t.Errorf("Changed length: got %v", err)
+	}
+
+	for _, n := range []int{3, HeaderSize + 2} {
+		if _, err := NewReader(bytes.NewReader(good[:n])).Next(); err != io.ErrUnexpectedEOF {
+			t.Errorf("Torn after %d bytes: got %v", n, err)
+		}
+	}
+}
+
+// Test that reopening a log truncates a torn tail and keeps appending
+// after the last complete record.
+func TestLogRecovery(t *testing.T) {
+	var name string = filepath.Join(t.TempDir(), "log")
+
+	l, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Append([]byte("first"))
+	if off, _ := l.Append([]byte("second")); off != HeaderSize+5 {
+		t.Errorf("Second record at %d", off)
+	}
+	l.Close()
+
+	// Simulate a crash in the middle of the next append.
+	f, _ := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{0, 0, 0, 9, 1, 2, 3, 4, 'x'})
+	f.Close()
+
+	if l, err = Open(name); err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() != 2 || l.Size() != 2*HeaderSize+11 {
+		t.Errorf("Reopened log has %d records in %d bytes", l.Len(), l.Size())
+	}
+	l.Append([]byte("third"))
+	l.Close()
+
+	data, _ := os.ReadFile(name)
+	var r *Reader = NewReader(bytes.NewReader(data))
+	for _, want := range []string{"first", "second", "third"} {
+		if rec, err := r.Next(); err != nil || string(rec) != want {
+			t.Errorf("Got %q, %v, expected %q", rec, err, want)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
+
+// Test that Writer obeys the io.Writer contract, so io.Copy works.
+func TestWriterCopy(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := io.Copy(NewWriter(&buf), strings.NewReader("hello"))
+	if err != nil || n != 5 {
+		t.Fatalf("io.Copy returned %d, %v", n, err)
+	}
+	if rec, err := NewReader(&buf).Next(); err != nil || string(rec) != "hello" {
+		t.Errorf("Got %q, %v", rec, err)
+	}
+}
+
+// Test that a log damaged before its end is not truncated by Open.
+func TestLogCorruptMiddle(t *testing.T) {
+	var name string = filepath.Join(t.TempDir(), "log")
+
+	l, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range []string{"first", "second", "third"} {
+		l.Append([]byte(rec))
+	}
+	l.Close()
+
+	data, _ := os.ReadFile(name)
+	data[2*HeaderSize+5] ^= 1
+	os.WriteFile(name, data, 0644)
+
+	if _, err = Open(name); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Opening damaged log: %v", err)
+	}
+	if after, _ := os.ReadFile(name); len(after) != len(data) {
+		t.Errorf("Damaged log was truncated to %d bytes", len(after))
+	}
+}
+
+// Test that a damaged last record running to the end of the file is
+// truncated as a torn tail.
+func TestLogCorruptTail(t *testing.T) {
+	var name string = filepath.Join(t.TempDir(), "log")
+
+	l, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Append([]byte("first"))
+	l.Close()
+
+	// A header claiming a huge record, and a complete but damaged one.
+	for _, tail := range [][]byte{
+		{0xff, 0xff, 0xff, 0xff, 1, 2, 3, 4, 'x'},
+		{0, 0, 0, 1, 1, 2, 3, 4, 'x'},
+	} {
+		f, _ := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+		f.Write(tail)
+		f.Close()
+
+		if l, err = Open(name); err != nil {
+			t.Fatalf("Opening log with tail %x: %v", tail, err)
+		}
+		if l.Len() != 1 || l.Size() != HeaderSize+5 {
+			t.Errorf("Reopened log has %d records in %d bytes", l.Len(), l.Size())
+		}
+		l.Close()
+	}
+}
+
+// failingWriter writes at most n bytes to w and then fails.
+type failingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > f.n {
+		n, _ := f.w.Write(p[:f.n])
+		f.n -= n
+		return n, errors.New("write failed")
+	}
+	f.n -= len(p)
+	return f.w.Write(p)
+}
+
+// Test that a failed append leaves no partial record behind.
+func TestLogAppendFailure(t *testing.T) {
+	var name string = filepath.Join(t.TempDir(), "log")
+
+	l, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Append([]byte("first"))
+
+	l.w = NewWriter(&failingWriter{w: l.f, n: HeaderSize + 2})
+	if _, err = l.Append([]byte("second")); err == nil {
+		t.Error("Failed append reported no error")
+	}
+	if l.Len() != 1 || l.Size() != HeaderSize+5 {
+		t.Errorf("Log has %d records in %d bytes after failed append", l.Len(), l.Size())
+	}
+
+	l.w = NewWriter(l.f)
+	if off, err := l.Append([]byte("third")); err != nil || off != HeaderSize+5 {
+		t.Errorf("Append after failure at %d: %v", off, err)
+	}
+	l.Close()
+
+	if l, err = Open(name); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if l.Len() != 2 {
+		t.Errorf("Reopened log has %d records", l.Len())
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package recordlog
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// ScanReport describes the result of checking a log.
+type ScanReport struct {
+	// Records is the number of valid records at the start of the log,
+	// which is also the index of the first bad record, if any.
+	Records int
+
+	// Valid is the size of the valid part of the log in bytes, which
+	// is also the offset of the first bad record, if any.
+	Valid int64
+
+	// Size is the total size of the log in bytes.
+	Size int64
+
+	// Problem is nil for a clean log. Otherwise it is the reason the
+	// first bad record was rejected: io.ErrUnexpectedEOF for a torn
+	// record at the end, or ErrCorrupt or ErrTooLarge.
+	Problem error
+}
+
+// Clean reports whether the log consists of valid records only.
+func (s ScanReport) Clean() bool {
+	return s.Problem == nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Scan reads the log from r up to the first bad record. An error is
+// only returned if r fails; damage is described in the report.
+func Scan(r io.Reader) (ScanReport, error) {
+	var report ScanReport
+	var cr *countingReader = &countingReader{r: r}
+	var lr *Reader = NewReader(bufio.NewReader(cr))
+	var err error
+
+	for {
+		if _, err = lr.Next(); err != nil {
+			break
+		}
+		report.Records++
+	}
+	report.Valid = lr.Offset()
+
+	switch err {
+	case io.EOF:
+	case io.ErrUnexpectedEOF, ErrCorrupt, ErrTooLarge:
+		report.Problem = err
+	default:
+		return report, err
+	}
+
+	// Count the rest of the input to learn the total size.
+	if _, err = io.Copy(io.Discard, cr); err != nil {
+		return report, err
+	}
+	report.Size = cr.n
+	return report, nil
+}
+
+// ScanFile scans the log in the file name. If repair is set and the log
+// is damaged, the file is truncated after its last valid record. This
+// drops all records after the first bad one.
+func ScanFile(name string, repair bool) (ScanReport, error) {
+	var flags int = os.O_RDONLY
+	if repair {
+		flags = os.O_RDWR
+	}
+
+	f, err := os.OpenFile(name, flags, 0)
+	if err != nil {
+		return ScanReport{}, err
+	}
+	defer f.Close()
+
+	report, err := Scan(f)
+	if err != nil || report.Clean() || !repair {
+		return report, err
+	}
+	if err = f.Truncate(report.Valid); err == nil {
+		err = f.Sync()
+	}
+	return report, err
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package recordlog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test finding a corrupt record in the middle of a log and repairing
+// the log by truncation.
+func TestScanFile(t *testing.T) {
+	var name string = filepath.Join(t.TempDir(), "log")
+	var buf bytes.Buffer
+	var w *Writer = NewWriter(&buf)
+
+	for _, rec := range []string{"one", "two", "three", "four"} {
+		w.Write([]byte(rec))
+	}
+	var data []byte = buf.Bytes()
+	data[2*HeaderSize+6+HeaderSize] ^= 0x20 // "three" becomes "Three"
+	if err := os.WriteFile(name, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ScanFile(name, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Clean() || report.Problem != ErrCorrupt || report.Records != 2 ||
+		report.Valid != 2*HeaderSize+6 || report.Size != int64(len(data)) {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if fi, _ := os.Stat(name); fi.Size() != int64(len(data)) {
+		t.Error("Scanning without repair changed the file")
+	}
+
+	if _, err = ScanFile(name, true); err != nil {
+		t.Fatal(err)
+	}
+	if report, err = ScanFile(name, false); err != nil || !report.Clean() || report.Records != 2 || report.Size != report.Valid {
+		t.Errorf("Unexpected report %+v, %v after repair", report, err)
+	}
+}
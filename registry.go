@@ -0,0 +1,69 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"sort"
+	"sync"
+)
+
+var (
+	registryMtx sync.RWMutex
+	registry    = map[string]func() hash.Hash32{
+		"nzaat": New,
+		"nzat":  NewNZAT,
+	}
+)
+
+// Register makes the hash constructed by fn available under name, so
+// file formats and configuration can select hashes at run time. The
+// names "nzaat" and "nzat" are registered by this package. Register
+// panics if name is already registered or fn is nil.
+func Register(name string, fn func() hash.Hash32) {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	if fn == nil {
+		panic("nzaat: Register of nil constructor for " + name)
+	}
+	if _, ok := registry[name]; ok {
+		panic("nzaat: Register called twice for " + name)
+	}
+	registry[name] = fn
+}
+
+// Get returns the constructor of the hash registered under name, and
+// whether there is one.
+func Get(name string) (func() hash.Hash32, bool) {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	fn, ok := registry[name]
+	return fn, ok
+}
+
+// Names returns the sorted names of all registered hashes.
+func Names() []string {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	var names []string = make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unregister removes name from the registry. It exists so tests can
+// undo their registrations.
+func unregister(name string) {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	delete(registry, name)
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"hash"
+	"testing"
+)
+
+// Test the predefined registry entries.
+func TestRegistryPredefined(t *testing.T) {
+	for name, want := range map[string]uint32{"nzaat": 0, "nzat": 0x48009} {
+		fn, ok := Get(name)
+		if !ok {
+			t.Fatalf("%s is not registered", name)
+		}
+		if res := fn().Sum32(); res != want {
+			t.Errorf("%s(\"\") = %x, expected %x", name, res, want)
+		}
+	}
+
+	if _, ok := Get("md5"); ok {
+		t.Error("Unknown name was found")
+	}
+}
+
+// Test registering a new variant and duplicate registration.
+func TestRegister(t *testing.T) {
+	var p Params = NZAATParams
+	p.IV = 42
+
+	Register("test-iv42", p.New)
+	t.Cleanup(func() { unregister("test-iv42") })
+	if fn, ok := Get("test-iv42"); !ok || fn().Sum32() != p.New().Sum32() {
+		t.Error("Registered variant was not found")
+	}
+
+	var found bool
+	for _, name := range Names() {
+		found = found || name == "test-iv42"
+	}
+	if !found {
+		t.Errorf("Names() = %v does not include the new variant", Names())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Duplicate registration did not panic")
+		}
+	}()
+	Register("nzaat", func() hash.Hash32 { return New() })
+}
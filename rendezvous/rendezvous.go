@@ -0,0 +1,198 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package rendezvous implements rendezvous, or highest random weight,
+// hashing with NZAAT: a key is assigned to the node for which the hash
+// of the node name and the key is highest. Adding or removing a node
+// only moves the keys which are assigned to that node.
+//
+// Nodes can be given weights, so that heterogeneous backends receive
+// shares of the keys proportional to their capacity. Weighted scores
+// follow the logarithmic method of weighted rendezvous hashing: the
+// hash is mapped to h in (0, 1) and the score is -w/ln(h), which keeps
+// the property that changing one node only moves keys to or from it.
+package rendezvous
+
+import (
+	"math"
+	"sort"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+type node struct {
+	name   string
+	weight float64
+
+	// prefix is the hash state after absorbing the node name, so
+	// only the key needs to be hashed for every pick.
+	prefix nzaat.Digest
+}
+
+func (n *node) score(key []byte) uint32 {
+	var d nzaat.Digest = n.prefix
+	d.Write(key)
+	return d.Sum32()
+}
+
+// weightedScore returns the score of the node for key scaled by the
+// weight of the node.
+func (n *node) weightedScore(key []byte) float64 {
+	var h float64 = (float64(n.score(key)) + 0.5) / (1 << 32)
+	return -n.weight / math.Log(h)
+}
+
+// Table is a set of nodes keys are assigned to. It may be used for
+// picking from several goroutines, but not while it is being changed.
+type Table struct {
+	nodes []node
+
+	// weighted is set if any node has a weight other than 1.
+	weighted bool
+}
+
+// New returns a table of the given nodes.
+func New(nodes ...string) *Table {
+	var t *Table = new(Table)
+	for _, name := range nodes {
+		t.Add(name)
+	}
+	return t
+}
+
+// Add adds the node name to the table with weight 1, if it is not
+// already there.
+func (t *Table) Add(name string) {
+	for _, n := range t.nodes {
+		if n.name == name {
+			return
+		}
+	}
+	t.AddWeighted(name, 1)
+}
+
+// AddWeighted adds the node name to the table with the given weight, or
+// changes the weight of the node if it is already there. A node with
+// twice the weight of another receives twice as many keys. It panics if
+// weight is negative or not finite.
+func (t *Table) AddWeighted(name string, weight float64) {
+	if !(weight >= 0) || math.IsInf(weight, 1) {
+		panic("rendezvous: weight must be finite and not negative")
+	}
+
+	defer t.updateWeighted()
+	for i := range t.nodes {
+		if t.nodes[i].name == name {
+			t.nodes[i].weight = weight
+			return
+		}
+	}
+
+	var n node = node{name: name, weight: weight}
+	n.prefix.WriteLengthPrefixedString(name)
+	t.nodes = append(t.nodes, n)
+}
+
+// updateWeighted sets weighted after a change of the nodes.
+func (t *Table) updateWeighted() {
+	t.weighted = false
+	for _, n := range t.nodes {
+		if n.weight != 1 {
+			t.weighted = true
+		}
+	}
+}
+
+// Weight returns the weight of the node name, or 0 if there is no such
+// node.
+func (t *Table) Weight(name string) float64 {
+	for _, n := range t.nodes {
+		if n.name == name {
+			return n.weight
+		}
+	}
+	return 0
+}
+
+// score returns the score of n for key. Without weights, it is the
+// plain hash, which is ordered the same but cheaper to compute.
+func (t *Table) score(n *node, key []byte) float64 {
+	if t.weighted {
+		return n.weightedScore(key)
+	}
+	return float64(n.score(key))
+}
+
+// Remove removes the node name from the table. It reports whether the
+// node was present.
+func (t *Table) Remove(name string) bool {
+	for i, n := range t.nodes {
+		if n.name == name {
+			t.nodes = append(t.nodes[:i], t.nodes[i+1:]...)
+			t.updateWeighted()
+			return true
+		}
+	}
+	return false
+}
+
+// Nodes returns the names of the nodes in the table, in the order they
+// were added.
+func (t *Table) Nodes() []string {
+	var names []string = make([]string, len(t.nodes))
+	for i, n := range t.nodes {
+		names[i] = n.name
+	}
+	return names
+}
+
+// Pick returns the node key is assigned to, or false if the table is
+// empty. Ties are broken by node name.
+func (t *Table) Pick(key []byte) (string, bool) {
+	var best *node
+	var bestScore float64
+
+	for i := range t.nodes {
+		var n *node = &t.nodes[i]
+		var s float64 = t.score(n, key)
+		if best == nil || s > bestScore || (s == bestScore && n.name < best.name) {
+			best, bestScore = n, s
+		}
+	}
+
+	if best == nil {
+		return "", false
+	}
+	return best.name, true
+}
+
+// PickN returns up to n nodes in order of preference for key, for
+// example for placing replicas. The first one is the node Pick returns.
+func (t *Table) PickN(key []byte, n int) []string {
+	type scored struct {
+		name  string
+		score float64
+	}
+	var all []scored = make([]scored, len(t.nodes))
+
+	for i := range t.nodes {
+		all[i] = scored{t.nodes[i].name, t.score(&t.nodes[i], key)}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].score != all[j].score {
+			return all[i].score > all[j].score
+		}
+		return all[i].name < all[j].name
+	})
+
+	if n > len(all) {
+		n = len(all)
+	}
+	var names []string = make([]string, n)
+	for i := range names {
+		names[i] = all[i].name
+	}
+	return names
+}
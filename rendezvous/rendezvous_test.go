@@ -0,0 +1,116 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package rendezvous
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test that keys are spread evenly and removing a node only moves the
+// keys assigned to it.
+func TestPick(t *testing.T) {
+	var tbl *Table = New("a", "b", "c", "d")
+	var before map[string]string = make(map[string]string)
+	var counts map[string]int = make(map[string]int)
+
+	for i := 0; i < 10000; i++ {
+		var key string = "user:" + strconv.Itoa(i)
+		node, ok := tbl.Pick([]byte(key))
+		if !ok {
+			t.Fatal("Pick failed on a non-empty table")
+		}
+		before[key] = node
+		counts[node]++
+	}
+	for node, n := range counts {
+		if n < 2000 || n > 3000 {
+			t.Errorf("Node %s got %d of 10000 keys", node, n)
+		}
+	}
+
+	tbl.Remove("b")
+	for key, node := range before {
+		after, _ := tbl.Pick([]byte(key))
+		if node != "b" && after != node {
+			t.Fatalf("Key %s moved from %s to %s", key, node, after)
+		}
+		if after == "b" {
+			t.Fatalf("Key %s still assigned to the removed node", key)
+		}
+	}
+}
+
+// Test the preference list and the empty table.
+func TestPickN(t *testing.T) {
+	var tbl *Table = New("a", "b", "c")
+
+	var pref []string = tbl.PickN([]byte("key"), 5)
+	if len(pref) != 3 {
+		t.Fatalf("Expected 3 nodes, got %v", pref)
+	}
+	if first, _ := tbl.Pick([]byte("key")); pref[0] != first {
+		t.Errorf("PickN starts with %s, Pick gave %s", pref[0], first)
+	}
+
+	if _, ok := New().Pick([]byte("key")); ok {
+		t.Error("Pick succeeded on an empty table")
+	}
+}
+
+// Test that weighted nodes receive shares proportional to their weight
+// and that changing a weight only moves keys to or from that node.
+func TestWeighted(t *testing.T) {
+	var tbl *Table = New()
+	tbl.AddWeighted("small", 1)
+	tbl.AddWeighted("medium", 2)
+	tbl.AddWeighted("large", 4)
+
+	var before map[string]string = make(map[string]string)
+	var counts map[string]int = make(map[string]int)
+	for i := 0; i < 70000; i++ {
+		var key string = "user:" + strconv.Itoa(i)
+		node, _ := tbl.Pick([]byte(key))
+		before[key] = node
+		counts[node]++
+	}
+	for node, want := range map[string]int{"small": 10000, "medium": 20000, "large": 40000} {
+		if n := counts[node]; n < want*9/10 || n > want*11/10 {
+			t.Errorf("Node %s got %d keys, expected about %d", node, n, want)
+		}
+	}
+
+	tbl.AddWeighted("medium", 3)
+	if w := tbl.Weight("medium"); w != 3 {
+		t.Errorf("Got weight %g, expected 3", w)
+	}
+	for key, old := range before {
+		node, _ := tbl.Pick([]byte(key))
+		if node != old && node != "medium" {
+			t.Fatalf("Key %s moved from %s to %s", key, old, node)
+		}
+		if names := tbl.PickN([]byte(key), 1); names[0] != node {
+			t.Fatalf("PickN prefers %s for %s, Pick %s", names[0], key, node)
+		}
+	}
+}
+
+// Test that nodes of weight 1 pick exactly as unweighted nodes.
+func TestWeightOne(t *testing.T) {
+	var plain *Table = New("a", "b", "c")
+	var weighted *Table = New("a", "b")
+	weighted.AddWeighted("c", 2)
+	weighted.AddWeighted("c", 1)
+
+	for i := 0; i < 1000; i++ {
+		var key []byte = []byte(strconv.Itoa(i))
+		a, _ := plain.Pick(key)
+		b, _ := weighted.Pick(key)
+		if a != b {
+			t.Fatalf("Key %s: got %s, expected %s", key, b, a)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package ring
+
+import (
+	"math"
+	"sync"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Bounded assigns keys with consistent hashing with bounded loads, as
+// described by Mirrokni, Thorup and Zadimoghaddam: no node takes on
+// more than c times the average load, rounded up. A key whose node is
+// full spills over to the next node along the ring which is not, so
+// popular keys spread out over a few neighbours instead of overloading
+// one backend, while most keys still go to their usual node.
+//
+// The load is the number of keys acquired and not yet released, e.g.
+// the requests in flight or the entries held. A Bounded is safe for
+// concurrent use; the underlying Ring must not be changed while the
+// Bounded is in use.
+type Bounded struct {
+	mtx    sync.Mutex
+	ring   *Ring
+	c      float64
+	loads  map[string]int
+	total  int
+	owners int
+}
+
+// NewBounded returns a Bounded assigning keys to the nodes of r with
+// the capacity factor c, which must be greater than 1. Values around
+// 1.25 trade little extra movement of keys for a tight bound.
+func NewBounded(r *Ring, c float64) *Bounded {
+	if !(c > 1) {
+		panic("ring: capacity factor must be greater than 1")
+	}
+
+	// Nodes of low weight on a ketama ring may own no points at all
+	// and can never take any load.
+	var owners map[string]bool = make(map[string]bool)
+	for _, p := range r.points {
+		owners[p.node] = true
+	}
+	return &Bounded{ring: r, c: c, loads: make(map[string]int), owners: len(owners)}
+}
+
+// capacity returns the maximum load of a node after adding one more
+// key. The load is spread over the nodes which own points on the ring.
+func (b *Bounded) capacity() int {
+	return int(math.Ceil(b.c * float64(b.total+1) / float64(b.owners)))
+}
+
+// Acquire returns the node key is assigned to and adds one to its load,
+// or returns false if the ring is empty. Every successful Acquire must
+// be followed by a Release of the node once the key is done with.
+func (b *Bounded) Acquire(key []byte) (string, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	var points []point = b.ring.points
+	if len(points) == 0 {
+		return "", false
+	}
+
+	var capacity int = b.capacity()
+	var start int = b.ring.search(nzaat.Checksum(key))
+	for i := 0; i < len(points); i++ {
+		var node string = points[(start+i)%len(points)].node
+		if b.loads[node] < capacity {
+			b.loads[node]++
+			b.total++
+			return node, true
+		}
+	}
+
+	// The capacities of the nodes owning points add up to more than
+	// the total load, so this is not reached.
+	return "", false
+}
+
+// Release takes one off the load of node, which must have been
+// returned by Acquire.
+func (b *Bounded) Release(node string) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.loads[node] > 0 {
+		b.loads[node]--
+		b.total--
+	}
+}
+
+// Load returns the current load of node.
+func (b *Bounded) Load(node string) int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.loads[node]
+}
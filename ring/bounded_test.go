@@ -0,0 +1,72 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package ring
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// Test that a hot key spills over to other nodes once its node is at
+// capacity, and that no node exceeds the bound.
+func TestBounded(t *testing.T) {
+	var b *Bounded = NewBounded(New(0, "a", "b", "c", "d"), 1.25)
+	var home, _ = b.ring.Pick([]byte("hot"))
+	var acquired []string
+
+	for i := 0; i < 1000; i++ {
+		var key string = "hot"
+		if i%2 == 1 {
+			key = "key-" + strconv.Itoa(i)
+		}
+		node, ok := b.Acquire([]byte(key))
+		if !ok {
+			t.Fatal("Acquire failed")
+		}
+		acquired = append(acquired, node)
+	}
+
+	var bound int = int(math.Ceil(1.25 * 1000 / 4))
+	for _, n := range b.ring.Nodes() {
+		if l := b.Load(n); l > bound {
+			t.Errorf("Node %s has a load of %d, more than %d", n, l, bound)
+		}
+	}
+	if b.Load(home) != bound {
+		t.Errorf("Home node of the hot key has a load of %d, expected %d", b.Load(home), bound)
+	}
+
+	for _, n := range acquired {
+		b.Release(n)
+	}
+	for _, n := range b.ring.Nodes() {
+		if l := b.Load(n); l != 0 {
+			t.Errorf("Node %s has a load of %d after releasing everything", n, l)
+		}
+	}
+	if node, _ := b.Acquire([]byte("hot")); node != home {
+		t.Errorf("Idle hot key went to %s instead of %s", node, home)
+	}
+}
+
+// Test acquiring from an empty ring.
+func TestBoundedEmpty(t *testing.T) {
+	if _, ok := NewBounded(New(0), 1.5).Acquire([]byte("a")); ok {
+		t.Error("Acquire on an empty ring succeeded")
+	}
+}
+
+// Test a ketama ring where a node of low weight owns no points.
+func TestBoundedKetamaWeights(t *testing.T) {
+	var b *Bounded = NewBounded(NewKetama(KetamaNode{"a", 100}, KetamaNode{"b", 1}), 1.25)
+
+	for i := 0; i < 100; i++ {
+		if _, ok := b.Acquire([]byte("key-" + strconv.Itoa(i))); !ok {
+			t.Fatalf("Acquire %d failed", i)
+		}
+	}
+}
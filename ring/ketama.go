@@ -0,0 +1,96 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package ring
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// ketamaLabels is the number of labels per node of average weight, each
+// of which yields four points.
+const ketamaLabels = 40
+
+// KetamaNode is a node of a ketama ring with its weight.
+type KetamaNode struct {
+	// Name is the label of the node, which for memcached clients is
+	// usually "host:port".
+	Name string
+
+	// Weight is the relative capacity of the node, such as its memory.
+	Weight float64
+}
+
+// NewKetama returns a ring placing its nodes the way libketama does, for
+// memcached style clients moving their node selection to this package.
+// Every node gets floor(40 · n · w/W) labels, where n is the number of
+// nodes, w the weight of the node and W the total weight; that is 40
+// labels for every node when the weights are equal. The labels are the
+// node name, a dash and the label number, and each label is hashed to
+// 16 octets with nzaat.ChecksumN, which are split into four little
+// endian points.
+//
+// The scheme, and so the shares of the nodes and the keys moved when
+// nodes come and go, is that of libketama, but the positions come from
+// NZAAT instead of MD5, so the actual assignment of keys differs from
+// that of a libketama client.
+//
+// Since every point depends on the total weight, adding or removing a
+// node places all nodes anew. Add adds nodes of weight 1.
+func NewKetama(nodes ...KetamaNode) *Ring {
+	var r *Ring = &Ring{weights: make(map[string]float64)}
+	for _, n := range nodes {
+		r.AddWeighted(n.Name, n.Weight)
+	}
+	return r
+}
+
+// AddWeighted adds the node name with the given weight to a ring
+// created by NewKetama, or changes the weight of the node if it is
+// already there. It panics for other rings and for weights which are
+// negative or not finite.
+func (r *Ring) AddWeighted(name string, weight float64) {
+	if r.weights == nil {
+		panic("ring: AddWeighted on a ring not created by NewKetama")
+	}
+	if !(weight >= 0) || math.IsInf(weight, 1) {
+		panic("ring: weight must be finite and not negative")
+	}
+
+	if _, ok := r.weights[name]; !ok {
+		r.nodes = append(r.nodes, name)
+	}
+	r.weights[name] = weight
+	r.placeKetama()
+}
+
+// placeKetama places all nodes of a ketama ring on it.
+func (r *Ring) placeKetama() {
+	var total float64
+	for _, w := range r.weights {
+		total += w
+	}
+
+	r.points = r.points[:0]
+	if total == 0 {
+		return
+	}
+
+	var n float64 = float64(len(r.nodes))
+	for _, name := range r.nodes {
+		var labels int = int(math.Floor(r.weights[name] / total * ketamaLabels * n))
+		for i := 0; i < labels; i++ {
+			var digest []byte = nzaat.ChecksumN([]byte(name+"-"+strconv.Itoa(i)), 16)
+			for j := 0; j < 16; j += 4 {
+				r.points = append(r.points, point{binary.LittleEndian.Uint32(digest[j:]), name})
+			}
+		}
+	}
+	r.sort()
+}
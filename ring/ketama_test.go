@@ -0,0 +1,76 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package ring
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test the number of points per node and the shares of weighted nodes.
+func TestKetama(t *testing.T) {
+	var r *Ring = NewKetama(
+		KetamaNode{"10.0.0.1:11211", 1},
+		KetamaNode{"10.0.0.2:11211", 1},
+		KetamaNode{"10.0.0.3:11211", 2},
+	)
+
+	var points map[string]int = make(map[string]int)
+	for _, p := range r.points {
+		points[p.node]++
+	}
+	// 40 · 3 · ¼ = 30 labels and 40 · 3 · ½ = 60 labels.
+	if points["10.0.0.1:11211"] != 120 || points["10.0.0.2:11211"] != 120 || points["10.0.0.3:11211"] != 240 {
+		t.Errorf("Got points %v, expected 120, 120 and 240", points)
+	}
+
+	var counts map[string]int = make(map[string]int)
+	for i := 0; i < 40000; i++ {
+		node, _ := r.Pick([]byte("key:" + strconv.Itoa(i)))
+		counts[node]++
+	}
+	if n := counts["10.0.0.3:11211"]; n < 16000 || n > 24000 {
+		t.Errorf("The node of weight 2 got %d of 40000 keys, expected about 20000", n)
+	}
+}
+
+// Test that equal weights give 160 points per node and that Add and
+// Remove place the nodes anew.
+func TestKetamaAddRemove(t *testing.T) {
+	var r *Ring = NewKetama(KetamaNode{"a", 1}, KetamaNode{"b", 1})
+	if len(r.points) != 320 {
+		t.Errorf("Got %d points, expected 320", len(r.points))
+	}
+
+	r.Add("c")
+	if len(r.points) != 480 || len(r.Nodes()) != 3 {
+		t.Errorf("Got %d points for %d nodes, expected 480 for 3", len(r.points), len(r.Nodes()))
+	}
+
+	var before map[string]string = make(map[string]string)
+	for i := 0; i < 5000; i++ {
+		var key string = strconv.Itoa(i)
+		before[key], _ = r.Pick([]byte(key))
+	}
+	if !r.Remove("c") || len(r.points) != 320 {
+		t.Fatalf("Got %d points after removing c, expected 320", len(r.points))
+	}
+	for key, old := range before {
+		if node, _ := r.Pick([]byte(key)); old != "c" && node != old {
+			t.Fatalf("Key %s moved from %s to %s", key, old, node)
+		}
+	}
+}
+
+// Test that AddWeighted is refused on plain rings.
+func TestAddWeightedPlain(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("AddWeighted did not panic on a plain ring")
+		}
+	}()
+	New(0).AddWeighted("a", 1)
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package ring implements a consistent hash ring with NZAAT. Every node
+// is placed on a circle of 2³² points a number of times, and a key is
+// assigned to the first node point at or after the hash of the key.
+//
+// NewKetama creates rings whose points are placed like those of
+// libketama, with shares proportional to node weights. NewBounded puts
+// an upper bound on the load of every node of a ring.
+package ring
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// DefaultReplicas is the number of points per node used if New is
+// given no positive number.
+const DefaultReplicas = 160
+
+type point struct {
+	hash uint32
+	node string
+}
+
+// Ring is a consistent hash ring. It may be used for picking from
+// several goroutines, but not while it is being changed.
+type Ring struct {
+	replicas int
+	points   []point
+	nodes    []string
+
+	// weights is set for rings created by NewKetama, and holds the
+	// weight of every node.
+	weights map[string]float64
+}
+
+// New returns a ring with the given nodes, placing every node at the
+// given number of points.
+func New(replicas int, nodes ...string) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+
+	var r *Ring = &Ring{replicas: replicas}
+	for _, name := range nodes {
+		r.Add(name)
+	}
+	return r
+}
+
+// pointHash returns the position of the i'th point of node.
+func pointHash(node string, i int) uint32 {
+	var d nzaat.Digest
+	d.WriteLengthPrefixedString(node)
+	d.WriteUint32(uint32(i), binary.BigEndian)
+	return d.Sum32()
+}
+
+func (r *Ring) sort() {
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].node < r.points[j].node
+	})
+}
+
+// Add places the node name on the ring, if it is not already there.
+func (r *Ring) Add(name string) {
+	for _, n := range r.nodes {
+		if n == name {
+			return
+		}
+	}
+
+	if r.weights != nil {
+		r.AddWeighted(name, 1)
+		return
+	}
+
+	r.nodes = append(r.nodes, name)
+	for i := 0; i < r.replicas; i++ {
+		r.points = append(r.points, point{pointHash(name, i), name})
+	}
+	r.sort()
+}
+
+// Remove takes the node name off the ring. It reports whether the node
+// was present.
+func (r *Ring) Remove(name string) bool {
+	var found bool
+	for i, n := range r.nodes {
+		if n == name {
+			r.nodes = append(r.nodes[:i], r.nodes[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	if r.weights != nil {
+		delete(r.weights, name)
+		r.placeKetama()
+		return true
+	}
+
+	var points []point = r.points[:0]
+	for _, p := range r.points {
+		if p.node != name {
+			points = append(points, p)
+		}
+	}
+	r.points = points
+	return true
+}
+
+// Nodes returns the names of the nodes on the ring, in the order they
+// were added.
+func (r *Ring) Nodes() []string {
+	return append([]string(nil), r.nodes...)
+}
+
+// search returns the index of the first point at or after h, wrapping
+// around to the start of the ring.
+func (r *Ring) search(h uint32) int {
+	var i int = sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return i
+}
+
+// Pick returns the node key is assigned to, or false if the ring is
+// empty.
+func (r *Ring) Pick(key []byte) (string, bool) {
+	if len(r.points) == 0 {
+		return "", false
+	}
+	return r.points[r.search(nzaat.Checksum(key))].node, true
+}
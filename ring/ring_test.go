@@ -0,0 +1,65 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package ring
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test that keys are spread evenly and adding a node only moves keys
+// to the new node.
+func TestPick(t *testing.T) {
+	var r *Ring = New(0, "a", "b", "c", "d")
+	var before map[string]string = make(map[string]string)
+	var counts map[string]int = make(map[string]int)
+
+	for i := 0; i < 10000; i++ {
+		var key string = "user:" + strconv.Itoa(i)
+		node, ok := r.Pick([]byte(key))
+		if !ok {
+			t.Fatal("Pick failed on a non-empty ring")
+		}
+		before[key] = node
+		counts[node]++
+	}
+	for node, n := range counts {
+		if n < 1800 || n > 3200 {
+			t.Errorf("Node %s got %d of 10000 keys", node, n)
+		}
+	}
+
+	r.Add("e")
+	var moved int
+	for key, node := range before {
+		after, _ := r.Pick([]byte(key))
+		if after != node {
+			if after != "e" {
+				t.Fatalf("Key %s moved from %s to %s", key, node, after)
+			}
+			moved++
+		}
+	}
+	if moved < 1000 || moved > 3000 {
+		t.Errorf("%d of 10000 keys moved to the new node", moved)
+	}
+
+	if !r.Remove("e") || r.Remove("e") {
+		t.Error("Remove gave wrong results")
+	}
+	for key, node := range before {
+		if after, _ := r.Pick([]byte(key)); after != node {
+			t.Fatalf("Key %s is at %s after removing the new node, was at %s", key, after, node)
+		}
+	}
+}
+
+// Test the empty ring.
+func TestEmpty(t *testing.T) {
+	if _, ok := New(10).Pick([]byte("key")); ok {
+		t.Error("Pick succeeded on an empty ring")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrColumn is returned, wrapped, for key columns which a record or
+// header does not have.
+var ErrColumn = errors.New("nzaat: no such column")
+
+// RowHasher computes canonical checksums of records, such as those read
+// by encoding/csv, from a set of key columns, as row fingerprints for
+// change data capture. Every key field is hashed with its length in
+// front of it, so that no choice of delimiter or quoting in the input
+// can make different rows hash alike the way "a,b"+"c" and "a"+"b,c"
+// would. A RowHasher can be used concurrently.
+type RowHasher struct {
+	columns []int
+	names   []string
+}
+
+// NewRowHasher returns a RowHasher hashing the fields at the given
+// column indexes in the given order, or all fields if there are none.
+func NewRowHasher(columns ...int) *RowHasher {
+	return &RowHasher{columns: columns}
+}
+
+// NewRowHasherHeader returns a RowHasher hashing the columns named
+// names in the header record. The fields are hashed together with their
+// names in the order of the names, so the checksum of a row stays the
+// same when the columns of an export are reordered. It returns an error
+// if header lacks any of the names, or if there are no names, since
+// hashing all fields like NewRowHasher would depend on their order.
+func NewRowHasherHeader(header []string, names ...string) (*RowHasher, error) {
+	if len(names) == 0 {
+		return nil, errors.New("nzaat: no key column names given")
+	}
+
+	var h *RowHasher = &RowHasher{names: append([]string(nil), names...)}
+	var index map[string]int = make(map[string]int, len(header))
+
+	for i, name := range header {
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+	sort.Strings(h.names)
+	for _, name := range h.names {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrColumn, name)
+		}
+		h.columns = append(h.columns, i)
+	}
+	return h, nil
+}
+
+// Sum32 returns the checksum of the key columns of record. It returns
+// an error if record is too short to have all of them.
+func (h *RowHasher) Sum32(record []string) (uint32, error) {
+	var d Digest
+
+	if len(h.columns) == 0 {
+		d.WriteUvarint(uint64(len(record)))
+		for _, field := range record {
+			d.WriteLengthPrefixedString(field)
+		}
+		return d.Sum32(), nil
+	}
+
+	d.WriteUvarint(uint64(len(h.columns)))
+	for i, c := range h.columns {
+		if c < 0 || c >= len(record) {
+			return 0, fmt.Errorf("%w: %d of %d", ErrColumn, c, len(record))
+		}
+		if h.names != nil {
+			d.WriteLengthPrefixedString(h.names[i])
+		}
+		d.WriteLengthPrefixedString(record[c])
+	}
+	return d.Sum32(), nil
+}
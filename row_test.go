@@ -0,0 +1,75 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test that fields can't be shifted between columns.
+func TestRowHasherDelimiters(t *testing.T) {
+	var h *RowHasher = NewRowHasher()
+
+	a, _ := h.Sum32([]string{"a,b", "c"})
+	b, _ := h.Sum32([]string{"a", "b,c"})
+	c, _ := h.Sum32([]string{"a", "b", "c"})
+	if a == b || a == c || b == c {
+		t.Errorf("Got colliding row checksums %08x, %08x, %08x", a, b, c)
+	}
+}
+
+// Test hashing key columns by index.
+func TestRowHasherColumns(t *testing.T) {
+	var h *RowHasher = NewRowHasher(0, 2)
+
+	a, err := h.Sum32([]string{"1", "x", "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := h.Sum32([]string{"1", "y", "alice"}); a != b {
+		t.Errorf("A non-key column changes the checksum: %08x, %08x", a, b)
+	}
+	if b, _ := h.Sum32([]string{"2", "x", "alice"}); a == b {
+		t.Errorf("A key column does not change the checksum: %08x", a)
+	}
+	if _, err := h.Sum32([]string{"1", "x"}); !errors.Is(err, ErrColumn) {
+		t.Errorf("Short record: got %v, expected ErrColumn", err)
+	}
+}
+
+// Test that hashing by column name is independent of the column order.
+func TestRowHasherHeader(t *testing.T) {
+	var sums []uint32
+
+	for _, in := range []string{"id,name,note\n1,alice,x\n", "note,name,id\ny,alice,1\n"} {
+		records, err := csv.NewReader(strings.NewReader(in)).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		h, err := NewRowHasherHeader(records[0], "name", "id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum, err := h.Sum32(records[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sums = append(sums, sum)
+	}
+	if sums[0] != sums[1] {
+		t.Errorf("Reordered columns: got %08x and %08x", sums[0], sums[1])
+	}
+
+	if _, err := NewRowHasherHeader([]string{"id"}, "name"); !errors.Is(err, ErrColumn) {
+		t.Errorf("Missing column: got %v, expected ErrColumn", err)
+	}
+	if _, err := NewRowHasherHeader([]string{"id"}); err == nil {
+		t.Error("Header hasher without key columns was accepted")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package sampling makes deterministic sampling decisions by hashing
+// identifiers with a seeded NZAAT, so that all processes sharing the
+// seed and rate agree on which identifiers are kept. Sampler samples
+// arbitrary keys such as user or session IDs, so that log processors
+// keep all entries of the same entities across hosts and restarts.
+//
+// TraceIDSampler is made to back an OpenTelemetry trace sampler, which
+// needs no more than a small adapter, since trace.TraceID is a [16]byte:
+//
+//	type otelSampler struct {
+//		s sampling.TraceIDSampler
+//	}
+//
+//	func (o otelSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+//		var ts trace.TraceState = trace.SpanContextFromContext(p.ParentContext).TraceState()
+//		if o.s.Sample(p.TraceID) {
+//			return sdktrace.SamplingResult{Decision: sdktrace.RecordAndSample, Tracestate: ts}
+//		}
+//		return sdktrace.SamplingResult{Decision: sdktrace.Drop, Tracestate: ts}
+//	}
+//
+//	func (o otelSampler) Description() string {
+//		return o.s.Description()
+//	}
+package sampling
+
+import (
+	"fmt"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// threshold returns the number of hash values out of 2³² which are
+// kept at rate.
+func threshold(rate float64) uint64 {
+	switch {
+	case rate <= 0:
+		return 0
+	case rate >= 1:
+		return 1 << 32
+	}
+	return uint64(rate * (1 << 32))
+}
+
+// Sampler keeps or drops keys by their hash. A key kept at some rate is
+// also kept at every higher rate with the same seed.
+type Sampler struct {
+	// Rate is the fraction of keys to keep, between 0 and 1.
+	Rate float64
+
+	// Seed selects the hash function. Processes must share it to make
+	// the same decisions.
+	Seed uint32
+}
+
+// Keep reports whether key is kept.
+func (s Sampler) Keep(key []byte) bool {
+	var h nzaat.Hash
+
+	h.SetSeed(nzaat.NewSeed(s.Seed))
+	h.Write(key)
+	return uint64(h.Sum32()) < threshold(s.Rate)
+}
+
+// KeepString is like Keep for a string key.
+func (s Sampler) KeepString(key string) bool {
+	var h nzaat.Hash
+
+	h.SetSeed(nzaat.NewSeed(s.Seed))
+	h.WriteString(key)
+	return uint64(h.Sum32()) < threshold(s.Rate)
+}
+
+// TraceIDSampler samples traces by their ID. A trace kept at some rate
+// is also kept at every higher rate with the same seed.
+type TraceIDSampler struct {
+	// Rate is the fraction of traces to keep, between 0 and 1.
+	Rate float64
+
+	// Seed selects the hash function. Services must share it to make
+	// the same decisions.
+	Seed uint32
+}
+
+// Sample reports whether the trace with the given ID is kept.
+func (s TraceIDSampler) Sample(traceID [16]byte) bool {
+	return Sampler{Rate: s.Rate, Seed: s.Seed}.Keep(traceID[:])
+}
+
+// Description describes the sampler, as OpenTelemetry samplers do.
+func (s TraceIDSampler) Description() string {
+	return fmt.Sprintf("NZAATTraceIDSampler{rate=%g,seed=%d}", s.Rate, s.Seed)
+}
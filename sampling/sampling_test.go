@@ -0,0 +1,100 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package sampling
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+)
+
+func traceID(i int) [16]byte {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[8:], uint64(i)*0x9e3779b97f4a7c15)
+	return id
+}
+
+// Test that the sampled fraction matches the rate and that decisions
+// are nested across rates.
+func TestTraceIDSampler(t *testing.T) {
+	var low TraceIDSampler = TraceIDSampler{Rate: 0.1, Seed: 7}
+	var high TraceIDSampler = TraceIDSampler{Rate: 0.5, Seed: 7}
+	var nlow, nhigh int
+
+	for i := 0; i < 20000; i++ {
+		var id [16]byte = traceID(i)
+		var l, h bool = low.Sample(id), high.Sample(id)
+		if l && !h {
+			t.Fatalf("Trace %d kept at rate 0.1 but not at 0.5", i)
+		}
+		if l {
+			nlow++
+		}
+		if h {
+			nhigh++
+		}
+	}
+	if nlow < 1800 || nlow > 2200 || nhigh < 9600 || nhigh > 10400 {
+		t.Errorf("Kept %d and %d of 20000 traces", nlow, nhigh)
+	}
+}
+
+// Test the extreme rates and that the seed matters.
+func TestTraceIDSamplerRates(t *testing.T) {
+	var differ int
+
+	for i := 0; i < 1000; i++ {
+		var id [16]byte = traceID(i)
+		if (TraceIDSampler{Rate: 0}).Sample(id) || !(TraceIDSampler{Rate: 1}).Sample(id) {
+			t.Fatal("Rates 0 and 1 not respected")
+		}
+		if (TraceIDSampler{Rate: 0.5, Seed: 1}).Sample(id) != (TraceIDSampler{Rate: 0.5, Seed: 2}).Sample(id) {
+			differ++
+		}
+	}
+	if differ < 400 {
+		t.Errorf("Only %d of 1000 decisions differ between seeds", differ)
+	}
+}
+
+// Test that Sampler keeps the expected fraction of keys, agrees between
+// Keep and KeepString and is nested across rates.
+func TestSampler(t *testing.T) {
+	var low Sampler = Sampler{Rate: 0.01, Seed: 3}
+	var high Sampler = Sampler{Rate: 0.2, Seed: 3}
+	var nlow, nhigh int
+
+	for i := 0; i < 50000; i++ {
+		var key string = "user-" + strconv.Itoa(i)
+		var l, h bool = low.Keep([]byte(key)), high.Keep([]byte(key))
+		if l != low.KeepString(key) || h != high.KeepString(key) {
+			t.Fatalf("Keep and KeepString disagree on %q", key)
+		}
+		if l && !h {
+			t.Fatalf("%q kept at rate 0.01 but not at 0.2", key)
+		}
+		if l {
+			nlow++
+		}
+		if h {
+			nhigh++
+		}
+	}
+	if nlow < 400 || nlow > 600 || nhigh < 9500 || nhigh > 10500 {
+		t.Errorf("Kept %d and %d of 50000 keys", nlow, nhigh)
+	}
+}
+
+// Test that TraceIDSampler makes the same decisions as Sampler on the
+// trace ID.
+func TestTraceIDSamplerIsSampler(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		var id [16]byte = traceID(i)
+		if (TraceIDSampler{Rate: 0.3, Seed: 9}).Sample(id) != (Sampler{Rate: 0.3, Seed: 9}).Keep(id[:]) {
+			t.Fatalf("Decisions for trace %d differ", i)
+		}
+	}
+}
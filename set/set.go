@@ -0,0 +1,219 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package set provides a generic hash set of strings or byte slices.
+//
+// The set is an open addressing table with linear probing. Since the
+// NZAT hash never yields 0, a stored hash of 0 marks an empty slot and
+// no separate occupancy information needs to be kept.
+//
+// The unseeded NZAT hash is public, so keys colliding under it are easy
+// to compute. Sets holding keys from untrusted sources should be created
+// with NewSeeded and a random seed, and can watch for attacks against
+// the seed with SetFloodAlarm.
+package set
+
+import "github.com/caoimhechaos/golang-nzaat"
+
+const minSize = 8
+
+// Set is a set of strings or byte slices. The zero value is an empty
+// set ready to use. A Set is not safe for concurrent use.
+type Set[T ~string | ~[]byte] struct {
+	hashes   []uint32
+	keys     []T
+	count    int
+	seed     nzaat.Digest
+	maxProbe int
+	alarm    func(probes int)
+}
+
+// New returns a new set containing elems.
+func New[T ~string | ~[]byte](elems ...T) *Set[T] {
+	var s *Set[T] = new(Set[T])
+	for _, e := range elems {
+		s.Add(e)
+	}
+	return s
+}
+
+// NewSeeded returns a new set containing elems which hashes its keys
+// with the seeded NZAT hash selected by seed. Use nzaat.MakeSeed for a
+// seed which cannot be guessed by whoever supplies the keys.
+func NewSeeded[T ~string | ~[]byte](seed nzaat.Seed, elems ...T) *Set[T] {
+	var s *Set[T] = &Set[T]{seed: seed.Digest()}
+	for _, e := range elems {
+		s.Add(e)
+	}
+	return s
+}
+
+// SetFloodAlarm arranges for alarm to be called with the number of
+// slots probed whenever a lookup probes more than maxProbe slots. With
+// a seed unknown to an attacker such runs are very unlikely at the load
+// factors used, so the alarm is a sign that keys are being chosen to
+// collide. alarm must not modify the set. A nil alarm turns the
+// detection off again.
+func (s *Set[T]) SetFloodAlarm(maxProbe int, alarm func(probes int)) {
+	s.maxProbe = maxProbe
+	s.alarm = alarm
+}
+
+func (s *Set[T]) hashOf(k T) uint32 {
+	return nzaat.FinalNZAT(nzaat.Update(s.seed, []byte(k)))
+}
+
+// Find the slot holding k, or the empty slot where it would go.
+func (s *Set[T]) find(k T, h uint32) (int, bool) {
+	var mask int = len(s.hashes) - 1
+	var i int = int(h) & mask
+	var probes int = 1
+
+	for s.hashes[i] != 0 {
+		if s.hashes[i] == h && string(s.keys[i]) == string(k) {
+			s.checkProbes(probes)
+			return i, true
+		}
+		i = (i + 1) & mask
+		probes++
+	}
+
+	s.checkProbes(probes)
+	return i, false
+}
+
+func (s *Set[T]) checkProbes(probes int) {
+	if s.alarm != nil && probes > s.maxProbe {
+		s.alarm(probes)
+	}
+}
+
+func (s *Set[T]) grow() {
+	var hashes []uint32 = s.hashes
+	var keys []T = s.keys
+	var size int = minSize
+
+	if len(hashes) > 0 {
+		size = len(hashes) * 2
+	}
+
+	s.hashes = make([]uint32, size)
+	s.keys = make([]T, size)
+
+	for i, h := range hashes {
+		if h != 0 {
+			j, _ := s.find(keys[i], h)
+			s.hashes[j] = h
+			s.keys[j] = keys[i]
+		}
+	}
+}
+
+// Add inserts k into the set. It reports whether k was not already
+// present. Byte slices are copied before they are stored.
+func (s *Set[T]) Add(k T) bool {
+	if (s.count+1)*4 > len(s.hashes)*3 {
+		s.grow()
+	}
+
+	var h uint32 = s.hashOf(k)
+	i, ok := s.find(k, h)
+	if ok {
+		return false
+	}
+
+	s.hashes[i] = h
+	s.keys[i] = T(string(k))
+	s.count++
+	return true
+}
+
+// Contains reports whether k is in the set.
+func (s *Set[T]) Contains(k T) bool {
+	if s.count == 0 {
+		return false
+	}
+
+	_, ok := s.find(k, s.hashOf(k))
+	return ok
+}
+
+// Delete removes k from the set. It reports whether k was present.
+func (s *Set[T]) Delete(k T) bool {
+	if s.count == 0 {
+		return false
+	}
+
+	i, ok := s.find(k, s.hashOf(k))
+	if !ok {
+		return false
+	}
+
+	// Shift back any following entries which would no longer be
+	// reachable across the hole we are about to leave.
+	var mask int = len(s.hashes) - 1
+	var zero T
+	for j := (i + 1) & mask; s.hashes[j] != 0; j = (j + 1) & mask {
+		var home int = int(s.hashes[j]) & mask
+		if (j-home)&mask >= (j-i)&mask {
+			s.hashes[i] = s.hashes[j]
+			s.keys[i] = s.keys[j]
+			i = j
+		}
+	}
+
+	s.hashes[i] = 0
+	s.keys[i] = zero
+	s.count--
+	return true
+}
+
+// Len returns the number of elements in the set.
+func (s *Set[T]) Len() int {
+	return s.count
+}
+
+// Range calls f for each element of the set, in no particular order,
+// until f returns false. The set must not be modified by f.
+func (s *Set[T]) Range(f func(T) bool) {
+	for i, h := range s.hashes {
+		if h != 0 && !f(s.keys[i]) {
+			return
+		}
+	}
+}
+
+// Union returns a new set containing the elements of both s and o. The
+// new set uses the same seed as s.
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	var r *Set[T] = &Set[T]{seed: s.seed}
+	var add = func(k T) bool {
+		r.Add(k)
+		return true
+	}
+
+	s.Range(add)
+	o.Range(add)
+	return r
+}
+
+// Intersect returns a new set containing the elements present in both
+// s and o. The new set uses the same seed as s.
+func (s *Set[T]) Intersect(o *Set[T]) *Set[T] {
+	var r *Set[T] = &Set[T]{seed: s.seed}
+	var small, large *Set[T] = s, o
+
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+
+	small.Range(func(k T) bool {
+		if large.Contains(k) {
+			r.Add(k)
+		}
+		return true
+	})
+	return r
+}
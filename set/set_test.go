@@ -0,0 +1,124 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package set
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// collidingKeys returns n keys whose unseeded NZAT hashes agree in the
+// low 12 bits, as an attacker would compute them.
+func collidingKeys(n int) []string {
+	var keys []string
+
+	for i := 0; len(keys) < n; i++ {
+		var k string = "k" + strconv.Itoa(i)
+		if nzaat.ChecksumNZAT([]byte(k))&0xfff == 0x123 {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Test adding, looking up and deleting a few strings.
+func TestAddContainsDelete(t *testing.T) {
+	var s *Set[string] = New("a", "abc")
+
+	if s.Add("a") {
+		t.Error("Adding \"a\" twice reported it as new")
+	}
+	if !s.Contains("abc") || s.Contains("ab") {
+		t.Error("Contains gave wrong results")
+	}
+	if !s.Delete("a") || s.Delete("a") {
+		t.Error("Delete gave wrong results")
+	}
+	if s.Len() != 1 {
+		t.Errorf("Expected 1 element, got %d", s.Len())
+	}
+}
+
+// Test that the table stays consistent across growth and deletion.
+func TestManyElements(t *testing.T) {
+	var s Set[[]byte]
+
+	for i := 0; i < 1000; i++ {
+		s.Add([]byte(strconv.Itoa(i)))
+	}
+	for i := 0; i < 1000; i += 2 {
+		s.Delete([]byte(strconv.Itoa(i)))
+	}
+	for i := 0; i < 1000; i++ {
+		if s.Contains([]byte(strconv.Itoa(i))) != (i%2 == 1) {
+			t.Fatalf("Wrong membership for %d", i)
+		}
+	}
+	if s.Len() != 500 {
+		t.Errorf("Expected 500 elements, got %d", s.Len())
+	}
+}
+
+// Test that stored byte slices are not aliased with the caller's.
+func TestAddCopies(t *testing.T) {
+	var s Set[[]byte]
+	var k []byte = []byte("key")
+
+	s.Add(k)
+	k[0] = 'x'
+
+	if !s.Contains([]byte("key")) {
+		t.Error("Modifying the caller's slice changed the set")
+	}
+}
+
+// Test union and intersection.
+func TestUnionIntersect(t *testing.T) {
+	var a *Set[string] = New("a", "b", "c")
+	var b *Set[string] = New("b", "c", "d")
+
+	if u := a.Union(b); u.Len() != 4 || !u.Contains("a") || !u.Contains("d") {
+		t.Errorf("Unexpected union of %d elements", u.Len())
+	}
+	if i := a.Intersect(b); i.Len() != 2 || !i.Contains("b") || !i.Contains("c") {
+		t.Errorf("Unexpected intersection of %d elements", i.Len())
+	}
+}
+
+// Test that the flood alarm fires for colliding keys in an unseeded
+// set but not once the set uses a seed.
+func TestFloodAlarm(t *testing.T) {
+	var keys []string = collidingKeys(40)
+	var unseeded *Set[string] = New[string]()
+	var seeded *Set[string] = NewSeeded[string](nzaat.NewSeed(7))
+	var worst, seededWorst int
+
+	unseeded.SetFloodAlarm(16, func(probes int) {
+		worst = max(worst, probes)
+	})
+	seeded.SetFloodAlarm(16, func(probes int) {
+		seededWorst = max(seededWorst, probes)
+	})
+	for _, k := range keys {
+		unseeded.Add(k)
+		seeded.Add(k)
+	}
+
+	if worst < 30 {
+		t.Errorf("Unseeded set probed at most %d slots", worst)
+	}
+	if seededWorst != 0 {
+		t.Errorf("Seeded set raised the alarm after %d probes", seededWorst)
+	}
+	var union *Set[string] = seeded.Union(unseeded)
+	for _, k := range keys {
+		if !seeded.Contains(k) || !union.Contains(k) {
+			t.Fatalf("Seeded set lost %q", k)
+		}
+	}
+}
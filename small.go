@@ -0,0 +1,101 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+// smallSize is the longest input Checksum and ChecksumString hash
+// without a loop.
+const smallSize = 16
+
+// naf applies the NAF postprocess function to the state s.
+func naf(s uint32) uint32 {
+	s += s << 10
+	s ^= s >> 6
+	s += s << 3
+	s ^= s >> 11
+	s += s << 15
+	return s
+}
+
+// nzf applies the NZF postprocess function to the state s.
+func nzf(s uint32) uint32 {
+	s += s << 10
+	s ^= s >> 6
+	if s == 0 {
+		s++
+	}
+	s += s << 3
+	s ^= s >> 11
+	s += s << 15
+	return s
+}
+
+// sumSmall returns the state after absorbing p, which must not be
+// longer than smallSize, starting from the zero state. Entering the
+// switch at the case for len(p) and falling through absorbs the octets
+// in order without a loop.
+func sumSmall[T string | []byte](p T) uint32 {
+	var s uint32
+	var n int = len(p)
+
+	switch n {
+	case 16:
+		s = nup(s, p[n-16])
+		fallthrough
+	case 15:
+		s = nup(s, p[n-15])
+		fallthrough
+	case 14:
+		s = nup(s, p[n-14])
+		fallthrough
+	case 13:
+		s = nup(s, p[n-13])
+		fallthrough
+	case 12:
+		s = nup(s, p[n-12])
+		fallthrough
+	case 11:
+		s = nup(s, p[n-11])
+		fallthrough
+	case 10:
+		s = nup(s, p[n-10])
+		fallthrough
+	case 9:
+		s = nup(s, p[n-9])
+		fallthrough
+	case 8:
+		s = nup(s, p[n-8])
+		fallthrough
+	case 7:
+		s = nup(s, p[n-7])
+		fallthrough
+	case 6:
+		s = nup(s, p[n-6])
+		fallthrough
+	case 5:
+		s = nup(s, p[n-5])
+		fallthrough
+	case 4:
+		s = nup(s, p[n-4])
+		fallthrough
+	case 3:
+		s = nup(s, p[n-3])
+		fallthrough
+	case 2:
+		s = nup(s, p[n-2])
+		fallthrough
+	case 1:
+		s = nup(s, p[n-1])
+	}
+	return s
+}
+
+// ChecksumString returns the NZAAT checksum of s.
+func ChecksumString(s string) uint32 {
+	if len(s) <= smallSize {
+		return naf(sumSmall(s))
+	}
+	return naf(updateString(0, s))
+}
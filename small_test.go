@@ -0,0 +1,56 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// Test that Checksum and ChecksumString agree with a Digest on all
+// lengths around the small-input limit.
+func TestChecksumSmall(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(5, 6))
+	var buf []byte = make([]byte, 2*smallSize+1)
+
+	for i := range buf {
+		buf[i] = byte(rng.Uint32())
+	}
+	for n := 0; n <= len(buf); n++ {
+		var d Digest
+		d.Write(buf[:n])
+		var want uint32 = d.Sum32()
+
+		if got := Checksum(buf[:n]); got != want {
+			t.Errorf("Checksum, length %d: got %08x, expected %08x", n, got, want)
+		}
+		if got := ChecksumString(string(buf[:n])); got != want {
+			t.Errorf("ChecksumString, length %d: got %08x, expected %08x", n, got, want)
+		}
+	}
+}
+
+// Test that hashing short keys does not allocate.
+func TestChecksumSmallAllocs(t *testing.T) {
+	var key []byte = []byte("user:12345")
+	var skey string = "user:12345"
+
+	if n := testing.AllocsPerRun(100, func() { Checksum(key) }); n != 0 {
+		t.Errorf("Checksum allocates %v times", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { ChecksumString(skey) }); n != 0 {
+		t.Errorf("ChecksumString allocates %v times", n)
+	}
+}
+
+func BenchmarkChecksumSmall(b *testing.B) {
+	var key []byte = []byte("user:12345")
+
+	b.SetBytes(int64(len(key)))
+	for i := 0; i < b.N; i++ {
+		updateSink = Checksum(key)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"io"
+	"os"
+)
+
+// writeZeroes adds n zero bytes to the running hash.
+func (d *Digest) writeZeroes(n int64) {
+	var s uint32 = uint32(*d)
+	for ; n > 0; n-- {
+		s++
+		s += s << 10
+		s ^= s >> 6
+	}
+	*d = Digest(s)
+}
+
+// ChecksumFileSparse returns the NZAAT checksum of the file at path,
+// which may be sparse. Where the platform and file system can locate
+// holes with SEEK_DATA and SEEK_HOLE, the holes are hashed as the runs
+// of zero bytes they read as, without reading them. This saves the I/O
+// and copying of preallocated space in VM images and databases; since
+// every zero byte still changes the state, the hashing itself is not
+// skipped. Elsewhere, the file is read like any other.
+func ChecksumFileSparse(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	var d Digest
+	if fi.Mode().IsRegular() {
+		ok, err := d.writeSparse(f, fi.Size())
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return d.Sum32(), nil
+		}
+	}
+
+	if _, err = io.Copy(&d, f); err != nil {
+		return 0, err
+	}
+	return d.Sum32(), nil
+}
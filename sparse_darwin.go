@@ -0,0 +1,13 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+// Values of whence for locating data and holes with lseek. Darwin has
+// them the other way around from everyone else.
+const (
+	seekHole = 3
+	seekData = 4
+)
@@ -0,0 +1,15 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !(linux || freebsd || darwin || illumos || solaris)
+
+package nzaat
+
+import "os"
+
+// writeSparse is not supported on this platform.
+func (d *Digest) writeSparse(f *os.File, size int64) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build linux || freebsd || illumos || solaris
+
+package nzaat
+
+// Values of whence for locating data and holes with lseek.
+const (
+	seekData = 3
+	seekHole = 4
+)
@@ -0,0 +1,58 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test hashing files with holes at the start, in the middle and at the
+// end, and one without any.
+func TestChecksumFileSparse(t *testing.T) {
+	var name string = filepath.Join(t.TempDir(), "sparse")
+
+	for _, layout := range [][]int64{
+		{},
+		{0},
+		{1 << 20},
+		{0, 3 << 20},
+		{1 << 20, 5 << 20},
+	} {
+		var size int64 = 8 << 20
+		var want []byte = make([]byte, size)
+
+		f, err := os.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, off := range layout {
+			f.WriteAt([]byte("data"), off)
+			copy(want[off:], "data")
+		}
+		f.Truncate(size)
+		f.Close()
+
+		sum, err := ChecksumFileSparse(name)
+		if err != nil || sum != Checksum(want) {
+			t.Errorf("Data at %v: got %08x, %v, expected %08x", layout, sum, err, Checksum(want))
+		}
+	}
+}
+
+// Test that writing zeroes is the same as writing zero bytes.
+func TestWriteZeroes(t *testing.T) {
+	var a, b Digest
+	a.WriteString("abc")
+	b.WriteString("abc")
+
+	a.writeZeroes(1000)
+	b.Write(make([]byte, 1000))
+	if a != b {
+		t.Errorf("Got state %08x, expected %08x", uint32(a), uint32(b))
+	}
+}
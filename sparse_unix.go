@@ -0,0 +1,55 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build linux || freebsd || darwin || illumos || solaris
+
+package nzaat
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+)
+
+// writeSparse adds the size bytes of f to d, skipping over holes. It
+// reports false, with d unchanged, if the file system can't tell where
+// the holes are.
+func (d *Digest) writeSparse(f *os.File, size int64) (bool, error) {
+	var off int64
+
+	for off < size {
+		data, err := f.Seek(off, seekData)
+		if errors.Is(err, syscall.ENXIO) {
+			// No more data; the rest of the file is a hole.
+			data = size
+		} else if err != nil {
+			if off == 0 {
+				_, err = f.Seek(0, io.SeekStart)
+				return false, err
+			}
+			return true, err
+		}
+
+		d.writeZeroes(data - off)
+		if data >= size {
+			break
+		}
+
+		hole, err := f.Seek(data, seekHole)
+		if err != nil {
+			return true, err
+		}
+		if _, err = f.Seek(data, io.SeekStart); err != nil {
+			return true, err
+		}
+		if _, err = io.CopyN(d, f, hole-data); err != nil {
+			return true, err
+		}
+		off = hole
+	}
+
+	return true, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "hash"
+
+// tagDigest returns the state after absorbing the length of tag as a
+// uvarint followed by tag itself.
+func tagDigest(tag string) Digest {
+	var d Digest
+	d.WriteLengthPrefixedString(tag)
+	return d
+}
+
+// taggedDigest is a Digest which returns to the state after its domain
+// tag on Reset rather than to the empty state.
+type taggedDigest struct {
+	Digest
+	init Digest
+}
+
+// NewTagged returns a new hash.Hash32 computing the NZAAT checksum of
+// data preceded by the length-prefixed domain tag. Subsystems hashing
+// the same bytes for different purposes should use different tags, so
+// that their identifiers cannot be mistaken for each other. Because of
+// the length prefix, no two tags yield the same starting state by
+// concatenation, e.g. tag "ab" with data "c" differs from tag "a" with
+// data "bc".
+func NewTagged(tag string) hash.Hash32 {
+	var init Digest = tagDigest(tag)
+	return &taggedDigest{Digest: init, init: init}
+}
+
+// Reset discards all data written so far but keeps the tag.
+func (d *taggedDigest) Reset() {
+	d.Digest = d.init
+}
+
+// ChecksumTagged returns the NZAAT checksum of data under the domain
+// tag, like a hash returned by NewTagged.
+func ChecksumTagged(tag string, data []byte) uint32 {
+	return naf(update(uint32(tagDigest(tag)), data))
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "testing"
+
+// Test that tagged hashes agree with their definition, survive Reset
+// and separate tags from data.
+func TestTagged(t *testing.T) {
+	var h = NewTagged("manifest")
+	var want uint32 = Checksum([]byte("\x08manifestabc"))
+
+	h.Write([]byte("abc"))
+	if res := h.Sum32(); res != want {
+		t.Errorf("Tagged hash returned %08x, expected %08x", res, want)
+	}
+	if res := ChecksumTagged("manifest", []byte("abc")); res != want {
+		t.Errorf("ChecksumTagged returned %08x, expected %08x", res, want)
+	}
+
+	h.Reset()
+	h.Write([]byte("abc"))
+	if res := h.Sum32(); res != want {
+		t.Errorf("Tagged hash after Reset returned %08x, expected %08x", res, want)
+	}
+
+	if ChecksumTagged("ab", []byte("c")) == ChecksumTagged("a", []byte("bc")) {
+		t.Error("Tags are not separated from the data")
+	}
+	if ChecksumTagged("", []byte("abc")) == Checksum([]byte("abc")) {
+		t.Error("Empty tag gave the untagged checksum")
+	}
+}
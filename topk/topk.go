@@ -0,0 +1,143 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package topk tracks the most frequent keys of a stream, the heavy
+// hitters, in bounded memory. The counts of all keys are estimated by a
+// Count-Min sketch over NZAAT, and a min-heap holds the k keys with the
+// highest estimates seen so far. Structures of several shards with the
+// same parameters can be merged.
+package topk
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/caoimhechaos/golang-nzaat/countmin"
+)
+
+// Item is a key with its estimated count.
+type Item struct {
+	Key   string
+	Count uint64
+}
+
+// items is a min-heap of items by count, which keeps the position of
+// every key in index.
+type items struct {
+	list  []Item
+	index map[string]int
+}
+
+func (h *items) Len() int {
+	return len(h.list)
+}
+
+func (h *items) Less(i, j int) bool {
+	return h.list[i].Count < h.list[j].Count
+}
+
+func (h *items) Swap(i, j int) {
+	h.list[i], h.list[j] = h.list[j], h.list[i]
+	h.index[h.list[i].Key] = i
+	h.index[h.list[j].Key] = j
+}
+
+func (h *items) Push(x any) {
+	var it Item = x.(Item)
+	h.index[it.Key] = len(h.list)
+	h.list = append(h.list, it)
+}
+
+func (h *items) Pop() any {
+	var it Item = h.list[len(h.list)-1]
+	h.list = h.list[:len(h.list)-1]
+	delete(h.index, it.Key)
+	return it
+}
+
+// TopK tracks the k most frequent keys. It is not safe for concurrent
+// use.
+type TopK struct {
+	k      int
+	sketch *countmin.Sketch
+	heap   items
+}
+
+// New returns an empty TopK tracking k keys, estimating counts with a
+// conservative-update Count-Min sketch of d rows of w counters with the
+// given seed. It panics if k, w or d are not positive.
+func New(k, w, d int, seed uint32) *TopK {
+	if k <= 0 {
+		panic("topk: k must be positive")
+	}
+	return &TopK{
+		k:      k,
+		sketch: countmin.NewConservative(w, d, seed),
+		heap:   items{index: make(map[string]int)},
+	}
+}
+
+// Add adds count occurrences of key.
+func (t *TopK) Add(key []byte, count uint64) {
+	t.sketch.Add(key, count)
+	t.offer(string(key), t.sketch.Count(key))
+}
+
+// AddString adds count occurrences of key.
+func (t *TopK) AddString(key string, count uint64) {
+	t.Add([]byte(key), count)
+}
+
+// offer updates the estimate of key, adding it to the heap if it is
+// among the top k.
+func (t *TopK) offer(key string, est uint64) {
+	if i, ok := t.heap.index[key]; ok {
+		t.heap.list[i].Count = est
+		heap.Fix(&t.heap, i)
+		return
+	}
+	if t.heap.Len() < t.k {
+		heap.Push(&t.heap, Item{Key: key, Count: est})
+		return
+	}
+	if est > t.heap.list[0].Count {
+		delete(t.heap.index, t.heap.list[0].Key)
+		t.heap.list[0] = Item{Key: key, Count: est}
+		t.heap.index[key] = 0
+		heap.Fix(&t.heap, 0)
+	}
+}
+
+// List returns the tracked keys with their estimated counts, most
+// frequent first. Estimates are never below the true counts.
+func (t *TopK) List() []Item {
+	var list []Item = append([]Item(nil), t.heap.list...)
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
+
+// Merge adds the counts of u to t, so that t tracks the top keys of
+// both streams. Both must have been created with the same parameters;
+// otherwise countmin.ErrIncompatible is returned.
+func (t *TopK) Merge(u *TopK) error {
+	if t.k != u.k {
+		return countmin.ErrIncompatible
+	}
+	if err := t.sketch.Merge(u.sketch); err != nil {
+		return err
+	}
+
+	var candidates []Item = append(t.heap.list, u.heap.list...)
+	t.heap = items{index: make(map[string]int)}
+	for _, it := range candidates {
+		t.offer(it.Key, t.sketch.CountString(it.Key))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package topk
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat/countmin"
+)
+
+// Test that the most frequent keys are found.
+func TestTopK(t *testing.T) {
+	var tk *TopK = New(10, 2000, 4, 1)
+	for i := 0; i < 5000; i++ {
+		tk.AddString("key"+strconv.Itoa(i), uint64(100000/(i+1)))
+	}
+
+	var list []Item = tk.List()
+	if len(list) != 10 {
+		t.Fatalf("Got %d items, expected 10", len(list))
+	}
+	for i, it := range list {
+		if want := "key" + strconv.Itoa(i); it.Key != want {
+			t.Errorf("Item %d: got %s, expected %s", i, it.Key, want)
+		}
+		if it.Count < uint64(100000/(i+1)) {
+			t.Errorf("Item %d: estimated %d, below the true %d", i, it.Count, 100000/(i+1))
+		}
+	}
+}
+
+// Test that keys growing late in the stream still enter the top.
+func TestLateHitter(t *testing.T) {
+	var tk *TopK = New(3, 1000, 4, 1)
+	for i := 0; i < 100; i++ {
+		tk.AddString("early"+strconv.Itoa(i), 10)
+	}
+	for i := 0; i < 50; i++ {
+		tk.AddString("late", 10)
+	}
+	if list := tk.List(); list[0].Key != "late" {
+		t.Errorf("Got %v, expected late first", list)
+	}
+}
+
+// Test that merged shards report the global heavy hitters.
+func TestMerge(t *testing.T) {
+	var a, b *TopK = New(5, 2000, 4, 3), New(5, 2000, 4, 3)
+
+	// Each shard sees half of every key's occurrences, plus local noise
+	// which is frequent in one shard only.
+	for i := 0; i < 1000; i++ {
+		var n uint64 = uint64(50000 / (i + 1))
+		a.AddString("key"+strconv.Itoa(i), n)
+		b.AddString("key"+strconv.Itoa(i), n)
+	}
+	a.AddString("local-a", 30000)
+	b.AddString("local-b", 30000)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, it := range a.List() {
+		got = append(got, it.Key)
+	}
+	var want []string = []string{"key0", "key1", "key2", "local-a", "local-b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Got %v, expected %v", got, want)
+			break
+		}
+	}
+
+	if err := New(5, 2000, 4, 3).Merge(New(6, 2000, 4, 3)); !errors.Is(err, countmin.ErrIncompatible) {
+		t.Errorf("Got %v, expected ErrIncompatible", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"time"
+)
+
+// Address family octets written ahead of IP addresses.
+const (
+	familyInvalid = 0
+	familyIPv4    = 4
+	familyIPv6    = 6
+)
+
+// WriteAddr adds the IP address a to the running hash. The encoding is
+// a family octet (4 or 6, or 0 for the zero Addr), the 4 or 16 address
+// octets and, for IPv6 addresses, the length-prefixed zone, which is
+// empty if there is none.
+// Like netip.Addr itself, this distinguishes IPv4 addresses from their
+// IPv4-mapped IPv6 form.
+func (d *Digest) WriteAddr(a netip.Addr) {
+	switch {
+	case a.Is4():
+		var b [4]byte = a.As4()
+		d.update(familyIPv4)
+		d.Write(b[:])
+	case a.Is6():
+		var b [16]byte = a.As16()
+		d.update(familyIPv6)
+		d.Write(b[:])
+		d.WriteLengthPrefixedString(a.Zone())
+	default:
+		d.update(familyInvalid)
+	}
+}
+
+// WriteIP adds the IP address ip to the running hash, using the same
+// encoding as WriteAddr. Like net.IP.Equal, it treats IPv4 addresses
+// and their IPv4-mapped IPv6 form as the same address, no matter
+// whether ip is stored in 4 or 16 octets.
+func (d *Digest) WriteIP(ip net.IP) {
+	a, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		d.update(familyInvalid)
+		return
+	}
+	d.WriteAddr(a.Unmap())
+}
+
+// WriteTime adds the instant t to the running hash, as the big-endian
+// 64-bit Unix time in seconds followed by the big-endian 32-bit
+// nanoseconds. The location and monotonic clock reading of t are not
+// part of the encoding, so times for which Equal reports true hash the
+// same.
+func (d *Digest) WriteTime(t time.Time) {
+	d.WriteUint64(uint64(t.Unix()), binary.BigEndian)
+	d.WriteUint32(uint32(t.Nanosecond()), binary.BigEndian)
+}
+
+// WriteUUID adds the 16 octets of the UUID u to the running hash. UUID
+// types defined as [16]byte, such as the one of github.com/google/uuid,
+// can be passed directly.
+func (d *Digest) WriteUUID(u [16]byte) {
+	d.Write(u[:])
+}
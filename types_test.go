@@ -0,0 +1,76 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+// Test that equivalent representations of addresses hash equally.
+func TestWriteIP(t *testing.T) {
+	var a, b, c, e Digest
+
+	a.WriteIP(net.ParseIP("192.0.2.1"))
+	b.WriteIP(net.ParseIP("192.0.2.1").To4())
+	c.WriteAddr(netip.MustParseAddr("192.0.2.1"))
+	e.WriteAddr(netip.MustParseAddr("::ffff:192.0.2.1"))
+
+	if a.Sum32() != b.Sum32() || a.Sum32() != c.Sum32() {
+		t.Error("Equivalent IPv4 addresses hash differently")
+	}
+	if a.Sum32() == e.Sum32() {
+		t.Error("netip.Addr did not distinguish the IPv4-mapped form")
+	}
+
+	a.Reset()
+	b.Reset()
+	a.WriteAddr(netip.MustParseAddr("fe80::1%eth0"))
+	b.WriteAddr(netip.MustParseAddr("fe80::1%eth1"))
+	if a.Sum32() == b.Sum32() {
+		t.Error("Zones were not hashed")
+	}
+
+	a.Reset()
+	b.Reset()
+	a.WriteAddr(netip.MustParseAddr("fe80::1"))
+	a.WriteLengthPrefixedString("eth0")
+	b.WriteAddr(netip.MustParseAddr("fe80::1%eth0"))
+	if a.Sum32() == b.Sum32() {
+		t.Error("Address without zone is ambiguous with the following data")
+	}
+}
+
+// Test that the location of a time does not matter.
+func TestWriteTime(t *testing.T) {
+	var a, b, c Digest
+	var now time.Time = time.Now()
+
+	a.WriteTime(now)
+	b.WriteTime(now.In(time.FixedZone("X", 3600)).Round(0))
+	c.WriteTime(now.Add(time.Nanosecond))
+
+	if a.Sum32() != b.Sum32() {
+		t.Error("Equal times hash differently")
+	}
+	if a.Sum32() == c.Sum32() {
+		t.Error("Different times hash equally")
+	}
+}
+
+// Test that UUIDs are hashed as their raw octets.
+func TestWriteUUID(t *testing.T) {
+	type uuid [16]byte
+	var d Digest
+	var u uuid = uuid{0: 1, 15: 2}
+
+	d.WriteUUID(u)
+	if d.Sum32() != Checksum(u[:]) {
+		t.Error("UUID was not hashed as its octets")
+	}
+}
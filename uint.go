@@ -0,0 +1,148 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "encoding/binary"
+
+// update adds the single octet b to the running hash.
+func (d *Digest) update(b byte) {
+	*d = Digest(nup(uint32(*d), b))
+}
+
+// writeUint adds the low n octets of v to the running hash, in the
+// given byte order. binary.BigEndian and binary.LittleEndian are
+// handled without going through a temporary buffer.
+func (d *Digest) writeUint(v uint64, n int, order binary.ByteOrder) {
+	var s uint32 = uint32(*d)
+
+	switch order {
+	case binary.BigEndian:
+		for i := n - 1; i >= 0; i-- {
+			s = nup(s, byte(v>>(8*i)))
+		}
+	case binary.LittleEndian:
+		for i := 0; i < n; i++ {
+			s = nup(s, byte(v>>(8*i)))
+		}
+	default:
+		var b [8]byte
+		switch n {
+		case 2:
+			order.PutUint16(b[:], uint16(v))
+		case 4:
+			order.PutUint32(b[:], uint32(v))
+		default:
+			order.PutUint64(b[:], v)
+		}
+		s = update(s, b[:n])
+	}
+
+	*d = Digest(s)
+}
+
+// WriteUint16 adds the two octets of v in the given byte order to the
+// running hash.
+func (d *Digest) WriteUint16(v uint16, order binary.ByteOrder) {
+	d.writeUint(uint64(v), 2, order)
+}
+
+// WriteUint32 adds the four octets of v in the given byte order to the
+// running hash.
+func (d *Digest) WriteUint32(v uint32, order binary.ByteOrder) {
+	d.writeUint(uint64(v), 4, order)
+}
+
+// WriteUint64 adds the eight octets of v in the given byte order to the
+// running hash.
+func (d *Digest) WriteUint64(v uint64, order binary.ByteOrder) {
+	d.writeUint(v, 8, order)
+}
+
+// SumUint16 returns the NZAAT checksum of the two octets of v in the
+// given byte order.
+func SumUint16(v uint16, order binary.ByteOrder) uint32 {
+	var d Digest
+	d.WriteUint16(v, order)
+	return d.Sum32()
+}
+
+// SumUint32 returns the NZAAT checksum of the four octets of v in the
+// given byte order.
+func SumUint32(v uint32, order binary.ByteOrder) uint32 {
+	var d Digest
+	d.WriteUint32(v, order)
+	return d.Sum32()
+}
+
+// SumUint64 returns the NZAAT checksum of the eight octets of v in the
+// given byte order.
+func SumUint64(v uint64, order binary.ByteOrder) uint32 {
+	var d Digest
+	d.WriteUint64(v, order)
+	return d.Sum32()
+}
+
+// ChecksumUint32 returns the NZAAT checksum of the four octets of v in
+// little-endian order, the same as SumUint32(v, binary.LittleEndian).
+// The update is fully unrolled, for hash tables and partitioners keyed
+// by machine words.
+func ChecksumUint32(v uint32) uint32 {
+	var s uint32 = nup(0, byte(v))
+	s = nup(s, byte(v>>8))
+	s = nup(s, byte(v>>16))
+	s = nup(s, byte(v>>24))
+	return naf(s)
+}
+
+// ChecksumUint64 returns the NZAAT checksum of the eight octets of v in
+// little-endian order, the same as SumUint64(v, binary.LittleEndian),
+// with the update fully unrolled like ChecksumUint32.
+func ChecksumUint64(v uint64) uint32 {
+	var s uint32 = nup(0, byte(v))
+	s = nup(s, byte(v>>8))
+	s = nup(s, byte(v>>16))
+	s = nup(s, byte(v>>24))
+	s = nup(s, byte(v>>32))
+	s = nup(s, byte(v>>40))
+	s = nup(s, byte(v>>48))
+	s = nup(s, byte(v>>56))
+	return naf(s)
+}
+
+// WriteUvarint adds v to the running hash in the varint encoding of
+// encoding/binary.
+func (d *Digest) WriteUvarint(v uint64) {
+	for v >= 0x80 {
+		d.update(byte(v) | 0x80)
+		v >>= 7
+	}
+	d.update(byte(v))
+}
+
+// WriteVarint adds v to the running hash in the zig-zag varint encoding
+// of encoding/binary.
+func (d *Digest) WriteVarint(v int64) {
+	var u uint64 = uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	d.WriteUvarint(u)
+}
+
+// WriteLengthPrefixed adds the length of b as a uvarint followed by b
+// itself to the running hash. Composite keys written as a sequence of
+// length-prefixed fields cannot be confused with each other the way
+// plain concatenations like "ab"+"c" and "a"+"bc" can.
+func (d *Digest) WriteLengthPrefixed(b []byte) {
+	d.WriteUvarint(uint64(len(b)))
+	d.Write(b)
+}
+
+// WriteLengthPrefixedString is like WriteLengthPrefixed for a string.
+func (d *Digest) WriteLengthPrefixedString(s string) {
+	d.WriteUvarint(uint64(len(s)))
+	d.WriteString(s)
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// A byte order the fast paths don't know about.
+type otherOrder struct {
+	binary.ByteOrder
+}
+
+// Test that the integer writers agree with hashing the encoded bytes.
+func TestWriteUint(t *testing.T) {
+	var v uint64 = 0x0102030405060708
+
+	for _, order := range []binary.ByteOrder{
+		binary.BigEndian, binary.LittleEndian, otherOrder{binary.BigEndian},
+	} {
+		var b []byte = make([]byte, 8)
+
+		order.PutUint16(b, uint16(v))
+		if SumUint16(uint16(v), order) != Checksum(b[:2]) {
+			t.Errorf("SumUint16 mismatch for %v", order)
+		}
+
+		order.PutUint32(b, uint32(v))
+		if SumUint32(uint32(v), order) != Checksum(b[:4]) {
+			t.Errorf("SumUint32 mismatch for %v", order)
+		}
+
+		order.PutUint64(b, v)
+		if SumUint64(v, order) != Checksum(b) {
+			t.Errorf("SumUint64 mismatch for %v", order)
+		}
+	}
+}
+
+// Test that writing integers does not allocate.
+func TestWriteUintNoAlloc(t *testing.T) {
+	var d Digest
+
+	if n := testing.AllocsPerRun(100, func() {
+		d.WriteUint64(42, binary.BigEndian)
+		d.WriteUint32(42, binary.LittleEndian)
+	}); n != 0 {
+		t.Errorf("Writing integers allocated %v times", n)
+	}
+}
+
+// Test that the varint writers match encoding/binary.
+func TestWriteVarint(t *testing.T) {
+	for _, v := range []int64{0, 1, -1, 127, 128, -300, 1 << 40, -1 << 63} {
+		var d, e Digest
+
+		d.WriteVarint(v)
+		e.Write(binary.AppendVarint(nil, v))
+		if d.Sum32() != e.Sum32() {
+			t.Errorf("WriteVarint(%d) mismatch", v)
+		}
+
+		d.Reset()
+		e.Reset()
+		d.WriteUvarint(uint64(v))
+		e.Write(binary.AppendUvarint(nil, uint64(v)))
+		if d.Sum32() != e.Sum32() {
+			t.Errorf("WriteUvarint(%d) mismatch", uint64(v))
+		}
+	}
+}
+
+// Test that length-prefixed composite keys are unambiguous.
+func TestWriteLengthPrefixed(t *testing.T) {
+	var a, b Digest
+
+	a.WriteLengthPrefixedString("user")
+	a.WriteVarint(12)
+	a.WriteLengthPrefixed([]byte("ab"))
+	a.WriteLengthPrefixed([]byte("c"))
+
+	b.WriteLengthPrefixedString("user")
+	b.WriteVarint(12)
+	b.WriteLengthPrefixed([]byte("a"))
+	b.WriteLengthPrefixed([]byte("bc"))
+
+	if a.Sum32() == b.Sum32() {
+		t.Error("Length-prefixed fields are ambiguous")
+	}
+}
+
+// Test the unrolled machine word checksums against SumUint32 and
+// SumUint64.
+func TestChecksumUint(t *testing.T) {
+	for _, v := range []uint64{0, 1, 0xff, 0x12345678, 0xdeadbeefcafebabe, ^uint64(0)} {
+		if res, want := ChecksumUint32(uint32(v)), SumUint32(uint32(v), binary.LittleEndian); res != want {
+			t.Errorf("ChecksumUint32(%#x) returned %08x, expected %08x", uint32(v), res, want)
+		}
+		if res, want := ChecksumUint64(v), SumUint64(v, binary.LittleEndian); res != want {
+			t.Errorf("ChecksumUint64(%#x) returned %08x, expected %08x", v, res, want)
+		}
+	}
+}
+
+func BenchmarkChecksumUint64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		updateSink = ChecksumUint64(uint64(i))
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !purego && !tinygo
+
+package nzaat
+
+import "github.com/caoimhechaos/golang-nzaat/internal/cpu"
+
+// updateAMD64 is update implemented in update_amd64.s, keeping the
+// state in a register and processing four bytes per iteration.
+//
+//go:noescape
+func updateAMD64(s uint32, p []byte) uint32
+
+// update4SSE41 is update4 implemented in update_amd64.s, with the
+// states in the four lanes of an SSE register.
+//
+//go:noescape
+func update4SSE41(s *[4]uint32, p0, p1, p2, p3 []byte)
+
+// updateArch is update for the implementations with archUpdate set.
+func updateArch(s uint32, p []byte) uint32 {
+	return updateAMD64(s, p)
+}
+
+// update4Arch is update4 for the implementations with archUpdate4 set.
+func update4Arch(s *[4]uint32, p0, p1, p2, p3 []byte) {
+	update4SSE41(s, p0, p1, p2, p3)
+}
+
+var archImplementations = []implementation{
+	{
+		name:        "amd64-sse41",
+		archUpdate:  true,
+		archUpdate4: true,
+		supported:   cpu.X86.HasSSE41,
+	},
+	{
+		name:       "amd64",
+		archUpdate: true,
+		supported:  true,
+	},
+}
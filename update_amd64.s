@@ -0,0 +1,97 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !purego && !tinygo
+
+#include "textflag.h"
+
+// NUP absorbs the byte at off(SI) into the state in AX, using DX as
+// scratch: s += b+1; s += s<<10; s ^= s>>6.
+#define NUP(off) \
+	MOVBLZX off(SI), DX; \
+	LEAL    1(AX)(DX*1), AX; \
+	MOVL    AX, DX; \
+	SHLL    $10, DX; \
+	ADDL    DX, AX; \
+	MOVL    AX, DX; \
+	SHRL    $6, DX; \
+	XORL    DX, AX
+
+// func updateAMD64(s uint32, p []byte) uint32
+TEXT ·updateAMD64(SB), NOSPLIT, $0-36
+	MOVL s+0(FP), AX
+	MOVQ p_base+8(FP), SI
+	MOVQ p_len+16(FP), CX
+
+	CMPQ CX, $4
+	JB   tail
+
+loop4:
+	NUP(0)
+	NUP(1)
+	NUP(2)
+	NUP(3)
+	ADDQ $4, SI
+	SUBQ $4, CX
+	CMPQ CX, $4
+	JAE  loop4
+
+tail:
+	TESTQ CX, CX
+	JZ    done
+
+loop1:
+	NUP(0)
+	INCQ SI
+	DECQ CX
+	JNZ  loop1
+
+done:
+	MOVL AX, ret+32(FP)
+	RET
+
+// func update4SSE41(s *[4]uint32, p0, p1, p2, p3 []byte)
+TEXT ·update4SSE41(SB), NOSPLIT, $0-104
+	MOVQ s+0(FP), DI
+	MOVQ p0_base+8(FP), R8
+	MOVQ p0_len+16(FP), CX
+	MOVQ p1_base+32(FP), R9
+	MOVQ p2_base+56(FP), R10
+	MOVQ p3_base+80(FP), R11
+
+	MOVOU   (DI), X0
+	PCMPEQL X2, X2 // All lanes -1, so subtracting X2 adds 1.
+	XORQ    DX, DX
+	TESTQ   CX, CX
+	JZ      done4
+
+loop:
+	// Gather one byte of every lane into X1.
+	MOVBLZX (R8)(DX*1), AX
+	MOVBLZX (R9)(DX*1), BX
+	MOVBLZX (R10)(DX*1), SI
+	MOVBLZX (R11)(DX*1), R12
+	PINSRD  $0, AX, X1
+	PINSRD  $1, BX, X1
+	PINSRD  $2, SI, X1
+	PINSRD  $3, R12, X1
+
+	// s += b+1; s += s<<10; s ^= s>>6
+	PADDL X1, X0
+	PSUBL X2, X0
+	MOVO  X0, X3
+	PSLLL $10, X3
+	PADDL X3, X0
+	MOVO  X0, X3
+	PSRLL $6, X3
+	PXOR  X3, X0
+
+	INCQ DX
+	CMPQ DX, CX
+	JB   loop
+
+done4:
+	MOVOU X0, (DI)
+	RET
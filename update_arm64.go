@@ -0,0 +1,34 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !purego && !tinygo
+
+package nzaat
+
+import "github.com/caoimhechaos/golang-nzaat/internal/cpu"
+
+// update4NEON is update4 implemented in update_arm64.s, with the
+// states in the four lanes of a NEON register.
+//
+//go:noescape
+func update4NEON(s *[4]uint32, p0, p1, p2, p3 []byte)
+
+// updateArch is update for the implementations with archUpdate set.
+func updateArch(s uint32, p []byte) uint32 {
+	return updateGeneric(s, p)
+}
+
+// update4Arch is update4 for the implementations with archUpdate4 set.
+func update4Arch(s *[4]uint32, p0, p1, p2, p3 []byte) {
+	update4NEON(s, p0, p1, p2, p3)
+}
+
+var archImplementations = []implementation{
+	{
+		name:        "arm64-neon",
+		archUpdate4: true,
+		supported:   cpu.ARM64.HasASIMD,
+	},
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+//go:build !purego && !tinygo
+
+#include "textflag.h"
+
+// func update4NEON(s *[4]uint32, p0, p1, p2, p3 []byte)
+TEXT ·update4NEON(SB), NOSPLIT, $0-104
+	MOVD s+0(FP), R0
+	MOVD p0_base+8(FP), R3
+	MOVD p0_len+16(FP), R2
+	MOVD p1_base+32(FP), R4
+	MOVD p2_base+56(FP), R5
+	MOVD p3_base+80(FP), R6
+
+	VLD1 (R0), [V0.S4]
+	MOVW $1, R7
+	VDUP R7, V2.S4
+	CBZ  R2, done
+
+loop:
+	// Gather one byte of every lane into V1.
+	MOVBU.P 1(R3), R8
+	MOVBU.P 1(R4), R9
+	MOVBU.P 1(R5), R10
+	MOVBU.P 1(R6), R11
+	VMOV    R8, V1.S[0]
+	VMOV    R9, V1.S[1]
+	VMOV    R10, V1.S[2]
+	VMOV    R11, V1.S[3]
+
+	// s += b+1; s += s<<10; s ^= s>>6
+	VADD  V1.S4, V0.S4, V0.S4
+	VADD  V2.S4, V0.S4, V0.S4
+	VSHL  $10, V0.S4, V3.S4
+	VADD  V3.S4, V0.S4, V0.S4
+	VUSHR $6, V0.S4, V3.S4
+	VEOR  V3.B16, V0.B16, V0.B16
+
+	SUBS $1, R2, R2
+	BNE  loop
+
+done:
+	VST1 [V0.S4], (R0)
+	RET
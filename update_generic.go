@@ -0,0 +1,77 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+// nup returns the state s after absorbing the octet b.
+func nup(s uint32, b byte) uint32 {
+	s += uint32(b) + 1
+	s += s << 10
+	s ^= s >> 6
+	return s
+}
+
+// updateGeneric returns the state s after absorbing p. Keeping the
+// state in a local variable and unrolling eight times lets the compiler
+// keep it in a register and drop the bounds checks.
+func updateGeneric(s uint32, p []byte) uint32 {
+	for ; len(p) >= 8; p = p[8:] {
+		s = nup(s, p[0])
+		s = nup(s, p[1])
+		s = nup(s, p[2])
+		s = nup(s, p[3])
+		s = nup(s, p[4])
+		s = nup(s, p[5])
+		s = nup(s, p[6])
+		s = nup(s, p[7])
+	}
+	for _, x := range p {
+		s = nup(s, x)
+	}
+	return s
+}
+
+// updateString is updateGeneric for strings.
+func updateString(s uint32, p string) uint32 {
+	for ; len(p) >= 8; p = p[8:] {
+		s = nup(s, p[0])
+		s = nup(s, p[1])
+		s = nup(s, p[2])
+		s = nup(s, p[3])
+		s = nup(s, p[4])
+		s = nup(s, p[5])
+		s = nup(s, p[6])
+		s = nup(s, p[7])
+	}
+	for i := 0; i < len(p); i++ {
+		s = nup(s, p[i])
+	}
+	return s
+}
+
+// update4Generic absorbs len(p0) bytes of each of p0 to p3 into the
+// four independent states s. Interleaving the lanes lets the CPU work
+// on four dependency chains at once.
+func update4Generic(s *[4]uint32, p0, p1, p2, p3 []byte) {
+	var a, b, c, d uint32 = s[0], s[1], s[2], s[3]
+
+	p1, p2, p3 = p1[:len(p0)], p2[:len(p0)], p3[:len(p0)]
+	for i, x := range p0 {
+		a += uint32(x) + 1
+		b += uint32(p1[i]) + 1
+		c += uint32(p2[i]) + 1
+		d += uint32(p3[i]) + 1
+		a += a << 10
+		b += b << 10
+		c += c << 10
+		d += d << 10
+		a ^= a >> 6
+		b ^= b >> 6
+		c ^= c >> 6
+		d ^= d >> 6
+	}
+
+	s[0], s[1], s[2], s[3] = a, b, c, d
+}
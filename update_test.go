@@ -0,0 +1,134 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// updateReference is the plain byte loop update must agree with.
+func updateReference(s uint32, p []byte) uint32 {
+	for _, x := range p {
+		s += uint32(x) + 1
+		s += s << 10
+		s ^= s >> 6
+	}
+	return s
+}
+
+// Test update of every supported implementation against the reference
+// for all lengths around the unrolling boundaries and random states.
+func TestUpdate(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(1, 2))
+	var buf []byte = make([]byte, 100)
+
+	for i := range buf {
+		buf[i] = byte(rng.Uint32())
+	}
+	for _, i := range implementations() {
+		for n := 0; n <= len(buf); n++ {
+			var s uint32 = rng.Uint32()
+			if got, want := i.update(s, buf[:n]), updateReference(s, buf[:n]); got != want {
+				t.Errorf("%s, length %d: got %08x, expected %08x", i.name, n, got, want)
+			}
+		}
+	}
+}
+
+// Test that updateString matches the reference on all lengths around
+// the unrolling boundary.
+func TestUpdateString(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(3, 4))
+	var buf []byte = make([]byte, 40)
+
+	for i := range buf {
+		buf[i] = byte(rng.Uint32())
+	}
+	for n := 0; n <= len(buf); n++ {
+		var s uint32 = rng.Uint32()
+		if got, want := updateString(s, string(buf[:n])), updateReference(s, buf[:n]); got != want {
+			t.Errorf("length %d: got %08x, expected %08x", n, got, want)
+		}
+	}
+}
+
+// Test choosing implementations by name.
+func TestSelectImplementation(t *testing.T) {
+	t.Logf("Using %s", Implementation())
+
+	if i := selectImplementation("generic"); i.name != "generic" {
+		t.Errorf("Selected %s instead of generic", i.name)
+	}
+	var best string = "generic"
+	for _, i := range archImplementations {
+		if i.supported {
+			best = i.name
+			break
+		}
+	}
+	if i := selectImplementation("nonexistent"); i.name != best {
+		t.Errorf("Selected %s for an unknown name, expected %s", i.name, best)
+	}
+}
+
+var updateSink uint32
+
+func BenchmarkUpdate(b *testing.B) {
+	var buf []byte = make([]byte, 64<<10)
+
+	for _, i := range implementations() {
+		b.Run(i.name, func(b *testing.B) {
+			b.SetBytes(int64(len(buf)))
+			for n := 0; n < b.N; n++ {
+				updateSink = i.update(updateSink, buf)
+			}
+		})
+	}
+}
+
+// Test update4 of every supported implementation against the
+// reference, with lanes longer than the common part.
+func TestUpdate4(t *testing.T) {
+	var rng *rand.Rand = rand.New(rand.NewPCG(3, 4))
+	var bufs [4][]byte
+
+	for j := range bufs {
+		bufs[j] = make([]byte, 70+j)
+		for i := range bufs[j] {
+			bufs[j][i] = byte(rng.Uint32())
+		}
+	}
+
+	for _, i := range implementations() {
+		for n := 0; n <= 70; n++ {
+			var s [4]uint32 = [4]uint32{rng.Uint32(), rng.Uint32(), rng.Uint32(), rng.Uint32()}
+			var want [4]uint32
+			for j := range want {
+				want[j] = updateReference(s[j], bufs[j][:n])
+			}
+
+			i.update4(&s, bufs[0][:n], bufs[1], bufs[2], bufs[3])
+			if s != want {
+				t.Errorf("%s, length %d: got %08x, expected %08x", i.name, n, s, want)
+			}
+		}
+	}
+}
+
+func BenchmarkUpdate4(b *testing.B) {
+	var buf []byte = make([]byte, 16<<10)
+
+	for _, i := range implementations() {
+		b.Run(i.name, func(b *testing.B) {
+			var s [4]uint32
+			b.SetBytes(int64(4 * len(buf)))
+			for n := 0; n < b.N; n++ {
+				i.update4(&s, buf, buf, buf, buf)
+			}
+		})
+	}
+}
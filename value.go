@@ -0,0 +1,341 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Option modifies the behaviour of HashValue.
+type Option func(*valueOptions)
+
+type valueOptions struct {
+	seed              Seed
+	seeded            bool
+	includeUnexported bool
+	skipUnsupported   bool
+}
+
+// WithSeed makes HashValue use a Hash seeded with seed.
+func WithSeed(seed Seed) Option {
+	return func(o *valueOptions) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+// IncludeUnexported makes HashValue include unexported struct fields.
+func IncludeUnexported() Option {
+	return func(o *valueOptions) {
+		o.includeUnexported = true
+	}
+}
+
+// SkipUnsupported makes HashValue skip functions, channels and unsafe
+// pointers instead of panicking when it encounters them.
+func SkipUnsupported() Option {
+	return func(o *valueOptions) {
+		o.skipUnsupported = true
+	}
+}
+
+// Kind tags written ahead of each value, so that values of different
+// shapes cannot encode to the same octets.
+const (
+	tagNil       = 0
+	tagBool      = 'b'
+	tagInt       = 'i'
+	tagUint      = 'u'
+	tagFloat     = 'f'
+	tagComplex   = 'c'
+	tagString    = 's'
+	tagBytes     = 'y'
+	tagList      = 'l'
+	tagMap       = 'm'
+	tagStruct    = 'S'
+	tagPointer   = 'p'
+	tagInterface = 'I'
+	tagBinary    = 'B'
+	tagSkipped   = '-'
+)
+
+var binaryMarshalerType = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+
+// HashValue returns the NZAAT hash of a canonical encoding of v, which
+// may be any value made up of basic types, structs, arrays, slices,
+// maps, pointers and interfaces.
+//
+// The encoding is deterministic: struct fields are ordered by name and
+// map entries by the encoding of their keys, and pointers are followed
+// rather than hashed by address. A nil slice, map or pointer hashes
+// differently from an empty slice or map or a pointer to a zero value.
+// Values implementing encoding.BinaryMarshaler, such as time.Time, are
+// hashed by their binary encoding.
+//
+// Unexported struct fields are skipped unless IncludeUnexported is
+// given. Exported fields can be renamed with a struct tag such as
+// `nzaat:"name"` or skipped with `nzaat:"-"`.
+//
+// HashValue panics if v contains a cycle, or a function, channel or
+// unsafe pointer and SkipUnsupported was not given.
+func HashValue(v any, opts ...Option) uint32 {
+	var o valueOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var e *valueEncoder = &valueEncoder{
+		opts:     &o,
+		visiting: make(map[visit]bool),
+	}
+	e.encode(reflect.ValueOf(v))
+
+	var h Hash
+	if o.seeded {
+		h.SetSeed(o.seed)
+	}
+	h.Write(e.buf)
+	return h.Sum32()
+}
+
+type valueEncoder struct {
+	opts     *valueOptions
+	buf      []byte
+	visiting map[visit]bool
+}
+
+// visit identifies a map, pointer or slice being encoded. Like
+// reflect.DeepEqual, it includes the type, since a struct and its first
+// field share an address. Slices are told apart by their length as
+// well, since a slice and a shorter one of the same array do too.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+func (e *valueEncoder) tag(t byte) {
+	e.buf = append(e.buf, t)
+}
+
+func (e *valueEncoder) uint(v uint64) {
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, v)
+}
+
+func (e *valueEncoder) len(n int) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(n))
+}
+
+func (e *valueEncoder) string(s string) {
+	e.len(len(s))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *valueEncoder) float(f float64) {
+	if f == 0 {
+		f = 0
+	}
+	e.uint(math.Float64bits(f))
+}
+
+func (e *valueEncoder) encode(v reflect.Value) {
+	if !v.IsValid() {
+		e.tag(tagNil)
+		return
+	}
+
+	if v.CanInterface() && v.Type().Implements(binaryMarshalerType) &&
+		!(v.Kind() == reflect.Pointer && v.IsNil()) {
+		b, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			panic("nzaat: cannot marshal " + v.Type().String() + ": " + err.Error())
+		}
+		e.tag(tagBinary)
+		e.string(string(b))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		e.tag(tagBool)
+		if v.Bool() {
+			e.buf = append(e.buf, 1)
+		} else {
+			e.buf = append(e.buf, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.tag(tagInt)
+		e.uint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.tag(tagUint)
+		e.uint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.tag(tagFloat)
+		e.float(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		e.tag(tagComplex)
+		e.float(real(v.Complex()))
+		e.float(imag(v.Complex()))
+	case reflect.String:
+		e.tag(tagString)
+		e.string(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			e.tag(tagNil)
+			return
+		}
+		e.enter(v)
+		e.list(v)
+		e.leave(v)
+	case reflect.Array:
+		e.list(v)
+	case reflect.Map:
+		if v.IsNil() {
+			e.tag(tagNil)
+			return
+		}
+		e.enter(v)
+		e.encodeMap(v)
+		e.leave(v)
+	case reflect.Struct:
+		e.encodeStruct(v)
+	case reflect.Pointer:
+		if v.IsNil() {
+			e.tag(tagNil)
+			return
+		}
+		e.enter(v)
+		e.tag(tagPointer)
+		e.encode(v.Elem())
+		e.leave(v)
+	case reflect.Interface:
+		if v.IsNil() {
+			e.tag(tagNil)
+			return
+		}
+		e.tag(tagInterface)
+		e.string(v.Elem().Type().String())
+		e.encode(v.Elem())
+	default:
+		if !e.opts.skipUnsupported {
+			panic("nzaat: cannot hash value of type " + v.Type().String())
+		}
+		e.tag(tagSkipped)
+	}
+}
+
+// Keep track of the maps, pointers and slices we are inside of, so
+// cycles are reported rather than recursing forever.
+func (e *valueEncoder) enter(v reflect.Value) {
+	var k visit = visitOf(v)
+	if e.visiting[k] {
+		panic("nzaat: cannot hash cyclic value of type " + v.Type().String())
+	}
+	e.visiting[k] = true
+}
+
+func (e *valueEncoder) leave(v reflect.Value) {
+	delete(e.visiting, visitOf(v))
+}
+
+func visitOf(v reflect.Value) visit {
+	if v.Kind() == reflect.Slice {
+		return visit{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}
+	}
+	return visit{ptr: v.Pointer(), typ: v.Type(), len: -1}
+}
+
+func (e *valueEncoder) list(v reflect.Value) {
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		e.tag(tagBytes)
+		e.len(v.Len())
+		for i := 0; i < v.Len(); i++ {
+			e.buf = append(e.buf, byte(v.Index(i).Uint()))
+		}
+		return
+	}
+
+	e.tag(tagList)
+	e.len(v.Len())
+	for i := 0; i < v.Len(); i++ {
+		e.encode(v.Index(i))
+	}
+}
+
+func (e *valueEncoder) encodeMap(v reflect.Value) {
+	type pair struct {
+		key, value []byte
+	}
+	var pairs []pair = make([]pair, 0, v.Len())
+	var start int = len(e.buf)
+
+	for it := v.MapRange(); it.Next(); {
+		e.encode(it.Key())
+		var mid int = len(e.buf)
+		e.encode(it.Value())
+		pairs = append(pairs, pair{
+			key:   append([]byte(nil), e.buf[start:mid]...),
+			value: append([]byte(nil), e.buf[mid:]...),
+		})
+		e.buf = e.buf[:start]
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].key, pairs[j].key) < 0
+	})
+
+	e.tag(tagMap)
+	e.len(len(pairs))
+	for _, p := range pairs {
+		e.buf = append(e.buf, p.key...)
+		e.buf = append(e.buf, p.value...)
+	}
+}
+
+func (e *valueEncoder) encodeStruct(v reflect.Value) {
+	type field struct {
+		name  string
+		index int
+	}
+	var t reflect.Type = v.Type()
+	var fields []field
+
+	for i := 0; i < t.NumField(); i++ {
+		var f reflect.StructField = t.Field(i)
+		var name string = f.Name
+
+		if !f.IsExported() && !e.opts.includeUnexported {
+			continue
+		}
+		if tag, ok := f.Tag.Lookup("nzaat"); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, field{name: name, index: i})
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
+
+	e.tag(tagStruct)
+	e.len(len(fields))
+	for _, f := range fields {
+		e.string(f.name)
+		e.encode(v.Field(f.index))
+	}
+}
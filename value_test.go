@@ -0,0 +1,127 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"testing"
+	"time"
+)
+
+type configA struct {
+	Name    string
+	Ports   []int
+	Labels  map[string]string
+	Comment string `nzaat:"-"`
+	secret  string
+}
+
+type configB struct {
+	Labels map[string]string
+	Ports  []int
+	Title  string `nzaat:"Name"`
+}
+
+// Test that field order, skipped fields and map order do not matter.
+func TestHashValueStruct(t *testing.T) {
+	var a = configA{
+		Name:    "web",
+		Ports:   []int{80, 443},
+		Labels:  map[string]string{"a": "1", "b": "2", "c": "3"},
+		Comment: "ignored",
+		secret:  "ignored",
+	}
+	var b = configB{
+		Labels: map[string]string{"c": "3", "b": "2", "a": "1"},
+		Ports:  []int{80, 443},
+		Title:  "web",
+	}
+
+	if HashValue(a) != HashValue(b) {
+		t.Error("Equivalent structs hash differently")
+	}
+	if HashValue(a) == HashValue(a, IncludeUnexported()) {
+		t.Error("Unexported field did not change the hash")
+	}
+
+	b.Ports = []int{443, 80}
+	if HashValue(a) == HashValue(b) {
+		t.Error("Slice order did not change the hash")
+	}
+}
+
+// Test that nil and empty values are distinguished.
+func TestHashValueNil(t *testing.T) {
+	var zero int
+
+	if HashValue([]int(nil)) == HashValue([]int{}) {
+		t.Error("nil and empty slices hash equally")
+	}
+	if HashValue(map[int]int(nil)) == HashValue(map[int]int{}) {
+		t.Error("nil and empty maps hash equally")
+	}
+	if HashValue((*int)(nil)) == HashValue(&zero) {
+		t.Error("nil pointer and pointer to zero hash equally")
+	}
+}
+
+// Test that values are hashed through BinaryMarshaler.
+func TestHashValueTime(t *testing.T) {
+	var a time.Time = time.Unix(1000, 0).UTC()
+	var b time.Time = time.Unix(1001, 0).UTC()
+
+	if HashValue(a) == HashValue(b) {
+		t.Error("Different times hash equally")
+	}
+}
+
+// Test that unsupported values and cycles are reported.
+func TestHashValueUnsupported(t *testing.T) {
+	type node struct {
+		Next *node
+		F    func()
+	}
+	var n node
+	n.Next = &n
+
+	HashValue(node{F: func() {}}, SkipUnsupported())
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Cyclic value did not panic")
+		}
+	}()
+	HashValue(&n, SkipUnsupported())
+}
+
+// Test that a pointer to the first field of a struct is not mistaken
+// for a pointer back to the struct.
+func TestHashValueFirstFieldPointer(t *testing.T) {
+	type first struct {
+		First int
+		P     *int
+	}
+	var s first = first{First: 1}
+	s.P = &s.First
+
+	var one int = 1
+	if HashValue(&s) != HashValue(&first{First: 1, P: &one}) {
+		t.Error("Pointer to the first field changed the hash")
+	}
+}
+
+// Test that a slice containing itself is reported as a cycle.
+func TestHashValueCyclicSlice(t *testing.T) {
+	type list []list
+	var l list = make(list, 1)
+	l[0] = l
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Self-referential slice did not panic")
+		}
+	}()
+	HashValue(l)
+}
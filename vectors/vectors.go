@@ -0,0 +1,202 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+// Package vectors generates, reads and writes golden test vectors of
+// NZAAT and NZAT, so that implementations in other languages can be
+// tested against exactly the same inputs and results as this one.
+//
+// Vectors are exchanged as JSON or CSV. Inputs are hex encoded, and
+// checksums are written as 8 hex digits. The JSON format is
+//
+//	{"version": 1, "vectors": [
+//	  {"name": "empty", "input": "", "nzaat": "00000000", "nzat": "00048009"},
+//	  ...
+//	]}
+//
+// and the CSV format has the columns name, input, nzaat and nzat, with
+// a header line naming them.
+//
+//go:generate go run ../cmd/nzaatvectors -format json -o testdata/vectors.json
+//go:generate go run ../cmd/nzaatvectors -format csv -o testdata/vectors.csv
+package vectors
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+// Version is the version of the file format written by this package.
+const Version = 1
+
+// DefaultSeed is the seed of the random inputs in the published vectors.
+const DefaultSeed = 20131107
+
+// Vector is a single test vector.
+type Vector struct {
+	Name  string
+	Input []byte
+	NZAAT uint32
+	NZAT  uint32
+}
+
+func newVector(name string, input []byte) Vector {
+	return Vector{
+		Name:  name,
+		Input: input,
+		NZAAT: nzaat.Checksum(input),
+		NZAT:  nzaat.ChecksumNZAT(input),
+	}
+}
+
+// Generate returns the standard set of vectors: the empty input, each
+// single octet value, all octet values in sequence, runs of NUL octets
+// of various lengths, and random inputs generated from seed.
+func Generate(seed uint64) []Vector {
+	var vs []Vector = []Vector{newVector("empty", nil)}
+	var all []byte = make([]byte, 256)
+
+	for i := range all {
+		all[i] = byte(i)
+		vs = append(vs, newVector(fmt.Sprintf("octet-%02x", i), all[i:i+1]))
+	}
+	vs = append(vs, newVector("all-octets", all))
+
+	for _, n := range []int{1, 2, 3, 4, 5, 8, 16, 255, 256, 1000, 4096} {
+		vs = append(vs, newVector(fmt.Sprintf("nul-%d", n), make([]byte, n)))
+	}
+
+	var rnd *rand.Rand = rand.New(rand.NewPCG(seed, 0))
+	for i, n := range []int{1, 7, 16, 31, 64, 100, 256, 1000, 1024} {
+		var input []byte = make([]byte, n)
+		for j := range input {
+			input[j] = byte(rnd.Uint32())
+		}
+		vs = append(vs, newVector(fmt.Sprintf("random-%d-%d", i, n), input))
+	}
+
+	return vs
+}
+
+type jsonVector struct {
+	Name  string `json:"name"`
+	Input string `json:"input"`
+	NZAAT string `json:"nzaat"`
+	NZAT  string `json:"nzat"`
+}
+
+type jsonFile struct {
+	Version int          `json:"version"`
+	Vectors []jsonVector `json:"vectors"`
+}
+
+// WriteJSON writes vs to w in the JSON format.
+func WriteJSON(w io.Writer, vs []Vector) error {
+	var f jsonFile = jsonFile{Version: Version, Vectors: make([]jsonVector, len(vs))}
+
+	for i, v := range vs {
+		f.Vectors[i] = jsonVector{
+			Name:  v.Name,
+			Input: hex.EncodeToString(v.Input),
+			NZAAT: nzaat.FormatSum(v.NZAAT),
+			NZAT:  nzaat.FormatSum(v.NZAT),
+		}
+	}
+
+	var enc *json.Encoder = json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(f)
+}
+
+// ReadJSON reads vectors in the JSON format from r.
+func ReadJSON(r io.Reader) ([]Vector, error) {
+	var f jsonFile
+
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	if f.Version != Version {
+		return nil, fmt.Errorf("vectors: unsupported version %d", f.Version)
+	}
+
+	var vs []Vector = make([]Vector, len(f.Vectors))
+	for i, jv := range f.Vectors {
+		var err error
+		if vs[i], err = parseVector(jv.Name, jv.Input, jv.NZAAT, jv.NZAT); err != nil {
+			return nil, err
+		}
+	}
+	return vs, nil
+}
+
+var csvHeader = []string{"name", "input", "nzaat", "nzat"}
+
+// WriteCSV writes vs to w in the CSV format.
+func WriteCSV(w io.Writer, vs []Vector) error {
+	var cw *csv.Writer = csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, v := range vs {
+		if err := cw.Write([]string{
+			v.Name,
+			hex.EncodeToString(v.Input),
+			nzaat.FormatSum(v.NZAAT),
+			nzaat.FormatSum(v.NZAT),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads vectors in the CSV format from r.
+func ReadCSV(r io.Reader) ([]Vector, error) {
+	var cr *csv.Reader = csv.NewReader(r)
+	var vs []Vector
+
+	cr.FieldsPerRecord = len(csvHeader)
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || records[0][0] != csvHeader[0] {
+		return nil, fmt.Errorf("vectors: missing CSV header")
+	}
+
+	for _, rec := range records[1:] {
+		var v Vector
+		if v, err = parseVector(rec[0], rec[1], rec[2], rec[3]); err != nil {
+			return nil, err
+		}
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
+
+func parseVector(name, input, sumNZAAT, sumNZAT string) (Vector, error) {
+	var v Vector = Vector{Name: name}
+	var err error
+
+	if v.Input, err = hex.DecodeString(input); err != nil {
+		return v, fmt.Errorf("vectors: bad input of %s: %w", strconv.Quote(name), err)
+	}
+	if v.NZAAT, err = nzaat.ParseSum(sumNZAAT); err != nil {
+		return v, fmt.Errorf("vectors: bad NZAAT of %s: %w", strconv.Quote(name), err)
+	}
+	if v.NZAT, err = nzaat.ParseSum(sumNZAT); err != nil {
+		return v, fmt.Errorf("vectors: bad NZAT of %s: %w", strconv.Quote(name), err)
+	}
+	return v, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package vectors
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/caoimhechaos/golang-nzaat"
+)
+
+func checkVectors(t *testing.T, file string, read func(*os.File) ([]Vector, error)) {
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	vs, err := read(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range vs {
+		if res := nzaat.Checksum(v.Input); res != v.NZAAT {
+			t.Errorf("%s: NZAAT %x, expected %x", v.Name, res, v.NZAAT)
+		}
+		if res := nzaat.ChecksumNZAT(v.Input); res != v.NZAT {
+			t.Errorf("%s: NZAT %x, expected %x", v.Name, res, v.NZAT)
+		}
+	}
+
+	// The published files must not go stale.
+	if !reflect.DeepEqual(normalize(vs), normalize(Generate(DefaultSeed))) {
+		t.Errorf("%s differs from the generated vectors; run go generate", file)
+	}
+}
+
+// Treat nil and empty inputs alike.
+func normalize(vs []Vector) []Vector {
+	for i := range vs {
+		if len(vs[i].Input) == 0 {
+			vs[i].Input = nil
+		}
+	}
+	return vs
+}
+
+// Test the published JSON vectors.
+func TestJSON(t *testing.T) {
+	checkVectors(t, "testdata/vectors.json", func(f *os.File) ([]Vector, error) {
+		return ReadJSON(f)
+	})
+}
+
+// Test the published CSV vectors.
+func TestCSV(t *testing.T) {
+	checkVectors(t, "testdata/vectors.csv", func(f *os.File) ([]Vector, error) {
+		return ReadCSV(f)
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// NonZeroReport is the result of checking the NZAT finalization over a
+// range of states with VerifyNonZero.
+type NonZeroReport struct {
+	// States is the number of states checked.
+	States uint64
+
+	// Zeroes lists the states for which NZAT returned 0. It is empty
+	// if NZAT keeps its promise.
+	Zeroes []uint32
+
+	// Colliding lists the states other than 0 whose NZAT result is the
+	// same as that of state 0, which is mapped to the state 1 after
+	// mixing. Over all 2³² states, this is exactly one state: the one
+	// which MIX maps to 1. It is NZAT's single voluntary collision.
+	Colliding []uint32
+}
+
+// VerifyNonZero computes the NZAT result of the count states starting
+// at first, spread over all CPUs, and reports any which are 0 or which
+// collide with the state 0. Checking all 2³² states, with first 0 and
+// count 1<<32, takes a few seconds to minutes depending on the machine.
+func VerifyNonZero(first uint32, count uint64) *NonZeroReport {
+	var workers int = runtime.GOMAXPROCS(0)
+	var chunk uint64 = (count + uint64(workers) - 1) / uint64(workers)
+	var zeroSum uint32 = (&nzatDigest{}).Sum32()
+	var report *NonZeroReport = &NonZeroReport{States: count}
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+
+	for start := uint64(0); start < count; start += chunk {
+		var n uint64 = min(chunk, count-start)
+
+		wg.Add(1)
+		go func(s uint32, n uint64) {
+			defer wg.Done()
+			var zeroes, colliding []uint32
+
+			for ; n > 0; n-- {
+				var sum uint32 = (&nzatDigest{Digest: Digest(s)}).Sum32()
+				if sum == 0 {
+					zeroes = append(zeroes, s)
+				} else if sum == zeroSum && s != 0 {
+					colliding = append(colliding, s)
+				}
+				s++
+			}
+
+			mtx.Lock()
+			report.Zeroes = append(report.Zeroes, zeroes...)
+			report.Colliding = append(report.Colliding, colliding...)
+			mtx.Unlock()
+		}(first+uint32(start), n)
+	}
+	wg.Wait()
+
+	sort.Slice(report.Zeroes, func(i, j int) bool {
+		return report.Zeroes[i] < report.Zeroes[j]
+	})
+	sort.Slice(report.Colliding, func(i, j int) bool {
+		return report.Colliding[i] < report.Colliding[j]
+	})
+	return report
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"flag"
+	"testing"
+)
+
+var exhaustive = flag.Bool("nzat.exhaustive", false,
+	"verify the NZAT finalization for all 2³² states")
+
+// The one state which MIX maps to 1, and which thus collides with 0.
+const nzatCollision = 0xC00FFC01
+
+// Test the neighbourhoods of the interesting states.
+func TestVerifyNonZero(t *testing.T) {
+	var s uint32 = nzatCollision
+
+	s += s << 10
+	s ^= s >> 6
+	if s != 1 {
+		t.Fatalf("MIX(%x) = %x, expected 1", uint32(nzatCollision), s)
+	}
+
+	for _, first := range []uint32{0, nzatCollision - 1<<19, 0xFFF00000} {
+		var r *NonZeroReport = VerifyNonZero(first, 1<<20)
+		if len(r.Zeroes) != 0 {
+			t.Errorf("NZAT returned 0 for states %x", r.Zeroes)
+		}
+		if first == nzatCollision-1<<19 {
+			if len(r.Colliding) != 1 || r.Colliding[0] != nzatCollision {
+				t.Errorf("Expected the collision with %x, got %x",
+					uint32(nzatCollision), r.Colliding)
+			}
+		} else if len(r.Colliding) != 0 {
+			t.Errorf("Unexpected collisions with 0: %x", r.Colliding)
+		}
+	}
+}
+
+// Test all states if requested with -nzat.exhaustive.
+func TestVerifyNonZeroExhaustive(t *testing.T) {
+	if !*exhaustive {
+		t.Skip("Run with -nzat.exhaustive to check all states")
+	}
+
+	var r *NonZeroReport = VerifyNonZero(0, 1<<32)
+	if r.States != 1<<32 || len(r.Zeroes) != 0 {
+		t.Errorf("NZAT returned 0 for states %x", r.Zeroes)
+	}
+	if len(r.Colliding) != 1 || r.Colliding[0] != nzatCollision {
+		t.Errorf("Expected exactly the collision with %x, got %x",
+			uint32(nzatCollision), r.Colliding)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+// Fold16 reduces the 32-bit checksum sum to 16 bits by XORing its upper
+// and lower halves, for records which only have room for two octets of
+// checksum.
+//
+// Every output bit depends on two input bits, so any single bit flip of
+// sum still changes the result, and as the finalization of NZAAT spreads
+// each input octet over all 32 bits, the folded values are distributed
+// as evenly as the full ones. Simply truncating the checksum would throw
+// away half of the bits instead. Note that Fold16 of an NZAT checksum
+// can be 0.
+func Fold16(sum uint32) uint16 {
+	return uint16(sum>>16) ^ uint16(sum)
+}
+
+// Fold8 reduces the 32-bit checksum sum to 8 bits by XORing all four of
+// its octets, for records which only have room for a single octet of
+// checksum. The same considerations as for Fold16 apply.
+func Fold8(sum uint32) uint8 {
+	var f uint16 = Fold16(sum)
+	return uint8(f>>8) ^ uint8(f)
+}
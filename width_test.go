@@ -0,0 +1,39 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// Test the folding arithmetic.
+func TestFold(t *testing.T) {
+	if f := Fold16(0x434B78B4); f != 0x434B^0x78B4 {
+		t.Errorf("Fold16 returned %x", f)
+	}
+	if f := Fold8(0x434B78B4); f != 0x43^0x4B^0x78^0xB4 {
+		t.Errorf("Fold8 returned %x", f)
+	}
+}
+
+// Test that folded checksums of sequential keys fill all buckets
+// evenly.
+func TestFold8Distribution(t *testing.T) {
+	var counts [256]int
+	var b [4]byte
+
+	for i := uint32(0); i < 256*64; i++ {
+		binary.BigEndian.PutUint32(b[:], i)
+		counts[Fold8(Checksum(b[:]))]++
+	}
+
+	for v, n := range counts {
+		if n < 32 || n > 96 {
+			t.Errorf("Bucket %d has %d entries, expected around 64", v, n)
+		}
+	}
+}
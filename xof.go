@@ -0,0 +1,50 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import "encoding/binary"
+
+// SumN returns n octets of output derived from the current state, for
+// callers which need identifiers longer than 32 bits. It does not
+// change the state.
+//
+// The output is made up of 32-bit big-endian blocks. Block 0 is the
+// regular checksum, so SumN(4) equals Sum(nil). Block i > 0 is the
+// checksum of the state after the four octets of the big-endian block
+// number i have been added to it. The last block is truncated to make
+// up n octets.
+//
+// Note that the extra blocks are derived from the same 32-bit state, so
+// the output can't have more than 2³² distinct values regardless of n.
+// Longer outputs avoid the collisions added by truncating or combining
+// several checksums ad hoc, not those of the state itself.
+//
+// SumN panics if n is negative.
+func (d *Digest) SumN(n int) []byte {
+	if n < 0 {
+		panic("nzaat: negative output length")
+	}
+
+	var out []byte = make([]byte, 0, (n+3)&^3)
+
+	for i := uint32(0); len(out) < n; i++ {
+		var s Digest = *d
+		if i > 0 {
+			s.WriteUint32(i, binary.BigEndian)
+		}
+		out = s.Sum(out)
+	}
+
+	return out[:n]
+}
+
+// ChecksumN returns n octets of NZAAT output for data, as described for
+// Digest.SumN. It panics if n is negative.
+func ChecksumN(data []byte, n int) []byte {
+	var d Digest
+	d.Write(data)
+	return d.SumN(n)
+}
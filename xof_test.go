@@ -0,0 +1,51 @@
+// Copyright 2026 Caoimhe Chaos <caoimhechaos@protonmail.com>
+// All rights reserved.
+// Use of this source code is governed by a BSD-style license that can
+// be found in the LICENSE file.
+
+package nzaat
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// Test that the first block is the regular checksum.
+func TestSumNPrefix(t *testing.T) {
+	var d Digest
+
+	d.Write([]byte("abc"))
+	if !bytes.Equal(d.SumN(4), d.Sum(nil)) {
+		t.Errorf("SumN(4) = %x, expected %x", d.SumN(4), d.Sum(nil))
+	}
+	if binary.BigEndian.Uint32(ChecksumN([]byte("abc"), 16)) != 0xC3E39E2D {
+		t.Error("ChecksumN does not start with the checksum")
+	}
+}
+
+// Test lengths and that shorter outputs are prefixes of longer ones.
+func TestSumNLengths(t *testing.T) {
+	var long []byte = ChecksumN([]byte("message digest"), 33)
+
+	for n := 0; n <= 33; n++ {
+		var out []byte = ChecksumN([]byte("message digest"), n)
+		if !bytes.Equal(out, long[:n]) {
+			t.Errorf("ChecksumN(%d) = %x is not a prefix of %x", n, out, long)
+		}
+	}
+
+	if bytes.Equal(long[:4], long[4:8]) || bytes.Equal(long[4:8], long[8:12]) {
+		t.Errorf("Blocks repeat: %x", long)
+	}
+}
+
+// Test that a negative length panics with a descriptive message.
+func TestSumNNegative(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "nzaat: negative output length" {
+			t.Errorf("Negative length panicked with %v", r)
+		}
+	}()
+	ChecksumN(nil, -1)
+}